@@ -0,0 +1,5 @@
+data "github_app_token" "this" {
+  app_id          = "123456"
+  installation_id = "78910"
+  signer_command  = ["kms-jwt-signer", "--key", "projects/example/locations/global/keyRings/github/cryptoKeys/app"]
+}
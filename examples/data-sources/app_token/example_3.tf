@@ -0,0 +1,11 @@
+data "github_app_token" "this" {
+  app_id           = "123456"
+  installation_id  = "78910"
+  private_key_file = "foo/bar.pem"
+
+  permissions = {
+    contents      = "read"
+    pull_requests = "write"
+  }
+  repositories = ["my-repo"]
+}
@@ -0,0 +1,15 @@
+data "github_branch_effective_rules" "main" {
+  repository = "example"
+  branch     = "main"
+
+  lifecycle {
+    postcondition {
+      condition     = try(self.rules[0].pull_request[0].required_approving_review_count, 0) >= 2
+      error_message = "The main branch must require at least 2 approving reviews."
+    }
+  }
+}
+
+output "rule_sources" {
+  value = [for source in data.github_branch_effective_rules.main.sources : "${source.source_type}: ${source.source}"]
+}
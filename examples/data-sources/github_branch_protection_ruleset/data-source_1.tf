@@ -0,0 +1,51 @@
+data "github_branch_protection_ruleset" "main" {
+  repository = "example"
+  pattern    = "main"
+}
+
+resource "github_repository_ruleset" "main" {
+  name        = "main"
+  repository  = "example"
+  target      = "branch"
+  enforcement = "evaluate"
+
+  conditions {
+    ref_name {
+      include = data.github_branch_protection_ruleset.main.conditions[0].ref_name[0].include
+      exclude = []
+    }
+  }
+
+  dynamic "bypass_actors" {
+    for_each = data.github_branch_protection_ruleset.main.bypass_actors
+
+    content {
+      actor_id    = bypass_actors.value.actor_id
+      actor_type  = bypass_actors.value.actor_type
+      bypass_mode = bypass_actors.value.bypass_mode
+    }
+  }
+
+  rules {
+    deletion                = data.github_branch_protection_ruleset.main.rules[0].deletion
+    non_fast_forward        = data.github_branch_protection_ruleset.main.rules[0].non_fast_forward
+    required_linear_history = data.github_branch_protection_ruleset.main.rules[0].required_linear_history
+    required_signatures     = data.github_branch_protection_ruleset.main.rules[0].required_signatures
+
+    dynamic "pull_request" {
+      for_each = data.github_branch_protection_ruleset.main.rules[0].pull_request
+
+      content {
+        required_approving_review_count   = pull_request.value.required_approving_review_count
+        dismiss_stale_reviews_on_push     = pull_request.value.dismiss_stale_reviews_on_push
+        require_code_owner_review         = pull_request.value.require_code_owner_review
+        require_last_push_approval        = pull_request.value.require_last_push_approval
+        required_review_thread_resolution = pull_request.value.required_review_thread_resolution
+      }
+    }
+  }
+}
+
+output "unsupported_settings" {
+  value = data.github_branch_protection_ruleset.main.unsupported_settings
+}
@@ -0,0 +1,39 @@
+resource "github_organization_ruleset" "example" {
+  name        = "example"
+  target      = "branch"
+  enforcement = var.promote ? "active" : "evaluate"
+
+  conditions {
+    ref_name {
+      include = ["~DEFAULT_BRANCH"]
+      exclude = []
+    }
+
+    repository_name {
+      include = ["~ALL"]
+      exclude = []
+    }
+  }
+
+  rules {
+    required_signatures = true
+  }
+
+  lifecycle {
+    precondition {
+      condition     = !var.promote || data.github_ruleset_rule_suites.example.fail_count == 0
+      error_message = "The ruleset would have blocked ${data.github_ruleset_rule_suites.example.fail_count} pushes in the last week (${join(", ", data.github_ruleset_rule_suites.example.failing_rule_types)})."
+    }
+  }
+}
+
+data "github_ruleset_rule_suites" "example" {
+  # Referencing github_organization_ruleset.example.ruleset_id would create a cycle with the precondition.
+  ruleset_id  = 42
+  time_period = "week"
+}
+
+variable "promote" {
+  type    = bool
+  default = false
+}
@@ -0,0 +1,15 @@
+data "github_repository_codeowners" "example" {
+  repository = "example-repository"
+
+  content = <<-EOT
+    * @example/maintainers
+    /docs/ @octocat
+  EOT
+}
+
+resource "github_repository_file" "codeowners" {
+  repository     = "example-repository"
+  file           = data.github_repository_codeowners.example.path
+  content        = data.github_repository_codeowners.example.content
+  commit_message = "Update CODEOWNERS"
+}
@@ -0,0 +1,10 @@
+ephemeral "github_actions_removal_token" "example" {
+  repository = "example-repo"
+}
+
+resource "vault_kv_secret_v2" "example" {
+  mount                = "secret"
+  name                 = "github/runner-removal-token"
+  data_json_wo         = jsonencode({ token = ephemeral.github_actions_removal_token.example.token })
+  data_json_wo_version = 1
+}
@@ -0,0 +1,17 @@
+ephemeral "github_app_token" "example" {
+  app_id          = var.app_id
+  installation_id = var.installation_id
+  pem_file        = var.app_pem
+  repositories    = ["example-repo"]
+
+  permissions = {
+    contents = "read"
+  }
+}
+
+resource "vault_kv_secret_v2" "example" {
+  mount                = "secret"
+  name                 = "github/app-token"
+  data_json_wo         = jsonencode({ token = ephemeral.github_app_token.example.token })
+  data_json_wo_version = 1
+}
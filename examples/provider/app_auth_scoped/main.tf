@@ -0,0 +1,12 @@
+provider "github" {
+  owner = "my-org"
+
+  app_auth {
+    permissions = {
+      contents      = "read"
+      metadata      = "read"
+      pull_requests = "write"
+    }
+    repositories = ["my-repo"]
+  }
+}
@@ -0,0 +1,11 @@
+provider "github" {
+  owner = var.github_organization
+
+  app_auth {
+    id              = var.github_app_id              # or `GITHUB_APP_ID`
+    installation_id = var.github_app_installation_id # or `GITHUB_APP_INSTALLATION_ID`
+
+    # or `GITHUB_APP_SIGNER_COMMAND`
+    signer_command = ["kms-jwt-signer", "--key", var.github_app_kms_key]
+  }
+}
@@ -0,0 +1,7 @@
+provider "github" {
+  owner           = "my-org"
+  cache_path      = "/var/cache/terraform"
+  app_token_cache = true
+
+  app_auth {}
+}
@@ -0,0 +1,11 @@
+provider "github" {
+  owner = "octocat"
+
+  auth_mode = "oidc" # or `GITHUB_AUTH_MODE=oidc`
+
+  oidc_auth {
+    token_url     = "https://sts.example.com/exchange" # or `GITHUB_OIDC_TOKEN_URL`
+    id_token_file = "/var/run/secrets/tokens/github"   # or `GITHUB_OIDC_ID_TOKEN_FILE`, or the JWT in `GITHUB_OIDC_ID_TOKEN`
+    scope         = "octocat"                          # or `GITHUB_OIDC_SCOPE`
+  }
+}
@@ -0,0 +1,24 @@
+provider "github" {
+  owner = "octo-org"
+
+  token = var.token # or `GITHUB_TOKEN`
+
+  owners {
+    name = "octo-org-public" # uses the provider authentication
+  }
+
+  owners {
+    name = "octo-org-archive"
+
+    app_auth {
+      id              = var.app_id
+      installation_id = var.app_installation_id
+      pem_file        = var.app_pem_file
+    }
+  }
+}
+
+resource "github_repository" "example" {
+  owner = "octo-org-archive"
+  name  = "example"
+}
@@ -0,0 +1,9 @@
+provider "github" {
+  owner = "my-org"
+
+  rate_limit_budget {
+    min_remaining    = 200
+    max_wait_seconds = 120
+    warn_remaining   = 1000
+  }
+}
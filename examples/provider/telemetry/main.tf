@@ -0,0 +1,4 @@
+provider "github" {
+  owner          = "my-org"
+  telemetry_file = "${path.root}/.terraform/github-telemetry.jsonl"
+}
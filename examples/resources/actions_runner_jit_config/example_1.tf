@@ -0,0 +1,15 @@
+resource "github_actions_runner_jit_config" "example" {
+  repository = "example-repository"
+  name       = "example-runner"
+  labels     = ["self-hosted", "linux"]
+}
+
+resource "kubernetes_secret" "runner" {
+  metadata {
+    name = "example-runner"
+  }
+
+  data = {
+    jitconfig = github_actions_runner_jit_config.example.encoded_jit_config
+  }
+}
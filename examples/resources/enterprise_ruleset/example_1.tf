@@ -0,0 +1,79 @@
+resource "github_enterprise_ruleset" "example" {
+  enterprise_slug = "example-enterprise"
+  name            = "example"
+  target          = "branch"
+  enforcement     = "active"
+
+  conditions {
+    organization_name {
+      include = ["~ALL"]
+      exclude = ["example-sandbox"]
+    }
+
+    repository_name {
+      include = ["~ALL"]
+      exclude = []
+    }
+
+    ref_name {
+      include = ["~DEFAULT_BRANCH"]
+      exclude = []
+    }
+  }
+
+  bypass_actors {
+    actor_type  = "EnterpriseOwner"
+    bypass_mode = "always"
+  }
+
+  rules {
+    deletion                = true
+    non_fast_forward        = true
+    required_linear_history = true
+
+    pull_request {
+      required_approving_review_count   = 1
+      required_review_thread_resolution = true
+    }
+
+    merge_queue {
+      merge_method = "SQUASH"
+    }
+  }
+}
+
+# Example with organization and repository properties
+resource "github_enterprise_ruleset" "example_properties" {
+  enterprise_slug = "example-enterprise"
+  name            = "example-properties"
+  target          = "branch"
+  enforcement     = "evaluate"
+
+  conditions {
+    organization_property {
+      include = [{
+        name            = "environment"
+        property_values = ["production"]
+      }]
+      exclude = []
+    }
+
+    repository_property {
+      include = [{
+        name            = "visibility"
+        source          = "system"
+        property_values = ["public"]
+      }]
+      exclude = []
+    }
+
+    ref_name {
+      include = ["~ALL"]
+      exclude = []
+    }
+  }
+
+  rules {
+    required_signatures = true
+  }
+}
@@ -0,0 +1,9 @@
+# Write-only Secret Example
+
+resource "github_actions_environment_secret" "example" {
+  repository       = "example-repo"
+  environment      = "example-environment"
+  secret_name      = "EXAMPLE_SECRET_NAME"
+  value_wo         = var.secret_value
+  value_wo_version = 1
+}
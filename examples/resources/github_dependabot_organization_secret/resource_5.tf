@@ -0,0 +1,8 @@
+# Write-only Secret Example
+
+resource "github_dependabot_organization_secret" "example" {
+  secret_name      = "EXAMPLE_SECRET_NAME"
+  value_wo         = var.secret_value
+  value_wo_version = 1
+  visibility       = "all"
+}
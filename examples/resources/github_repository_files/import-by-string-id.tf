@@ -0,0 +1,4 @@
+import {
+  to = github_repository_files.example
+  id = "example:main:.gitignore,.github/CODEOWNERS"
+}
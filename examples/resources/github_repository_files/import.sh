@@ -0,0 +1 @@
+terraform import github_repository_files.example example:main:.gitignore,.github/CODEOWNERS
@@ -0,0 +1,31 @@
+resource "github_repository" "example" {
+  name      = "example"
+  auto_init = true
+}
+
+resource "github_repository_files" "example" {
+  repository          = github_repository.example.name
+  branch              = "main"
+  commit_message      = "Seed repository"
+  commit_author       = "Terraform User"
+  commit_email        = "terraform@example.com"
+  overwrite_on_create = true
+
+  file {
+    path    = ".gitignore"
+    content = "**/*.tfstate"
+  }
+
+  file {
+    path    = ".github/CODEOWNERS"
+    content = "* @example/maintainers"
+  }
+
+  dynamic "file" {
+    for_each = fileset("${path.module}/workflows", "*.yml")
+    content {
+      path    = ".github/workflows/${file.value}"
+      content = file("${path.module}/workflows/${file.value}")
+    }
+  }
+}
@@ -0,0 +1,15 @@
+# Repository Secrets Example
+
+resource "github_secret_set" "example" {
+  secret_name  = "REGISTRY_TOKEN"
+  value        = var.registry_token
+  secret_types = ["actions", "codespaces", "dependabot"]
+
+  target {
+    repository = "example-repository-1"
+  }
+
+  target {
+    repository = "example-repository-2"
+  }
+}
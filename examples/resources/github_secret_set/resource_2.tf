@@ -0,0 +1,18 @@
+# Organization and Environment Secrets Example
+
+resource "github_secret_set" "example" {
+  secret_name      = "DEPLOY_TOKEN"
+  value_wo         = var.deploy_token
+  value_wo_version = 1
+  secret_types     = ["actions", "dependabot"]
+
+  target {
+    visibility              = "selected"
+    selected_repository_ids = [github_repository.example.repo_id]
+  }
+
+  target {
+    repository  = github_repository.example.name
+    environment = "production"
+  }
+}
@@ -0,0 +1,15 @@
+resource "github_repository_file" "codeowners" {
+  repository     = "example"
+  branch         = "main"
+  file           = ".github/CODEOWNERS"
+  content        = "* @example/maintainers"
+  commit_message = "Update CODEOWNERS"
+
+  pull_request {
+    title          = "Update CODEOWNERS"
+    labels         = ["terraform"]
+    team_reviewers = ["maintainers"]
+    auto_merge     = true
+    merge_method   = "squash"
+  }
+}
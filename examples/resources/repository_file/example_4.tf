@@ -0,0 +1,12 @@
+resource "github_repository_file" "gitignore" {
+  repository     = "example"
+  branch         = "main"
+  file           = ".gitignore"
+  content        = ".terraform/\n*.tfstate\n*.tfstate.*\n"
+  commit_message = "Update managed .gitignore entries"
+
+  managed_block {
+    begin_marker = "# BEGIN terraform"
+    end_marker   = "# END terraform"
+  }
+}
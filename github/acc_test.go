@@ -5,14 +5,20 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"os/exec"
 	"slices"
 	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
+	"github.com/integrations/terraform-provider-github/v6/internal/ghfake"
 )
 
 type testMode string
@@ -94,12 +100,33 @@ var testAccConf *testAccConfig
 // The factory function will be invoked for every Terraform CLI command executed
 // to create a provider server to which the CLI can reattach.
 var providerFactories = map[string]func() (*schema.Provider, error){
-	//nolint:unparam
 	"github": func() (*schema.Provider, error) {
-		return NewProvider("acctest", "none")(), nil
+		recorder, err := getRecorder()
+		if err != nil {
+			return nil, err
+		}
+
+		return newProvider("acctest", "none", recorder)(), nil
+	},
+}
+
+// protoV5ProviderFactories are used to instantiate the provider server during acceptance testing of ephemeral resources, which the SDK provider doesn't serve.
+var protoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"github": func() (tfprotov5.ProviderServer, error) {
+		recorder, err := getRecorder()
+		if err != nil {
+			return nil, err
+		}
+
+		return newProviderServer(newProvider("acctest", "none", recorder)()), nil
 	},
 }
 
+// echoProviderFactories are used to instantiate the echo provider during acceptance testing of ephemeral resources, which stores the ephemeral values it's configured with in the state so that they can be checked.
+var echoProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"echo": echoprovider.NewProviderServer(),
+}
+
 func TestMain(m *testing.M) {
 	if os.Getenv("TF_ACC") == "" {
 		os.Exit(m.Run())
@@ -238,11 +265,17 @@ func getTestToken(conf *testAccConfig) (string, error) {
 }
 
 func getTestMeta(conf *testAccConfig) (*Owner, error) {
+	recorder, err := getRecorder()
+	if err != nil {
+		return nil, err
+	}
+
 	config := &Config{
 		LegacyClient: conf.legacyClient,
 		BaseURL:      conf.baseURL,
 		IsGHES:       conf.isGHES,
 		Owner:        conf.owner,
+		Recorder:     recorder,
 	}
 
 	if config.LegacyClient || conf.appID == "" {
@@ -260,6 +293,26 @@ func getTestMeta(conf *testAccConfig) (*Owner, error) {
 	return configureProviderMeta(context.Background(), "test", config)
 }
 
+// getRecorder returns the recorder configured by the `GITHUB_RECORDER_MODE` and `GITHUB_RECORDER_CASSETTE` environment variables, which record or replay the interactions with the GitHub API so that acceptance tests can run offline; it returns nil if no recorder is configured.
+func getRecorder() (*ghclient.Recorder, error) {
+	mode := os.Getenv("GITHUB_RECORDER_MODE")
+	if mode == "" {
+		return nil, nil
+	}
+
+	cassette := os.Getenv("GITHUB_RECORDER_CASSETTE")
+	if cassette == "" {
+		return nil, fmt.Errorf("GITHUB_RECORDER_CASSETTE must be set when GITHUB_RECORDER_MODE is set")
+	}
+
+	recorder, err := ghclient.NewRecorder(ghclient.RecorderMode(mode), cassette)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recorder: %w", err)
+	}
+
+	return recorder, nil
+}
+
 func configureSweepers() {
 	resource.AddTestSweepers("repositories", &resource.Sweeper{
 		Name: "repositories",
@@ -332,6 +385,32 @@ func sweepRepositories(_ string) error {
 	return nil
 }
 
+// newFakeGitHub starts a fake GitHub API with the organization for unit tests and returns it along with the provider configuration to use it.
+func newFakeGitHub(t *testing.T, org string) (*ghfake.Server, string) {
+	t.Helper()
+
+	s := ghfake.NewServer(t)
+	s.AddOrganization(org)
+
+	return s, fmt.Sprintf(`
+provider "github" {
+  base_url = "%s/"
+  token    = "fake-token"
+  owner    = "%s"
+}
+`, s.URL, org)
+}
+
+func skipUnlessTerraform(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("Skipping as terraform is not installed")
+	}
+}
+
 func skipUnauthenticated(t *testing.T) {
 	if testAccConf.authMode == anonymous {
 		t.Skip("Skipping as test mode not authenticated")
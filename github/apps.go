@@ -1,6 +1,11 @@
 package github
 
 import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
@@ -13,58 +18,130 @@ import (
 
 	"github.com/go-jose/go-jose/v4"
 	"github.com/go-jose/go-jose/v4/jwt"
+	"golang.org/x/oauth2"
+
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
 )
 
 // GenerateOAuthTokenFromApp generates a GitHub OAuth access token from a set of valid GitHub App credentials.
 // The returned token can be used to interact with both GitHub's REST and GraphQL APIs.
 func GenerateOAuthTokenFromApp(apiURL *url.URL, appID, appInstallationID, pemData string) (string, error) {
-	appJWT, err := generateAppJWT(appID, time.Now(), []byte(pemData))
+	signer, err := ghclient.NewPEMSigner([]byte(pemData))
 	if err != nil {
 		return "", err
 	}
 
-	token, err := getInstallationAccessToken(apiURL, appJWT, appInstallationID)
+	return GenerateOAuthTokenFromAppSigner(apiURL, appID, appInstallationID, signer)
+}
+
+// GenerateOAuthTokenFromAppSigner generates a GitHub OAuth access token for a GitHub App installation, using the signer to sign the App JWT so that the private key doesn't need to be loaded.
+func GenerateOAuthTokenFromAppSigner(apiURL *url.URL, appID, appInstallationID string, signer crypto.Signer) (string, error) {
+	return generateAppInstallationToken(apiURL, appID, appInstallationID, signer, nil, nil)
+}
+
+// generateAppInstallationToken generates a GitHub OAuth access token for a GitHub App installation, restricted to the scope if one is provided and reusing a valid token from the persistent token cache if one is provided.
+func generateAppInstallationToken(apiURL *url.URL, appID, appInstallationID string, signer crypto.Signer, scope *ghclient.InstallationTokenScope, cache *ghclient.TokenCache) (string, error) {
+	var tokenSource oauth2.TokenSource = &appInstallationTokenSource{
+		apiURL:         apiURL,
+		appID:          appID,
+		installationID: appInstallationID,
+		signer:         signer,
+		scope:          scope,
+	}
+
+	if cache != nil {
+		var err error
+		tokenSource, err = cache.TokenSource(scope.CacheKey(apiURL.String(), appID, appInstallationID), signer, tokenSource)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	token, err := tokenSource.Token()
 	if err != nil {
 		return "", err
 	}
 
-	return token, nil
+	return token.AccessToken, nil
+}
+
+// appInstallationTokenSource is an [oauth2.TokenSource] which exchanges a GitHub App JWT for an installation token.
+type appInstallationTokenSource struct {
+	apiURL         *url.URL
+	appID          string
+	installationID string
+	signer         crypto.Signer
+	scope          *ghclient.InstallationTokenScope
+}
+
+// Token generates a GitHub App JWT and exchanges it for an installation token.
+func (s *appInstallationTokenSource) Token() (*oauth2.Token, error) {
+	appJWT, err := generateAppJWTWithSigner(s.appID, time.Now(), s.signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return getInstallationToken(s.apiURL, appJWT, s.installationID, s.scope)
 }
 
 func getInstallationAccessToken(apiURL *url.URL, jwt, installationID string) (string, error) {
-	req, err := http.NewRequest(http.MethodPost, apiURL.JoinPath("app/installations", installationID, "access_tokens").String(), nil)
+	token, err := getInstallationToken(apiURL, jwt, installationID, nil)
 	if err != nil {
 		return "", err
 	}
 
+	return token.AccessToken, nil
+}
+
+// getInstallationToken exchanges the GitHub App JWT for an installation token, including its expiry; the token is restricted to the scope if one is provided.
+func getInstallationToken(apiURL *url.URL, jwt, installationID string, scope *ghclient.InstallationTokenScope) (*oauth2.Token, error) {
+	var body io.Reader
+	if !scope.IsEmpty() {
+		b, err := json.Marshal(scope)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, apiURL.JoinPath("app/installations", installationID, "access_tokens").String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
 	req.Header.Add("Accept", "application/vnd.github.v3+json")
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", jwt))
 
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer func() { _ = res.Body.Close() }()
 
 	resBytes, err := io.ReadAll(res.Body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if res.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("failed to create OAuth token from GitHub App: %s", string(resBytes))
+		return nil, fmt.Errorf("failed to create OAuth token from GitHub App: %s", string(resBytes))
 	}
 
 	resData := struct {
-		Token string `json:"token"`
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
 	}{}
 
 	err = json.Unmarshal(resBytes, &resData)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return resData.Token, nil
+	return &oauth2.Token{AccessToken: resData.Token, TokenType: "Bearer", Expiry: resData.ExpiresAt}, nil
 }
 
 func generateAppJWT(appID string, now time.Time, pemData []byte) (string, error) {
@@ -78,8 +155,17 @@ func generateAppJWT(appID string, now time.Time, pemData []byte) (string, error)
 		return "", err
 	}
 
-	signer, err := jose.NewSigner(
-		jose.SigningKey{Algorithm: jose.RS256, Key: privateKey},
+	return generateAppJWTWithSigner(appID, now, privateKey)
+}
+
+// generateAppJWTWithSigner generates a GitHub App JWT signed by the signer, which may be backed by an external key store.
+func generateAppJWTWithSigner(appID string, now time.Time, signer crypto.Signer) (string, error) {
+	if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+		return "", errors.New("app signer public key must be an RSA key")
+	}
+
+	joseSigner, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: appOpaqueSigner{signer: signer}},
 		(&jose.SignerOptions{}).WithType("JWT"),
 	)
 	if err != nil {
@@ -95,10 +181,35 @@ func generateAppJWT(appID string, now time.Time, pemData []byte) (string, error)
 		Expiry: jwt.NewNumericDate(now.Add(time.Duration(5) * time.Minute)),
 	}
 
-	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	token, err := jwt.Signed(joseSigner).Claims(claims).Serialize()
 	if err != nil {
 		return "", err
 	}
 
 	return token, nil
 }
+
+// appOpaqueSigner adapts a [crypto.Signer] to a [jose.OpaqueSigner] producing RS256 signatures.
+type appOpaqueSigner struct {
+	signer crypto.Signer
+}
+
+// Public returns the public key of the signer.
+func (s appOpaqueSigner) Public() *jose.JSONWebKey {
+	return &jose.JSONWebKey{Key: s.signer.Public(), Algorithm: string(jose.RS256), Use: "sig"}
+}
+
+// Algs returns the supported signing algorithms.
+func (s appOpaqueSigner) Algs() []jose.SignatureAlgorithm {
+	return []jose.SignatureAlgorithm{jose.RS256}
+}
+
+// SignPayload signs the payload using the signer.
+func (s appOpaqueSigner) SignPayload(payload []byte, alg jose.SignatureAlgorithm) ([]byte, error) {
+	if alg != jose.RS256 {
+		return nil, jose.ErrUnsupportedAlgorithm
+	}
+
+	digest := sha256.Sum256(payload)
+	return s.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
@@ -2,26 +2,37 @@ package github
 
 import (
 	"context"
+	"crypto"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/shurcooL/githubv4"
 	"golang.org/x/oauth2"
+
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
 )
 
 type Config struct {
 	AppID             *string
 	AppInstallationID *string
 	AppPEM            []byte
+	AppPrivateKeyFile string
+	AppSignerCommand  []string
+	AppTokenScope     *ghclient.InstallationTokenScope
 	BaseURL           *url.URL
 	IsGHES            bool
 	CachePath         string
+	AppTokenCache     bool
 	Insecure          bool
 	LegacyClient      bool
 	MaxRetries        int
@@ -33,6 +44,11 @@ type Config struct {
 	Token             string
 	WriteDelay        time.Duration
 	MaxPerPage        int
+	Owners            []OwnerConfig
+	OIDC              *ghclient.OIDCOptions
+	Recorder          *ghclient.Recorder
+	Telemetry         *ghclient.Telemetry
+	RateLimitBudget   *ghclient.RateLimitBudget
 }
 
 type Owner struct {
@@ -40,9 +56,86 @@ type Owner struct {
 	id             int64
 	v3client       *github.Client
 	v4client       *githubv4.Client
+	v4httpClient   *http.Client
+	source         ghclient.Source
+	owners         *ownerRegistry
+	config         *Config
+	version        string
 	StopContext    context.Context
 	IsOrganization bool
 	maxPerPage     int
+	publicKeys     *publicKeyCache
+	userPublicKeys *publicKeyCache
+	graphQLURL     string
+	scopedOwners   *sync.Map
+}
+
+// lookup populates the owner type and ID by looking up the owner.
+func (o *Owner) lookup(ctx context.Context) error {
+	u, _, err := o.v3client.Users.Get(ctx, o.name)
+	if err != nil {
+		return fmt.Errorf("failed to lookup owner %q: %w", o.name, err)
+	}
+
+	if u.GetType() == "Organization" {
+		o.IsOrganization = true
+		o.id = u.GetID()
+	}
+
+	return nil
+}
+
+// restClient returns the REST client to use for resources owned by the given owner. The default client is returned for the configured owner, an empty owner or when the legacy client is in use; otherwise the client is obtained from the [ghclient.Source] so that it is authenticated for the owner (e.g. scoped to the owner's GitHub App installation), falling back to the default client if the GitHub App isn't installed for the owner.
+func (o *Owner) restClient(ctx context.Context, owner string) (*github.Client, error) {
+	if o.source == nil || owner == "" || strings.EqualFold(owner, o.name) {
+		return o.v3client, nil
+	}
+
+	client, err := o.source.OwnerRESTClient(ctx, owner)
+	if err != nil {
+		if errors.Is(err, ghclient.ErrInstallationNotFound) {
+			tflog.Debug(ctx, "No GitHub App installation found for owner; using the default client.", map[string]any{"owner": owner})
+			return o.v3client, nil
+		}
+		return nil, fmt.Errorf("failed to create rest client for owner %q: %w", owner, err)
+	}
+
+	return client, nil
+}
+
+// graphQLClient returns the GraphQL client to use for resources owned by the given owner. The default client is returned for the configured owner, an empty owner or when the legacy client is in use; otherwise the client is obtained from the [ghclient.Source] so that it is authenticated for the owner (e.g. scoped to the owner's GitHub App installation), falling back to the default client if the GitHub App isn't installed for the owner.
+func (o *Owner) graphQLClient(ctx context.Context, owner string) (*githubv4.Client, error) {
+	if o.source == nil || owner == "" || strings.EqualFold(owner, o.name) {
+		return o.v4client, nil
+	}
+
+	client, err := o.source.OwnerGraphQLClient(ctx, owner)
+	if err != nil {
+		if errors.Is(err, ghclient.ErrInstallationNotFound) {
+			tflog.Debug(ctx, "No GitHub App installation found for owner; using the default client.", map[string]any{"owner": owner})
+			return o.v4client, nil
+		}
+		return nil, fmt.Errorf("failed to create graphql client for owner %q: %w", owner, err)
+	}
+
+	return client, nil
+}
+
+// graphQLHTTPClient returns the HTTP client of the GraphQL client returned by [Owner.graphQLClient] for the given owner.
+func (o *Owner) graphQLHTTPClient(ctx context.Context, owner string) (*http.Client, error) {
+	if o.source == nil || owner == "" || strings.EqualFold(owner, o.name) {
+		return o.v4httpClient, nil
+	}
+
+	client, err := o.source.OwnerGraphQLHTTPClient(ctx, owner)
+	if err != nil {
+		if errors.Is(err, ghclient.ErrInstallationNotFound) {
+			return o.v4httpClient, nil
+		}
+		return nil, fmt.Errorf("failed to create graphql client for owner %q: %w", owner, err)
+	}
+
+	return client, nil
 }
 
 const (
@@ -87,30 +180,137 @@ func RateLimitedHTTPClient(client *http.Client, writeDelay, readDelay, retryDela
 
 func (c *Config) AuthenticatedHTTPClient() *http.Client {
 	ctx := context.Background()
+	if tr := c.baseTransport(http.DefaultTransport); tr != http.DefaultTransport {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: tr})
+	}
+
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: c.Token},
 	)
 	client := oauth2.NewClient(ctx, ts)
 
-	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries)
+	return c.telemetryHTTPClient(RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries))
+}
+
+// appSigner returns the signer for the GitHub App JWTs; this is the external signer command or the private key file if configured, otherwise the PEM file content.
+func (c *Config) appSigner(ctx context.Context) (crypto.Signer, error) {
+	switch {
+	case len(c.AppSignerCommand) > 0:
+		signer, err := ghclient.NewExecSigner(ctx, c.AppSignerCommand[0], c.AppSignerCommand[1:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create github app signer: %w", err)
+		}
+		return signer, nil
+	case c.AppPrivateKeyFile != "":
+		signer, err := ghclient.NewFileSigner(c.AppPrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create github app signer: %w", err)
+		}
+		return signer, nil
+	default:
+		return ghclient.NewPEMSigner(c.AppPEM)
+	}
+}
+
+// appTokenCache returns the persistent cache for GitHub App installation tokens, or nil if it isn't enabled.
+func (c *Config) appTokenCache() (*ghclient.TokenCache, error) {
+	if !c.AppTokenCache || c.CachePath == "" {
+		return nil, nil
+	}
+
+	return ghclient.NewTokenCache(filepath.Join(c.CachePath, "terraform-provider-github", "app-tokens"))
+}
+
+// exchangeAppToken exchanges the GitHub App credentials for an installation token to be used by the legacy client.
+func (c *Config) exchangeAppToken(ctx context.Context) error {
+	pathSuffix := RESTAPIPath
+	if c.IsGHES {
+		pathSuffix = GHESRESTAPIPath
+	}
+
+	signer, err := c.appSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	cache, err := c.appTokenCache()
+	if err != nil {
+		return err
+	}
+
+	appToken, err := generateAppInstallationToken(c.BaseURL.JoinPath(pathSuffix), *c.AppID, *c.AppInstallationID, signer, c.AppTokenScope, cache)
+	if err != nil {
+		return err
+	}
+	c.Token = appToken
+
+	return nil
+}
+
+// exchangeOIDCToken exchanges the OIDC JWT for a token with the token broker to be used by the legacy client.
+func (c *Config) exchangeOIDCToken() error {
+	tokenSource, err := ghclient.NewOIDCTokenSource(*c.OIDC)
+	if err != nil {
+		return err
+	}
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		return err
+	}
+	c.Token = token.AccessToken
+
+	return nil
 }
 
 func (c *Config) Anonymous() bool {
-	return c.AppID == nil && c.Token == ""
+	return c.AppID == nil && c.OIDC == nil && c.Token == ""
 }
 
 func (c *Config) AnonymousHTTPClient() *http.Client {
-	client := &http.Client{Transport: http.DefaultTransport.(*http.Transport).Clone()}
-	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries)
+	client := &http.Client{Transport: c.baseTransport(http.DefaultTransport.(*http.Transport).Clone())}
+
+	return c.telemetryHTTPClient(RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries))
+}
+
+// baseTransport wraps the transport sending requests to the GitHub API for the legacy client with the recorder, the telemetry attempt accounting and the rate limit budget, if configured.
+func (c *Config) baseTransport(tr http.RoundTripper) http.RoundTripper {
+	if c.Recorder != nil {
+		tr = c.Recorder.Transport(tr)
+	}
+
+	if c.Telemetry != nil {
+		tr = c.Telemetry.AttemptTransport(tr)
+	}
+
+	if c.RateLimitBudget != nil {
+		tr = c.RateLimitBudget.Transport(tr)
+	}
+
+	return tr
+}
+
+// telemetryHTTPClient wraps the transport of the legacy client with the telemetry request accounting, if configured.
+func (c *Config) telemetryHTTPClient(client *http.Client) *http.Client {
+	if c.Telemetry != nil {
+		client.Transport = c.Telemetry.Transport(client.Transport)
+	}
+
+	return client
 }
 
 func (c *Config) NewGraphQLClient(client *http.Client) (*githubv4.Client, error) {
+	return githubv4.NewEnterpriseClient(c.graphQLURL(), client), nil
+}
+
+// graphQLURL returns the URL of the GraphQL API.
+func (c *Config) graphQLURL() string {
 	pathSuffix := GraphQLAPIPath
 	if c.IsGHES {
 		pathSuffix = GHESGraphQLAPIPath
 	}
 
-	return githubv4.NewEnterpriseClient(c.BaseURL.JoinPath(pathSuffix).String(), client), nil
+	return c.BaseURL.JoinPath(pathSuffix).String()
 }
 
 func (c *Config) NewRESTClient(client *http.Client) (*github.Client, error) {
@@ -1,9 +1,17 @@
 package github
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
 )
 
 func Test_getBaseURL(t *testing.T) {
@@ -368,3 +376,132 @@ func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 	}
 	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
 }
+
+type testOwnerSource struct {
+	restClients    map[string]*github.Client
+	graphQLClients map[string]*githubv4.Client
+	err            error
+}
+
+func (s *testOwnerSource) RESTClient() (*github.Client, error) {
+	return nil, s.err
+}
+
+func (s *testOwnerSource) OwnerRESTClient(_ context.Context, owner string) (*github.Client, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.restClients[owner], nil
+}
+
+func (s *testOwnerSource) GraphQLClient() (*githubv4.Client, error) {
+	return nil, s.err
+}
+
+func (s *testOwnerSource) OwnerGraphQLClient(_ context.Context, owner string) (*githubv4.Client, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.graphQLClients[owner], nil
+}
+
+func (s *testOwnerSource) OwnerGraphQLHTTPClient(_ context.Context, _ string) (*http.Client, error) {
+	return nil, s.err
+}
+
+func TestOwner_clients(t *testing.T) {
+	t.Parallel()
+
+	defaultREST := &github.Client{}
+	defaultGraphQL := githubv4.NewClient(nil)
+	otherREST := &github.Client{}
+	otherGraphQL := githubv4.NewClient(nil)
+
+	source := &testOwnerSource{
+		restClients:    map[string]*github.Client{"other": otherREST},
+		graphQLClients: map[string]*githubv4.Client{"other": otherGraphQL},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		source          ghclient.Source
+		owner           string
+		expectedREST    *github.Client
+		expectedGraphQL *githubv4.Client
+		errors          bool
+	}{
+		{
+			name:            "legacy client",
+			source:          nil,
+			owner:           "other",
+			expectedREST:    defaultREST,
+			expectedGraphQL: defaultGraphQL,
+		},
+		{
+			name:            "empty owner",
+			source:          source,
+			owner:           "",
+			expectedREST:    defaultREST,
+			expectedGraphQL: defaultGraphQL,
+		},
+		{
+			name:            "configured owner",
+			source:          source,
+			owner:           "Octocat",
+			expectedREST:    defaultREST,
+			expectedGraphQL: defaultGraphQL,
+		},
+		{
+			name:            "other owner",
+			source:          source,
+			owner:           "other",
+			expectedREST:    otherREST,
+			expectedGraphQL: otherGraphQL,
+		},
+		{
+			name:            "other owner without installation",
+			source:          &testOwnerSource{err: fmt.Errorf("no installation found for owner %q: %w", "other", ghclient.ErrInstallationNotFound)},
+			owner:           "other",
+			expectedREST:    defaultREST,
+			expectedGraphQL: defaultGraphQL,
+		},
+		{
+			name:   "other owner error",
+			source: &testOwnerSource{err: errors.New("boom")},
+			owner:  "other",
+			errors: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			meta := &Owner{name: "octocat", v3client: defaultREST, v4client: defaultGraphQL, source: tc.source}
+
+			restClient, err := meta.restClient(t.Context(), tc.owner)
+			if tc.errors {
+				if err == nil {
+					t.Fatal("expected rest client error")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected rest client error: %v", err)
+			}
+
+			if restClient != tc.expectedREST {
+				t.Errorf("unexpected rest client for owner %q", tc.owner)
+			}
+
+			graphQLClient, err := meta.graphQLClient(t.Context(), tc.owner)
+			if tc.errors {
+				if err == nil {
+					t.Fatal("expected graphql client error")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected graphql client error: %v", err)
+			}
+
+			if graphQLClient != tc.expectedGraphQL {
+				t.Errorf("unexpected graphql client for owner %q", tc.owner)
+			}
+		})
+	}
+}
@@ -57,7 +57,6 @@ func dataSourceGithubActionsEnvironmentSecrets() *schema.Resource {
 
 func dataSourceGithubActionsEnvironmentSecretsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	meta, _ := m.(*Owner)
-	client := meta.v3client
 	owner := meta.name
 
 	var repoName string
@@ -77,6 +76,11 @@ func dataSourceGithubActionsEnvironmentSecretsRead(ctx context.Context, d *schem
 		}
 	}
 
+	client, err := meta.restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var all_secrets []map[string]string
 	for secret, err := range client.Actions.ListEnvSecretsIter(ctx, owner, repoName, url.PathEscape(envName), &github.ListOptions{PerPage: meta.maxPerPage}) {
 		if err != nil {
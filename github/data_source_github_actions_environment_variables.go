@@ -61,7 +61,6 @@ func dataSourceGithubActionsEnvironmentVariables() *schema.Resource {
 
 func dataSourceGithubActionsEnvironmentVariablesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	meta, _ := m.(*Owner)
-	client := meta.v3client
 	owner := meta.name
 	var repoName string
 
@@ -87,6 +86,11 @@ func dataSourceGithubActionsEnvironmentVariablesRead(ctx context.Context, d *sch
 		PerPage: meta.maxPerPage,
 	}
 
+	client, err := meta.restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var all_variables []map[string]string
 	for {
 		variables, resp, err := client.Actions.ListEnvVariables(ctx, owner, repoName, url.PathEscape(envName), &options)
@@ -52,7 +52,6 @@ func dataSourceGithubActionsSecrets() *schema.Resource {
 
 func dataSourceGithubActionsSecretsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	meta, _ := m.(*Owner)
-	client := meta.v3client
 	owner := meta.name
 	var repoName string
 
@@ -76,6 +75,11 @@ func dataSourceGithubActionsSecretsRead(ctx context.Context, d *schema.ResourceD
 		PerPage: meta.maxPerPage,
 	}
 
+	client, err := meta.restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var all_secrets []map[string]string
 	for {
 		secrets, resp, err := client.Actions.ListRepoSecrets(ctx, owner, repoName, &options)
@@ -97,7 +101,7 @@ func dataSourceGithubActionsSecretsRead(ctx context.Context, d *schema.ResourceD
 	}
 
 	d.SetId(repoName)
-	err := d.Set("secrets", all_secrets)
+	err = d.Set("secrets", all_secrets)
 	if err != nil {
 		return diag.FromErr(err)
 	}
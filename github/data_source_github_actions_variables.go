@@ -56,7 +56,6 @@ func dataSourceGithubActionsVariables() *schema.Resource {
 
 func dataSourceGithubActionsVariablesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	meta, _ := m.(*Owner)
-	client := meta.v3client
 	owner := meta.name
 	var repoName string
 
@@ -80,6 +79,11 @@ func dataSourceGithubActionsVariablesRead(ctx context.Context, d *schema.Resourc
 		PerPage: meta.maxPerPage,
 	}
 
+	client, err := meta.restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var all_variables []map[string]string
 	for {
 		variables, resp, err := client.Actions.ListRepoVariables(ctx, owner, repoName, &options)
@@ -102,7 +106,7 @@ func dataSourceGithubActionsVariablesRead(ctx context.Context, d *schema.Resourc
 	}
 
 	d.SetId(repoName)
-	err := d.Set("variables", all_variables)
+	err = d.Set("variables", all_variables)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -3,10 +3,14 @@ package github
 import (
 	"context"
 	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
 )
 
 func dataSourceGithubAppToken() *schema.Resource {
@@ -25,9 +29,36 @@ func dataSourceGithubAppToken() *schema.Resource {
 				Description: "The GitHub App installation's identifier.",
 			},
 			"pem_file": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The GitHub App's PEM file content; `\\n` can be used for newlines.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"pem_file", "private_key_file", "signer_command"},
+				Description:  "The GitHub App's PEM file content; `\\n` can be used for newlines.",
+			},
+			"private_key_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"pem_file", "private_key_file", "signer_command"},
+				Description:  "The path to the GitHub App's PEM encoded private key file.",
+			},
+			"signer_command": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{"pem_file", "private_key_file", "signer_command"},
+				Description:  "The external command (and arguments) used to sign the GitHub App's JWT so that the private key never needs to be available to the provider.",
+			},
+			"permissions": {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validation.MapValueMatch(regexp.MustCompile(`^(read|write|admin)$`), "must be one of read, write or admin"),
+				Description:      "The permissions to request for the token, mapping permission names (e.g. `contents`) to `read`, `write` or `admin`; if not set the token has all of the installation's permissions.",
+			},
+			"repositories": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the repositories to restrict the token to; if not set the token can access all of the installation's repositories.",
 			},
 			"token": {
 				Type:        schema.TypeString,
@@ -44,21 +75,58 @@ func dataSourceGithubAppTokenRead(ctx context.Context, d *schema.ResourceData, m
 
 	appID := d.Get("app_id").(string)
 	installationID := d.Get("installation_id").(string)
-	pemFile := d.Get("pem_file").(string)
+
+	pemFile, _ := d.Get("pem_file").(string)
+	privateKeyFile, _ := d.Get("private_key_file").(string)
+
+	var signerCommand []string
+	if l, ok := d.Get("signer_command").([]any); ok {
+		for _, a := range l {
+			if s, ok := a.(string); ok {
+				signerCommand = append(signerCommand, s)
+			}
+		}
+	}
 
 	// The Go encoding/pem package only decodes PEM formatted blocks
 	// that contain new lines. Some platforms, like Terraform Cloud,
 	// do not support new lines within Environment Variables.
 	// Any occurrence of \n in the `pem_file` argument's value
 	// is replaced with an actual new line character before decoding.
-	pemFile = strings.ReplaceAll(pemFile, `\n`, "\n")
+	config := &Config{
+		AppPEM:            []byte(strings.ReplaceAll(pemFile, `\n`, "\n")),
+		AppPrivateKeyFile: privateKeyFile,
+		AppSignerCommand:  signerCommand,
+	}
+
+	scope := &ghclient.InstallationTokenScope{}
+	if m, ok := d.Get("permissions").(map[string]any); ok && len(m) > 0 {
+		scope.Permissions = make(map[string]string, len(m))
+		for k, a := range m {
+			if s, ok := a.(string); ok {
+				scope.Permissions[k] = s
+			}
+		}
+	}
+	if set, ok := d.Get("repositories").(*schema.Set); ok {
+		for _, a := range set.List() {
+			if s, ok := a.(string); ok {
+				scope.Repositories = append(scope.Repositories, s)
+			}
+		}
+	}
+
+	signer, err := config.appSigner(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	u, err := url.Parse(meta.v3client.BaseURL())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	token, err := GenerateOAuthTokenFromApp(u, appID, installationID, pemFile)
+	token, err := generateAppInstallationToken(u, appID, installationID, signer, scope, nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -68,4 +68,104 @@ func TestGithubAppTokenDataSource(t *testing.T) {
 			t.Fail()
 		}
 	})
+
+	t.Run("creates a application token from a private key file without error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedAccessToken := "W+2e/zjiMTweDAr2b35toCF+h29l7NW92rJIPvFrCJQK"
+
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri: fmt.Sprintf("/app/installations/%s/access_tokens", testGitHubAppInstallationID),
+				ExpectedHeaders: map[string]string{
+					"Accept": "application/vnd.github.v3+json",
+				},
+				ResponseBody: fmt.Sprintf(`{"token": "%s"}`, expectedAccessToken),
+				StatusCode:   201,
+			},
+		})
+		defer ts.Close()
+
+		meta := &Owner{
+			name:     "test-owner",
+			v3client: mustCreateTestGitHubClient(t, ts.URL),
+		}
+
+		d := schema.TestResourceDataRaw(t, dataSourceGithubAppToken().Schema, map[string]any{
+			"app_id":           testGitHubAppID,
+			"installation_id":  testGitHubAppInstallationID,
+			"private_key_file": testGitHubAppPrivateKeyFile,
+		})
+
+		diags := dataSourceGithubAppTokenRead(t.Context(), d, meta)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+
+		if d.Get("token") != expectedAccessToken {
+			t.Errorf("expected token to be %s, got %s", expectedAccessToken, d.Get("token"))
+		}
+	})
+	t.Run("creates a scoped application token without error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedAccessToken := "W+2e/zjiMTweDAr2b35toCF+h29l7NW92rJIPvFrCJQK"
+
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri: fmt.Sprintf("/app/installations/%s/access_tokens", testGitHubAppInstallationID),
+				ExpectedHeaders: map[string]string{
+					"Accept":       "application/vnd.github.v3+json",
+					"Content-Type": "application/json",
+				},
+				ExpectedBody: []byte(`{"repositories":["test-repo"],"permissions":{"contents":"read"}}`),
+				ResponseBody: fmt.Sprintf(`{"token": "%s"}`, expectedAccessToken),
+				StatusCode:   201,
+			},
+		})
+		defer ts.Close()
+
+		meta := &Owner{
+			name:     "test-owner",
+			v3client: mustCreateTestGitHubClient(t, ts.URL),
+		}
+
+		d := schema.TestResourceDataRaw(t, dataSourceGithubAppToken().Schema, map[string]any{
+			"app_id":           testGitHubAppID,
+			"installation_id":  testGitHubAppInstallationID,
+			"private_key_file": testGitHubAppPrivateKeyFile,
+			"permissions":      map[string]any{"contents": "read"},
+			"repositories":     []any{"test-repo"},
+		})
+
+		diags := dataSourceGithubAppTokenRead(t.Context(), d, meta)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+
+		if d.Get("token") != expectedAccessToken {
+			t.Errorf("expected token to be %s, got %s", expectedAccessToken, d.Get("token"))
+		}
+	})
+
+	t.Run("errors on unknown permissions", func(t *testing.T) {
+		t.Parallel()
+
+		meta := &Owner{
+			name:     "test-owner",
+			v3client: mustCreateTestGitHubClient(t, "https://api.github.com/"),
+		}
+
+		d := schema.TestResourceDataRaw(t, dataSourceGithubAppToken().Schema, map[string]any{
+			"app_id":           testGitHubAppID,
+			"installation_id":  testGitHubAppInstallationID,
+			"private_key_file": testGitHubAppPrivateKeyFile,
+			"permissions":      map[string]any{"not_a_permission": "read"},
+		})
+
+		diags := dataSourceGithubAppTokenRead(t.Context(), d, meta)
+		if !diags.HasError() {
+			t.Fatal("expected error for unknown permission, got nil")
+		}
+	})
 }
@@ -0,0 +1,437 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"net/url"
+	"slices"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
+)
+
+// branchProtectionSourceType is the source type of the rules of a branch protection rule.
+const branchProtectionSourceType = "BranchProtection"
+
+// effectiveRuleSource is a ruleset or a branch protection rule and the rules of it which apply to a branch.
+type effectiveRuleSource struct {
+	sourceType string
+	source     string
+	rulesetID  int64
+	rules      *github.RepositoryRulesetRules
+}
+
+func dataSourceGithubBranchEffectiveRules() *schema.Resource {
+	// The rules can come from repository and organization rulesets, so they have the attributes of both.
+	rulesSchema := computedSchema(resourceGithubRepositoryRuleset().Schema["rules"].Elem.(*schema.Resource).Schema)
+	maps.Copy(rulesSchema, computedSchema(resourceGithubOrganizationRuleset().Schema["rules"].Elem.(*schema.Resource).Schema))
+
+	return &schema.Resource{
+		ReadContext: dataSourceGithubBranchEffectiveRulesRead,
+
+		Description: "Data source to read the active rules which apply to a branch, from the rulesets of the enterprise, the organization and the repository and from the branch protection rule.",
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the branch.",
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The strictest combination of the rules of all the sources, in the shape of the `rules` of a `github_repository_ruleset` or `github_organization_ruleset`.",
+				Elem:        &schema.Resource{Schema: rulesSchema},
+			},
+			"sources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rulesets and the branch protection rule which apply to the branch, with their rules.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the source. Can be one of: `Enterprise`, `Organization`, `Repository`, `BranchProtection`.",
+						},
+						"source": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the enterprise, organization or repository of the ruleset, or the pattern of the branch protection rule.",
+						},
+						"ruleset_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the ruleset, or `0` for the branch protection rule.",
+						},
+						"rules": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The rules of the source which apply to the branch.",
+							Elem:        &schema.Resource{Schema: rulesSchema},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubBranchEffectiveRulesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+	repoName := d.Get("repository").(string)
+	branch := d.Get("branch").(string)
+
+	branchRules := &github.BranchRules{}
+	opts := &github.ListOptions{PerPage: meta.maxPerPage}
+	for {
+		page, resp, err := client.Repositories.ListRulesForBranch(ctx, owner, repoName, url.PathEscape(branch), opts)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		appendBranchRules(branchRules, page)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	sources := branchRulesBySource(branchRules)
+
+	var query struct {
+		Repository struct {
+			Ref *struct {
+				BranchProtectionRule *BranchProtectionRule
+			} `graphql:"ref(qualifiedName: $ref)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	variables := map[string]any{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(repoName),
+		"ref":   githubv4.String("refs/heads/" + branch),
+	}
+	if err := meta.v4client.Query(ctx, &query, variables); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if ref := query.Repository.Ref; ref != nil && ref.BranchProtectionRule != nil {
+		ruleset, _ := branchProtectionRuleToRuleset(*ref.BranchProtectionRule)
+		sources = append(sources, effectiveRuleSource{
+			sourceType: branchProtectionSourceType,
+			source:     string(ref.BranchProtectionRule.Pattern),
+			rules:      ruleset.Rules,
+		})
+	}
+
+	tflog.Debug(ctx, "Read the effective rules of the branch", map[string]any{
+		"repository":    repoName,
+		"branch":        branch,
+		"count_sources": len(sources),
+	})
+
+	merged := &github.RepositoryRulesetRules{}
+	flattenedSources := make([]any, 0, len(sources))
+	for _, source := range sources {
+		mergeRulesetRules(merged, source.rules)
+
+		flattenedSources = append(flattenedSources, map[string]any{
+			"source_type": source.sourceType,
+			"source":      source.source,
+			"ruleset_id":  source.rulesetID,
+			"rules":       flattenEffectiveRules(ctx, source.rules),
+		})
+	}
+
+	id, err := buildID(owner, repoName, branch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := d.Set("rules", flattenEffectiveRules(ctx, merged)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("sources", flattenedSources); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenEffectiveRules flattens rules with the attributes of both repository and organization rulesets.
+func flattenEffectiveRules(ctx context.Context, rules *github.RepositoryRulesetRules) []any {
+	flattened := flattenRules(ctx, rules, false)
+	maps.Copy(flattened[0].(map[string]any), flattenRules(ctx, rules, true)[0].(map[string]any))
+	return flattened
+}
+
+// appendBranchRules appends a page of the rules for a branch to the rules read so far.
+func appendBranchRules(dst, page *github.BranchRules) {
+	if page == nil {
+		return
+	}
+
+	dst.Creation = append(dst.Creation, page.Creation...)
+	dst.Update = append(dst.Update, page.Update...)
+	dst.Deletion = append(dst.Deletion, page.Deletion...)
+	dst.RequiredLinearHistory = append(dst.RequiredLinearHistory, page.RequiredLinearHistory...)
+	dst.MergeQueue = append(dst.MergeQueue, page.MergeQueue...)
+	dst.RequiredDeployments = append(dst.RequiredDeployments, page.RequiredDeployments...)
+	dst.RequiredSignatures = append(dst.RequiredSignatures, page.RequiredSignatures...)
+	dst.PullRequest = append(dst.PullRequest, page.PullRequest...)
+	dst.RequiredStatusChecks = append(dst.RequiredStatusChecks, page.RequiredStatusChecks...)
+	dst.NonFastForward = append(dst.NonFastForward, page.NonFastForward...)
+	dst.CommitMessagePattern = append(dst.CommitMessagePattern, page.CommitMessagePattern...)
+	dst.CommitAuthorEmailPattern = append(dst.CommitAuthorEmailPattern, page.CommitAuthorEmailPattern...)
+	dst.CommitterEmailPattern = append(dst.CommitterEmailPattern, page.CommitterEmailPattern...)
+	dst.BranchNamePattern = append(dst.BranchNamePattern, page.BranchNamePattern...)
+	dst.TagNamePattern = append(dst.TagNamePattern, page.TagNamePattern...)
+	dst.Workflows = append(dst.Workflows, page.Workflows...)
+	dst.CodeScanning = append(dst.CodeScanning, page.CodeScanning...)
+	dst.CopilotCodeReview = append(dst.CopilotCodeReview, page.CopilotCodeReview...)
+	dst.FileExtensionRestriction = append(dst.FileExtensionRestriction, page.FileExtensionRestriction...)
+	dst.FilePathRestriction = append(dst.FilePathRestriction, page.FilePathRestriction...)
+	dst.MaxFilePathLength = append(dst.MaxFilePathLength, page.MaxFilePathLength...)
+	dst.MaxFileSize = append(dst.MaxFileSize, page.MaxFileSize...)
+}
+
+// branchRulesBySource groups the rules for a branch by the ruleset they're from, in the order the rulesets are first seen.
+func branchRulesBySource(branchRules *github.BranchRules) []effectiveRuleSource {
+	var sources []effectiveRuleSource
+	rulesOf := func(metadata github.BranchRuleMetadata) *github.RepositoryRulesetRules {
+		for _, source := range sources {
+			if source.rulesetID == metadata.RulesetID && source.sourceType == string(metadata.RulesetSourceType) {
+				return source.rules
+			}
+		}
+		source := effectiveRuleSource{
+			sourceType: string(metadata.RulesetSourceType),
+			source:     metadata.RulesetSource,
+			rulesetID:  metadata.RulesetID,
+			rules:      &github.RepositoryRulesetRules{},
+		}
+		sources = append(sources, source)
+		return source.rules
+	}
+
+	for _, r := range branchRules.Creation {
+		rulesOf(*r).Creation = &github.EmptyRuleParameters{}
+	}
+	for _, r := range branchRules.Update {
+		rulesOf(r.BranchRuleMetadata).Update = &r.Parameters
+	}
+	for _, r := range branchRules.Deletion {
+		rulesOf(*r).Deletion = &github.EmptyRuleParameters{}
+	}
+	for _, r := range branchRules.RequiredLinearHistory {
+		rulesOf(*r).RequiredLinearHistory = &github.EmptyRuleParameters{}
+	}
+	for _, r := range branchRules.MergeQueue {
+		rulesOf(r.BranchRuleMetadata).MergeQueue = &r.Parameters
+	}
+	for _, r := range branchRules.RequiredDeployments {
+		rulesOf(r.BranchRuleMetadata).RequiredDeployments = &r.Parameters
+	}
+	for _, r := range branchRules.RequiredSignatures {
+		rulesOf(*r).RequiredSignatures = &github.EmptyRuleParameters{}
+	}
+	for _, r := range branchRules.PullRequest {
+		rulesOf(r.BranchRuleMetadata).PullRequest = &r.Parameters
+	}
+	for _, r := range branchRules.RequiredStatusChecks {
+		rulesOf(r.BranchRuleMetadata).RequiredStatusChecks = &r.Parameters
+	}
+	for _, r := range branchRules.NonFastForward {
+		rulesOf(*r).NonFastForward = &github.EmptyRuleParameters{}
+	}
+	for _, r := range branchRules.CommitMessagePattern {
+		rulesOf(r.BranchRuleMetadata).CommitMessagePattern = &r.Parameters
+	}
+	for _, r := range branchRules.CommitAuthorEmailPattern {
+		rulesOf(r.BranchRuleMetadata).CommitAuthorEmailPattern = &r.Parameters
+	}
+	for _, r := range branchRules.CommitterEmailPattern {
+		rulesOf(r.BranchRuleMetadata).CommitterEmailPattern = &r.Parameters
+	}
+	for _, r := range branchRules.BranchNamePattern {
+		rulesOf(r.BranchRuleMetadata).BranchNamePattern = &r.Parameters
+	}
+	for _, r := range branchRules.TagNamePattern {
+		rulesOf(r.BranchRuleMetadata).TagNamePattern = &r.Parameters
+	}
+	for _, r := range branchRules.Workflows {
+		rulesOf(r.BranchRuleMetadata).Workflows = &r.Parameters
+	}
+	for _, r := range branchRules.CodeScanning {
+		rulesOf(r.BranchRuleMetadata).CodeScanning = &r.Parameters
+	}
+	for _, r := range branchRules.CopilotCodeReview {
+		rulesOf(r.BranchRuleMetadata).CopilotCodeReview = &r.Parameters
+	}
+	for _, r := range branchRules.FileExtensionRestriction {
+		rulesOf(r.BranchRuleMetadata).FileExtensionRestriction = &r.Parameters
+	}
+	for _, r := range branchRules.FilePathRestriction {
+		rulesOf(r.BranchRuleMetadata).FilePathRestriction = &r.Parameters
+	}
+	for _, r := range branchRules.MaxFilePathLength {
+		rulesOf(r.BranchRuleMetadata).MaxFilePathLength = &r.Parameters
+	}
+	for _, r := range branchRules.MaxFileSize {
+		rulesOf(r.BranchRuleMetadata).MaxFileSize = &r.Parameters
+	}
+
+	return sources
+}
+
+// mergeRulesetRules merges the rules of src into dst, keeping the strictest parameters of the rules in both.
+// The merge queue and pattern rules can't be combined, so the ones of dst are kept.
+func mergeRulesetRules(dst, src *github.RepositoryRulesetRules) {
+	dst.Creation = cmp.Or(dst.Creation, src.Creation)
+	dst.Deletion = cmp.Or(dst.Deletion, src.Deletion)
+	dst.RequiredLinearHistory = cmp.Or(dst.RequiredLinearHistory, src.RequiredLinearHistory)
+	dst.RequiredSignatures = cmp.Or(dst.RequiredSignatures, src.RequiredSignatures)
+	dst.NonFastForward = cmp.Or(dst.NonFastForward, src.NonFastForward)
+	dst.MergeQueue = cmp.Or(dst.MergeQueue, src.MergeQueue)
+	dst.CommitMessagePattern = cmp.Or(dst.CommitMessagePattern, src.CommitMessagePattern)
+	dst.CommitAuthorEmailPattern = cmp.Or(dst.CommitAuthorEmailPattern, src.CommitAuthorEmailPattern)
+	dst.CommitterEmailPattern = cmp.Or(dst.CommitterEmailPattern, src.CommitterEmailPattern)
+	dst.BranchNamePattern = cmp.Or(dst.BranchNamePattern, src.BranchNamePattern)
+	dst.TagNamePattern = cmp.Or(dst.TagNamePattern, src.TagNamePattern)
+
+	if src.Update != nil {
+		if dst.Update == nil {
+			dst.Update = new(*src.Update)
+		} else {
+			dst.Update.UpdateAllowsFetchAndMerge = dst.Update.UpdateAllowsFetchAndMerge && src.Update.UpdateAllowsFetchAndMerge
+		}
+	}
+
+	if src.PullRequest != nil {
+		if dst.PullRequest == nil {
+			dst.PullRequest = new(*src.PullRequest)
+			dst.PullRequest.AllowedMergeMethods = slices.Clone(src.PullRequest.AllowedMergeMethods)
+			dst.PullRequest.RequiredReviewers = slices.Clone(src.PullRequest.RequiredReviewers)
+		} else {
+			pr := dst.PullRequest
+			pr.RequiredApprovingReviewCount = max(pr.RequiredApprovingReviewCount, src.PullRequest.RequiredApprovingReviewCount)
+			pr.DismissStaleReviewsOnPush = pr.DismissStaleReviewsOnPush || src.PullRequest.DismissStaleReviewsOnPush
+			pr.RequireCodeOwnerReview = pr.RequireCodeOwnerReview || src.PullRequest.RequireCodeOwnerReview
+			pr.RequireLastPushApproval = pr.RequireLastPushApproval || src.PullRequest.RequireLastPushApproval
+			pr.RequiredReviewThreadResolution = pr.RequiredReviewThreadResolution || src.PullRequest.RequiredReviewThreadResolution
+			pr.RequiredReviewers = append(pr.RequiredReviewers, src.PullRequest.RequiredReviewers...)
+			if len(src.PullRequest.AllowedMergeMethods) > 0 {
+				pr.AllowedMergeMethods = slices.DeleteFunc(pr.AllowedMergeMethods, func(method github.PullRequestMergeMethod) bool {
+					return !slices.Contains(src.PullRequest.AllowedMergeMethods, method)
+				})
+			}
+		}
+	}
+
+	if src.RequiredStatusChecks != nil {
+		if dst.RequiredStatusChecks == nil {
+			dst.RequiredStatusChecks = new(*src.RequiredStatusChecks)
+			dst.RequiredStatusChecks.RequiredStatusChecks = slices.Clone(src.RequiredStatusChecks.RequiredStatusChecks)
+		} else {
+			checks := dst.RequiredStatusChecks
+			checks.StrictRequiredStatusChecksPolicy = checks.StrictRequiredStatusChecksPolicy || src.RequiredStatusChecks.StrictRequiredStatusChecksPolicy
+			if !src.RequiredStatusChecks.GetDoNotEnforceOnCreate() {
+				checks.DoNotEnforceOnCreate = nil
+			}
+			for _, check := range src.RequiredStatusChecks.RequiredStatusChecks {
+				if !slices.ContainsFunc(checks.RequiredStatusChecks, func(c *github.RuleStatusCheck) bool {
+					return c.Context == check.Context && c.GetIntegrationID() == check.GetIntegrationID()
+				}) {
+					checks.RequiredStatusChecks = append(checks.RequiredStatusChecks, check)
+				}
+			}
+		}
+	}
+
+	if src.RequiredDeployments != nil {
+		if dst.RequiredDeployments == nil {
+			dst.RequiredDeployments = &github.RequiredDeploymentsRuleParameters{}
+		}
+		dst.RequiredDeployments.RequiredDeploymentEnvironments = unionStrings(dst.RequiredDeployments.RequiredDeploymentEnvironments, src.RequiredDeployments.RequiredDeploymentEnvironments)
+	}
+
+	if src.Workflows != nil {
+		if dst.Workflows == nil {
+			dst.Workflows = new(*src.Workflows)
+			dst.Workflows.Workflows = slices.Clone(src.Workflows.Workflows)
+		} else {
+			if !src.Workflows.GetDoNotEnforceOnCreate() {
+				dst.Workflows.DoNotEnforceOnCreate = nil
+			}
+			dst.Workflows.Workflows = append(dst.Workflows.Workflows, src.Workflows.Workflows...)
+		}
+	}
+
+	if src.CodeScanning != nil {
+		if dst.CodeScanning == nil {
+			dst.CodeScanning = &github.CodeScanningRuleParameters{}
+		}
+		dst.CodeScanning.CodeScanningTools = append(dst.CodeScanning.CodeScanningTools, src.CodeScanning.CodeScanningTools...)
+	}
+
+	if src.CopilotCodeReview != nil {
+		if dst.CopilotCodeReview == nil {
+			dst.CopilotCodeReview = &github.CopilotCodeReviewRuleParameters{}
+		}
+		dst.CopilotCodeReview.ReviewOnPush = dst.CopilotCodeReview.ReviewOnPush || src.CopilotCodeReview.ReviewOnPush
+		dst.CopilotCodeReview.ReviewDraftPullRequests = dst.CopilotCodeReview.ReviewDraftPullRequests || src.CopilotCodeReview.ReviewDraftPullRequests
+	}
+
+	if src.FileExtensionRestriction != nil {
+		if dst.FileExtensionRestriction == nil {
+			dst.FileExtensionRestriction = &github.FileExtensionRestrictionRuleParameters{}
+		}
+		dst.FileExtensionRestriction.RestrictedFileExtensions = unionStrings(dst.FileExtensionRestriction.RestrictedFileExtensions, src.FileExtensionRestriction.RestrictedFileExtensions)
+	}
+
+	if src.FilePathRestriction != nil {
+		if dst.FilePathRestriction == nil {
+			dst.FilePathRestriction = &github.FilePathRestrictionRuleParameters{}
+		}
+		dst.FilePathRestriction.RestrictedFilePaths = unionStrings(dst.FilePathRestriction.RestrictedFilePaths, src.FilePathRestriction.RestrictedFilePaths)
+	}
+
+	if src.MaxFilePathLength != nil {
+		if dst.MaxFilePathLength == nil {
+			dst.MaxFilePathLength = new(*src.MaxFilePathLength)
+		} else {
+			dst.MaxFilePathLength.MaxFilePathLength = min(dst.MaxFilePathLength.MaxFilePathLength, src.MaxFilePathLength.MaxFilePathLength)
+		}
+	}
+
+	if src.MaxFileSize != nil {
+		if dst.MaxFileSize == nil {
+			dst.MaxFileSize = new(*src.MaxFileSize)
+		} else {
+			dst.MaxFileSize.MaxFileSize = min(dst.MaxFileSize.MaxFileSize, src.MaxFileSize.MaxFileSize)
+		}
+	}
+}
+
+// unionStrings returns the strings of a followed by the strings of b which aren't in a.
+func unionStrings(a, b []string) []string {
+	result := slices.Clone(a)
+	for _, s := range b {
+		if !slices.Contains(result, s) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
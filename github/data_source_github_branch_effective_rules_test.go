@@ -0,0 +1,191 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGithubBranchEffectiveRulesDataSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads_rules_of_ruleset_and_branch_protection", func(t *testing.T) {
+		t.Parallel()
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		repoName := fmt.Sprintf("%srepo-effective-rules-%s", testResourcePrefix, randomID)
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name      = "%s"
+				auto_init = true
+			}
+
+			resource "github_repository_ruleset" "test" {
+				name        = "test"
+				repository  = github_repository.test.name
+				target      = "branch"
+				enforcement = "active"
+
+				conditions {
+					ref_name {
+						include = ["~DEFAULT_BRANCH"]
+						exclude = []
+					}
+				}
+
+				rules {
+					deletion = true
+
+					pull_request {
+						required_approving_review_count = 1
+					}
+				}
+			}
+
+			resource "github_branch_protection" "test" {
+				repository_id = github_repository.test.node_id
+				pattern       = "main"
+
+				required_pull_request_reviews {
+					required_approving_review_count = 2
+				}
+			}
+
+			data "github_branch_effective_rules" "test" {
+				repository = github_repository.test.name
+				branch     = "main"
+
+				depends_on = [github_repository_ruleset.test, github_branch_protection.test]
+			}
+		`, repoName)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.github_branch_effective_rules.test", "sources.#", "2"),
+						resource.TestCheckResourceAttr("data.github_branch_effective_rules.test", "sources.0.source_type", "Repository"),
+						resource.TestCheckResourceAttr("data.github_branch_effective_rules.test", "sources.0.rules.0.pull_request.0.required_approving_review_count", "1"),
+						resource.TestCheckResourceAttr("data.github_branch_effective_rules.test", "sources.1.source_type", "BranchProtection"),
+						resource.TestCheckResourceAttr("data.github_branch_effective_rules.test", "sources.1.source", "main"),
+						resource.TestCheckResourceAttr("data.github_branch_effective_rules.test", "rules.0.deletion", "true"),
+						resource.TestCheckResourceAttr("data.github_branch_effective_rules.test", "rules.0.pull_request.0.required_approving_review_count", "2"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestBranchRulesBySource(t *testing.T) {
+	t.Parallel()
+
+	repository := github.BranchRuleMetadata{RulesetSourceType: github.RulesetSourceTypeRepository, RulesetSource: "owner/repo", RulesetID: 1}
+	organization := github.BranchRuleMetadata{RulesetSourceType: github.RulesetSourceTypeOrganization, RulesetSource: "owner", RulesetID: 2}
+
+	branchRules := &github.BranchRules{
+		Deletion: []*github.BranchRuleMetadata{&organization, &repository},
+		PullRequest: []*github.PullRequestBranchRule{
+			{BranchRuleMetadata: repository, Parameters: github.PullRequestRuleParameters{RequiredApprovingReviewCount: 1}},
+		},
+		MaxFileSize: []*github.MaxFileSizeBranchRule{
+			{BranchRuleMetadata: organization, Parameters: github.MaxFileSizeRuleParameters{MaxFileSize: 10}},
+		},
+	}
+
+	sources := branchRulesBySource(branchRules)
+
+	if len(sources) != 2 {
+		t.Fatalf("expected 2 sources, got %d", len(sources))
+	}
+	if sources[0].sourceType != "Organization" || sources[0].source != "owner" || sources[0].rulesetID != 2 {
+		t.Errorf("unexpected first source: %+v", sources[0])
+	}
+	if sources[0].rules.Deletion == nil || sources[0].rules.PullRequest != nil || sources[0].rules.MaxFileSize.MaxFileSize != 10 {
+		t.Errorf("unexpected rules of the organization ruleset: %+v", sources[0].rules)
+	}
+	if sources[1].rules.Deletion == nil || sources[1].rules.PullRequest.RequiredApprovingReviewCount != 1 || sources[1].rules.MaxFileSize != nil {
+		t.Errorf("unexpected rules of the repository ruleset: %+v", sources[1].rules)
+	}
+}
+
+func TestMergeRulesetRules(t *testing.T) {
+	t.Parallel()
+
+	first := &github.RepositoryRulesetRules{
+		Deletion: &github.EmptyRuleParameters{},
+		PullRequest: &github.PullRequestRuleParameters{
+			AllowedMergeMethods:          []github.PullRequestMergeMethod{github.PullRequestMergeMethodMerge, github.PullRequestMergeMethodSquash},
+			RequiredApprovingReviewCount: 1,
+			RequireCodeOwnerReview:       true,
+		},
+		RequiredStatusChecks: &github.RequiredStatusChecksRuleParameters{
+			DoNotEnforceOnCreate: new(true),
+			RequiredStatusChecks: []*github.RuleStatusCheck{{Context: "ci/build"}},
+		},
+		MaxFileSize: &github.MaxFileSizeRuleParameters{MaxFileSize: 100},
+	}
+	second := &github.RepositoryRulesetRules{
+		NonFastForward: &github.EmptyRuleParameters{},
+		PullRequest: &github.PullRequestRuleParameters{
+			AllowedMergeMethods:          []github.PullRequestMergeMethod{github.PullRequestMergeMethodSquash, github.PullRequestMergeMethodRebase},
+			RequiredApprovingReviewCount: 2,
+		},
+		RequiredStatusChecks: &github.RequiredStatusChecksRuleParameters{
+			StrictRequiredStatusChecksPolicy: true,
+			RequiredStatusChecks:             []*github.RuleStatusCheck{{Context: "ci/build"}, {Context: "ci/test"}},
+		},
+		RequiredDeployments: &github.RequiredDeploymentsRuleParameters{RequiredDeploymentEnvironments: []string{"staging"}},
+		MaxFileSize:         &github.MaxFileSizeRuleParameters{MaxFileSize: 50},
+	}
+
+	merged := &github.RepositoryRulesetRules{}
+	mergeRulesetRules(merged, first)
+	mergeRulesetRules(merged, second)
+
+	if merged.Deletion == nil || merged.NonFastForward == nil {
+		t.Errorf("expected the deletion and non_fast_forward rules, got %+v", merged)
+	}
+
+	pr := merged.PullRequest
+	if pr.RequiredApprovingReviewCount != 2 || !pr.RequireCodeOwnerReview {
+		t.Errorf("expected 2 required approving reviews and code owner reviews, got %+v", pr)
+	}
+	if !slices.Equal(pr.AllowedMergeMethods, []github.PullRequestMergeMethod{github.PullRequestMergeMethodSquash}) {
+		t.Errorf("expected only squash merges to be allowed, got %v", pr.AllowedMergeMethods)
+	}
+
+	checks := merged.RequiredStatusChecks
+	if len(checks.RequiredStatusChecks) != 2 || !checks.StrictRequiredStatusChecksPolicy || checks.DoNotEnforceOnCreate != nil {
+		t.Errorf("unexpected required status checks: %+v", checks)
+	}
+
+	if !slices.Equal(merged.RequiredDeployments.RequiredDeploymentEnvironments, []string{"staging"}) {
+		t.Errorf("expected the staging deployment to be required, got %v", merged.RequiredDeployments.RequiredDeploymentEnvironments)
+	}
+	if merged.MaxFileSize.MaxFileSize != 50 {
+		t.Errorf("expected a max file size of 50, got %d", merged.MaxFileSize.MaxFileSize)
+	}
+
+	if first.PullRequest.RequiredApprovingReviewCount != 1 || len(first.PullRequest.AllowedMergeMethods) != 2 || len(first.RequiredStatusChecks.RequiredStatusChecks) != 1 {
+		t.Errorf("expected the merged rules not to be changed, got %+v", first)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubBranchEffectiveRules().Schema, map[string]any{})
+	if err := d.Set("rules", flattenEffectiveRules(context.Background(), merged)); err != nil {
+		t.Fatalf("failed to set rules: %s", err)
+	}
+	if got := d.Get("rules.0.pull_request.0.required_approving_review_count"); got != 2 {
+		t.Errorf("expected 2 required approving reviews, got %v", got)
+	}
+}
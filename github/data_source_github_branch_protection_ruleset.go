@@ -0,0 +1,292 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// repositoryRoleAdminID is the ID of the admin repository role as a ruleset bypass actor.
+const repositoryRoleAdminID = 5
+
+func dataSourceGithubBranchProtectionRuleset() *schema.Resource {
+	rulesetSchema := resourceGithubRepositoryRuleset().Schema
+
+	return &schema.Resource{
+		ReadContext: dataSourceGithubBranchProtectionRulesetRead,
+
+		Description: "Data source to convert a branch protection rule into the equivalent arguments of a `github_repository_ruleset`.",
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name or node ID of the repository of the branch protection rule.",
+			},
+			"pattern": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The pattern of the branch protection rule.",
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The `rules` of the equivalent repository ruleset.",
+				Elem:        &schema.Resource{Schema: computedSchema(rulesetSchema["rules"].Elem.(*schema.Resource).Schema)},
+			},
+			"conditions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The `conditions` of the equivalent repository ruleset.",
+				Elem:        &schema.Resource{Schema: computedSchema(rulesetSchema["conditions"].Elem.(*schema.Resource).Schema)},
+			},
+			"bypass_actors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The `bypass_actors` of the equivalent repository ruleset.",
+				Elem:        &schema.Resource{Schema: computedSchema(rulesetSchema["bypass_actors"].Elem.(*schema.Resource).Schema)},
+			},
+			"unsupported_settings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The settings of the branch protection rule which the repository ruleset can't express one-to-one.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// computedSchema returns a copy of the resource schema with every attribute computed, so a data source can return values in the shape the resource accepts.
+func computedSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	result := make(map[string]*schema.Schema, len(resourceSchema))
+
+	for k, v := range resourceSchema {
+		s := &schema.Schema{
+			Type:        v.Type,
+			Computed:    true,
+			Description: v.Description,
+		}
+
+		switch elem := v.Elem.(type) {
+		case *schema.Resource:
+			s.Elem = &schema.Resource{Schema: computedSchema(elem.Schema)}
+		case *schema.Schema:
+			s.Elem = &schema.Schema{Type: elem.Type}
+		}
+
+		result[k] = s
+	}
+
+	return result
+}
+
+func dataSourceGithubBranchProtectionRulesetRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta := m.(*Owner)
+	repoName := d.Get("repository").(string)
+	pattern := d.Get("pattern").(string)
+
+	repoID, err := getRepositoryID(repoName, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	protectionID, err := getBranchProtectionID(repoID, pattern, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var query struct {
+		Node struct {
+			Node BranchProtectionRule `graphql:"... on BranchProtectionRule"`
+		} `graphql:"node(id: $id)"`
+	}
+	variables := map[string]any{
+		"id": protectionID,
+	}
+	if err := meta.v4client.Query(ctx, &query, variables); err != nil {
+		return diag.FromErr(err)
+	}
+
+	ruleset, unsupported := branchProtectionRuleToRuleset(query.Node.Node)
+
+	tflog.Debug(ctx, "Converted branch protection rule to a ruleset", map[string]any{
+		"repository":           repoName,
+		"pattern":              pattern,
+		"unsupported_settings": unsupported,
+	})
+
+	d.SetId(fmt.Sprint(protectionID))
+
+	if err := d.Set("rules", flattenRules(ctx, ruleset.Rules, false)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("conditions", flattenConditions(ctx, ruleset.Conditions, false)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("bypass_actors", flattenBypassActors(ctx, ruleset.BypassActors)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("unsupported_settings", unsupported); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// branchProtectionRuleToRuleset converts a branch protection rule into the equivalent branch ruleset of a repository.
+// It also returns a description of each setting which the ruleset can't express one-to-one.
+func branchProtectionRuleToRuleset(protection BranchProtectionRule) (*github.RepositoryRuleset, []string) {
+	unsupported := []string{}
+	rules := &github.RepositoryRulesetRules{}
+
+	if !protection.AllowsDeletions {
+		rules.Deletion = &github.EmptyRuleParameters{}
+	}
+	if !protection.AllowsForcePushes {
+		rules.NonFastForward = &github.EmptyRuleParameters{}
+	}
+	if protection.RequiresCommitSignatures {
+		rules.RequiredSignatures = &github.EmptyRuleParameters{}
+	}
+	if protection.RequiresLinearHistory {
+		rules.RequiredLinearHistory = &github.EmptyRuleParameters{}
+	}
+
+	if protection.RestrictsPushes {
+		rules.Update = &github.UpdateRuleParameters{}
+		if protection.BlocksCreations {
+			rules.Creation = &github.EmptyRuleParameters{}
+		}
+	}
+	if protection.LockBranch {
+		rules.Update = &github.UpdateRuleParameters{}
+	}
+
+	if protection.RequiresApprovingReviews || protection.RequiresConversationResolution {
+		rules.PullRequest = &github.PullRequestRuleParameters{
+			AllowedMergeMethods: []github.PullRequestMergeMethod{
+				github.PullRequestMergeMethodMerge,
+				github.PullRequestMergeMethodSquash,
+				github.PullRequestMergeMethodRebase,
+			},
+			RequiredReviewThreadResolution: bool(protection.RequiresConversationResolution),
+		}
+
+		if protection.RequiresApprovingReviews {
+			rules.PullRequest.RequiredApprovingReviewCount = int(protection.RequiredApprovingReviewCount)
+			rules.PullRequest.DismissStaleReviewsOnPush = bool(protection.DismissesStaleReviews)
+			rules.PullRequest.RequireCodeOwnerReview = bool(protection.RequiresCodeOwnerReviews)
+			rules.PullRequest.RequireLastPushApproval = bool(protection.RequireLastPushApproval)
+		} else {
+			unsupported = append(unsupported, fmt.Sprintf("%s is converted to the pull_request rule, which also requires changes to be made through pull requests", PROTECTION_REQUIRES_CONVERSATION_RESOLUTION))
+		}
+	}
+
+	if protection.RequiresStatusChecks {
+		checks := make([]*github.RuleStatusCheck, 0, len(protection.RequiredStatusChecks))
+		for _, check := range protection.RequiredStatusChecks {
+			ruleCheck := &github.RuleStatusCheck{Context: string(check.Context)}
+			if check.App != nil {
+				ruleCheck.IntegrationID = new(int64(check.App.DatabaseID))
+			}
+			checks = append(checks, ruleCheck)
+		}
+
+		if len(checks) > 0 {
+			rules.RequiredStatusChecks = &github.RequiredStatusChecksRuleParameters{
+				RequiredStatusChecks:             checks,
+				StrictRequiredStatusChecksPolicy: bool(protection.RequiresStrictStatusChecks),
+			}
+		} else {
+			unsupported = append(unsupported, fmt.Sprintf("%s without any status checks can't be converted, as the required_status_checks rule requires at least one status check", PROTECTION_REQUIRES_STATUS_CHECKS))
+		}
+	}
+
+	if protection.RequiresDeployments {
+		environments := make([]string, 0, len(protection.RequiredDeploymentEnvironments))
+		for _, environment := range protection.RequiredDeploymentEnvironments {
+			environments = append(environments, string(environment))
+		}
+		rules.RequiredDeployments = &github.RequiredDeploymentsRuleParameters{RequiredDeploymentEnvironments: environments}
+	}
+
+	var bypassActors []*github.BypassActor
+	addBypassActor := func(actorType github.BypassActorType, actorID int64) {
+		for _, actor := range bypassActors {
+			if *actor.ActorType == actorType && actor.GetActorID() == actorID {
+				return
+			}
+		}
+		bypassActors = append(bypassActors, &github.BypassActor{
+			ActorID:    new(actorID),
+			ActorType:  new(actorType),
+			BypassMode: new(github.BypassModeAlways),
+		})
+	}
+
+	if !protection.IsAdminEnforced {
+		addBypassActor(github.BypassActorTypeRepositoryRole, repositoryRoleAdminID)
+	}
+
+	// Rulesets can only be bypassed as a whole, so the actors allowed to bypass a single setting become bypass actors of the ruleset.
+	var allowances []string
+	addAllowance := func(setting string, app, team Actor, user ActorUser) {
+		addBypassActor(allowanceBypassActor(app, team, user))
+		if !slices.Contains(allowances, setting) {
+			allowances = append(allowances, setting)
+		}
+	}
+	if protection.RestrictsPushes {
+		for _, a := range protection.PushAllowances.Nodes {
+			addAllowance(PROTECTION_PUSH_ALLOWANCES, a.Actor.App, a.Actor.Team, a.Actor.User)
+		}
+	}
+	if !protection.AllowsForcePushes {
+		for _, a := range protection.BypassForcePushAllowances.Nodes {
+			addAllowance(PROTECTION_FORCE_PUSHES_BYPASSERS, a.Actor.App, a.Actor.Team, a.Actor.User)
+		}
+	}
+	if protection.RequiresApprovingReviews {
+		for _, a := range protection.BypassPullRequestAllowances.Nodes {
+			addAllowance(PROTECTION_PULL_REQUESTS_BYPASSERS, a.Actor.App, a.Actor.Team, a.Actor.User)
+		}
+	}
+	for _, setting := range allowances {
+		unsupported = append(unsupported, fmt.Sprintf("%s are converted to bypass actors, which can bypass all the rules of the ruleset", setting))
+	}
+
+	if protection.RequiresApprovingReviews && protection.RestrictsReviewDismissals && len(protection.ReviewDismissalAllowances.Nodes) > 0 {
+		unsupported = append(unsupported, fmt.Sprintf("%s can't be converted, as rulesets can't restrict who can dismiss pull request reviews", PROTECTION_REVIEW_DISMISSAL_ALLOWANCES))
+	}
+
+	ruleset := &github.RepositoryRuleset{
+		Target: new(github.RulesetTargetBranch),
+		Conditions: &github.RepositoryRulesetConditions{
+			RefName: &github.RepositoryRulesetRefConditionParameters{
+				Include: []string{"refs/heads/" + string(protection.Pattern)},
+				Exclude: []string{},
+			},
+		},
+		Rules:        rules,
+		BypassActors: bypassActors,
+	}
+
+	return ruleset, unsupported
+}
+
+// allowanceBypassActor returns the type and ID of the bypass actor of the actor of a branch protection allowance.
+func allowanceBypassActor(app, team Actor, user ActorUser) (github.BypassActorType, int64) {
+	switch {
+	case app != (Actor{}):
+		return github.BypassActorTypeIntegration, int64(app.DatabaseID)
+	case team != (Actor{}):
+		return github.BypassActorTypeTeam, int64(team.DatabaseID)
+	default:
+		return github.BypassActorType("User"), int64(user.DatabaseID)
+	}
+}
@@ -0,0 +1,228 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/shurcooL/githubv4"
+)
+
+func TestAccGithubBranchProtectionRulesetDataSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("converts_branch_protection_to_ruleset", func(t *testing.T) {
+		t.Parallel()
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		repoName := fmt.Sprintf("%srepo-bp-ruleset-%s", testResourcePrefix, randomID)
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name      = "%s"
+				auto_init = true
+			}
+
+			resource "github_branch_protection" "test" {
+				repository_id           = github_repository.test.node_id
+				pattern                 = "main"
+				enforce_admins          = true
+				required_linear_history = true
+
+				required_status_checks {
+					strict   = true
+					contexts = ["ci/test"]
+				}
+
+				required_pull_request_reviews {
+					required_approving_review_count = 2
+					dismiss_stale_reviews           = true
+				}
+			}
+
+			data "github_branch_protection_ruleset" "test" {
+				repository = github_repository.test.name
+				pattern    = github_branch_protection.test.pattern
+			}
+
+			resource "github_repository_ruleset" "test" {
+				name        = "migrated"
+				repository  = github_repository.test.name
+				target      = "branch"
+				enforcement = "evaluate"
+
+				conditions {
+					ref_name {
+						include = data.github_branch_protection_ruleset.test.conditions[0].ref_name[0].include
+						exclude = []
+					}
+				}
+
+				rules {
+					deletion                = data.github_branch_protection_ruleset.test.rules[0].deletion
+					non_fast_forward        = data.github_branch_protection_ruleset.test.rules[0].non_fast_forward
+					required_linear_history = data.github_branch_protection_ruleset.test.rules[0].required_linear_history
+
+					pull_request {
+						required_approving_review_count = data.github_branch_protection_ruleset.test.rules[0].pull_request[0].required_approving_review_count
+						dismiss_stale_reviews_on_push   = data.github_branch_protection_ruleset.test.rules[0].pull_request[0].dismiss_stale_reviews_on_push
+					}
+				}
+			}
+		`, repoName)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.github_branch_protection_ruleset.test", "conditions.0.ref_name.0.include.0", "refs/heads/main"),
+						resource.TestCheckResourceAttr("data.github_branch_protection_ruleset.test", "rules.0.deletion", "true"),
+						resource.TestCheckResourceAttr("data.github_branch_protection_ruleset.test", "rules.0.non_fast_forward", "true"),
+						resource.TestCheckResourceAttr("data.github_branch_protection_ruleset.test", "rules.0.required_linear_history", "true"),
+						resource.TestCheckResourceAttr("data.github_branch_protection_ruleset.test", "rules.0.pull_request.0.required_approving_review_count", "2"),
+						resource.TestCheckResourceAttr("data.github_branch_protection_ruleset.test", "rules.0.required_status_checks.0.strict_required_status_checks_policy", "true"),
+						resource.TestCheckResourceAttr("data.github_branch_protection_ruleset.test", "bypass_actors.#", "0"),
+						resource.TestCheckResourceAttr("data.github_branch_protection_ruleset.test", "unsupported_settings.#", "0"),
+						resource.TestCheckResourceAttr("github_repository_ruleset.test", "rules.0.pull_request.0.required_approving_review_count", "2"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestBranchProtectionRuleToRuleset(t *testing.T) {
+	t.Parallel()
+
+	t.Run("converts_rules", func(t *testing.T) {
+		t.Parallel()
+
+		protection := BranchProtectionRule{
+			Pattern:                        "release/*",
+			AllowsForcePushes:              true,
+			IsAdminEnforced:                true,
+			RequiresCommitSignatures:       true,
+			RequiresApprovingReviews:       true,
+			RequiredApprovingReviewCount:   1,
+			RequiresCodeOwnerReviews:       true,
+			RequiresConversationResolution: true,
+			RequiresStatusChecks:           true,
+			RequiredStatusChecks: []RequiredStatusCheckDescription{
+				{Context: "ci/any"},
+				{Context: "ci/app", App: &struct {
+					DatabaseID githubv4.Int `graphql:"databaseId"`
+				}{DatabaseID: 42}},
+			},
+			RequiresDeployments:            true,
+			RequiredDeploymentEnvironments: []githubv4.String{"staging"},
+			LockBranch:                     true,
+		}
+
+		ruleset, unsupported := branchProtectionRuleToRuleset(protection)
+
+		if got := ruleset.Conditions.RefName.Include; !slices.Equal(got, []string{"refs/heads/release/*"}) {
+			t.Errorf("expected the ref name condition to include refs/heads/release/*, got %v", got)
+		}
+
+		rules := ruleset.Rules
+		if rules.Deletion == nil || rules.NonFastForward != nil || rules.RequiredSignatures == nil || rules.Update == nil || rules.Creation != nil {
+			t.Errorf("unexpected simple rules: %+v", rules)
+		}
+		if pr := rules.PullRequest; pr == nil || pr.RequiredApprovingReviewCount != 1 || !pr.RequireCodeOwnerReview || !pr.RequiredReviewThreadResolution {
+			t.Errorf("unexpected pull_request rule: %+v", pr)
+		}
+		if checks := rules.RequiredStatusChecks.RequiredStatusChecks; len(checks) != 2 || checks[0].IntegrationID != nil || checks[1].GetIntegrationID() != 42 {
+			t.Errorf("unexpected required status checks: %+v", checks)
+		}
+		if got := rules.RequiredDeployments.RequiredDeploymentEnvironments; !slices.Equal(got, []string{"staging"}) {
+			t.Errorf("expected the required deployment environments to be [staging], got %v", got)
+		}
+
+		if len(ruleset.BypassActors) != 0 {
+			t.Errorf("expected no bypass actors, got %d", len(ruleset.BypassActors))
+		}
+		if len(unsupported) != 0 {
+			t.Errorf("expected no unsupported settings, got %v", unsupported)
+		}
+	})
+
+	t.Run("converts_allowances_to_bypass_actors", func(t *testing.T) {
+		t.Parallel()
+
+		protection := BranchProtectionRule{
+			Pattern:                   "main",
+			AllowsDeletions:           true,
+			RestrictsPushes:           true,
+			BlocksCreations:           true,
+			RequiresApprovingReviews:  true,
+			RestrictsReviewDismissals: true,
+		}
+		protection.PushAllowances.Nodes = make([]PushActorTypes, 2)
+		protection.PushAllowances.Nodes[0].Actor.Team = Actor{ID: "T_1", DatabaseID: 1}
+		protection.PushAllowances.Nodes[1].Actor.App = Actor{ID: "A_2", DatabaseID: 2}
+		protection.BypassForcePushAllowances.Nodes = make([]BypassForcePushActorTypes, 1)
+		protection.BypassForcePushAllowances.Nodes[0].Actor.Team = Actor{ID: "T_1", DatabaseID: 1}
+		protection.BypassPullRequestAllowances.Nodes = make([]BypassPullRequestActorTypes, 1)
+		protection.BypassPullRequestAllowances.Nodes[0].Actor.User = ActorUser{ID: "U_3", DatabaseID: 3}
+		protection.ReviewDismissalAllowances.Nodes = make([]DismissalActorTypes, 1)
+		protection.ReviewDismissalAllowances.Nodes[0].Actor.User = ActorUser{ID: "U_3", DatabaseID: 3}
+
+		ruleset, unsupported := branchProtectionRuleToRuleset(protection)
+
+		if ruleset.Rules.Deletion != nil || ruleset.Rules.Update == nil || ruleset.Rules.Creation == nil {
+			t.Errorf("unexpected simple rules: %+v", ruleset.Rules)
+		}
+
+		var actors []string
+		for _, actor := range ruleset.BypassActors {
+			actors = append(actors, fmt.Sprintf("%s:%d:%s", *actor.ActorType, actor.GetActorID(), *actor.BypassMode))
+		}
+		wantActors := []string{"RepositoryRole:5:always", "Team:1:always", "Integration:2:always", "User:3:always"}
+		if !slices.Equal(actors, wantActors) {
+			t.Errorf("expected bypass actors %v, got %v", wantActors, actors)
+		}
+
+		if len(unsupported) != 4 {
+			t.Errorf("expected 4 unsupported settings, got %v", unsupported)
+		}
+	})
+
+	t.Run("flattens_to_the_data_source_schema", func(t *testing.T) {
+		t.Parallel()
+
+		protection := BranchProtectionRule{
+			Pattern:                  "main",
+			RequiresApprovingReviews: true,
+			RequiresStatusChecks:     true,
+			RequiredStatusChecks:     []RequiredStatusCheckDescription{{Context: "ci/test"}},
+		}
+		ruleset, _ := branchProtectionRuleToRuleset(protection)
+
+		d := schema.TestResourceDataRaw(t, dataSourceGithubBranchProtectionRuleset().Schema, map[string]any{})
+		ctx := context.Background()
+
+		if err := d.Set("rules", flattenRules(ctx, ruleset.Rules, false)); err != nil {
+			t.Fatalf("failed to set rules: %s", err)
+		}
+		if err := d.Set("conditions", flattenConditions(ctx, ruleset.Conditions, false)); err != nil {
+			t.Fatalf("failed to set conditions: %s", err)
+		}
+		if err := d.Set("bypass_actors", flattenBypassActors(ctx, ruleset.BypassActors)); err != nil {
+			t.Fatalf("failed to set bypass actors: %s", err)
+		}
+
+		if got := d.Get("rules.0.required_status_checks.0.required_check.#"); got != 1 {
+			t.Errorf("expected 1 required check, got %v", got)
+		}
+		if got := d.Get("bypass_actors.0.actor_id"); got != repositoryRoleAdminID {
+			t.Errorf("expected the admin role to bypass the ruleset, got %v", got)
+		}
+	})
+}
@@ -54,7 +54,6 @@ func dataSourceGithubCodespacesSecrets() *schema.Resource {
 
 func dataSourceGithubCodespacesSecretsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	meta, _ := m.(*Owner)
-	client := meta.v3client
 	owner := meta.name
 
 	var repoName string
@@ -79,6 +78,11 @@ func dataSourceGithubCodespacesSecretsRead(ctx context.Context, d *schema.Resour
 		PerPage: meta.maxPerPage,
 	}
 
+	client, err := meta.restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var all_secrets []map[string]string
 	for {
 		secrets, resp, err := client.Codespaces.ListRepoSecrets(ctx, owner, repoName, &options)
@@ -100,7 +104,7 @@ func dataSourceGithubCodespacesSecretsRead(ctx context.Context, d *schema.Resour
 	}
 
 	d.SetId(repoName)
-	err := d.Set("secrets", all_secrets)
+	err = d.Set("secrets", all_secrets)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -123,8 +123,6 @@ func dataSourceGithubCollaborators() *schema.Resource {
 
 func dataSourceGithubCollaboratorsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	meta, _ := m.(*Owner)
-	client := meta.v3client
-
 	owner := d.Get("owner").(string)
 	repo := d.Get("repository").(string)
 	affiliation := d.Get("affiliation").(string)
@@ -160,6 +158,11 @@ func dataSourceGithubCollaboratorsRead(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
+	client, err := meta.restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	totalCollaborators := make([]any, 0)
 	for {
 		collaborators, resp, err := client.Repositories.ListCollaborators(ctx, owner, repo, options)
@@ -43,7 +43,6 @@ func dataSourceGithubRef() *schema.Resource {
 }
 
 func dataSourceGithubRefRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	client := meta.(*Owner).v3client
 	owner, ok := d.Get("owner").(string)
 	if !ok {
 		owner = meta.(*Owner).name
@@ -51,6 +50,11 @@ func dataSourceGithubRefRead(ctx context.Context, d *schema.ResourceData, meta a
 	repoName := d.Get("repository").(string)
 	ref := d.Get("ref").(string)
 
+	client, err := meta.(*Owner).restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	refData, resp, err := client.Git.GetRef(ctx, owner, repoName, ref)
 	if err != nil {
 		var ghErr *github.ErrorResponse
@@ -160,9 +160,11 @@ func dataSourceGithubReleaseRead(ctx context.Context, d *schema.ResourceData, me
 	repository := d.Get("repository").(string)
 	owner := d.Get("owner").(string)
 
-	client := meta.(*Owner).v3client
+	client, err := meta.(*Owner).restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	var err error
 	var release *github.RepositoryRelease
 
 	switch retrieveBy := strings.ToLower(d.Get("retrieve_by").(string)); retrieveBy {
@@ -97,7 +97,10 @@ func dataSourceGithubReleaseAssetRead(ctx context.Context, d *schema.ResourceDat
 	repository := d.Get("repository").(string)
 	owner := d.Get("owner").(string)
 
-	client := meta.(*Owner).v3client
+	client, err := meta.(*Owner).restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	assetID := int64(d.Get("asset_id").(int))
 	asset, _, err := client.Repositories.GetReleaseAsset(ctx, owner, repository, assetID)
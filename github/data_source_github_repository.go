@@ -347,7 +347,6 @@ func dataSourceGithubRepository() *schema.Resource {
 }
 
 func dataSourceGithubRepositoryRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	var repoName string
 
@@ -366,6 +365,11 @@ func dataSourceGithubRepositoryRead(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("one of %q or %q has to be provided", "full_name", "name")
 	}
 
+	client, err := meta.(*Owner).restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	repo, _, err := client.Repositories.Get(ctx, owner, repoName)
 	if err != nil {
 		var ghErr *github.ErrorResponse
@@ -0,0 +1,430 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// codeownersPaths are the locations GitHub looks for a CODEOWNERS file in, in order of precedence.
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+func dataSourceGithubRepositoryCodeowners() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubRepositoryCodeownersRead,
+
+		Description: "Validates the owners of a CODEOWNERS file of a GitHub repository without managing the file, and reads the errors GitHub reports for it.",
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The repository name.",
+			},
+			"repository_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The repository ID, or `0` if the repository doesn't exist yet.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The branch to read the CODEOWNERS file and its errors from, defaults to the repository's default branch.",
+			},
+			"path": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The path of the CODEOWNERS file, one of `.github/CODEOWNERS`, `CODEOWNERS` or `docs/CODEOWNERS`; defaults to the path GitHub uses on the branch, or `.github/CODEOWNERS` if there's no file.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(codeownersPaths, false)),
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The CODEOWNERS content to validate, e.g. the content managed by a `github_repository_file` resource; defaults to the content of the file on the branch.",
+			},
+			"validate_owners": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Check that the teams and users in the content exist and have write access to the repository.",
+			},
+			"errors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The errors GitHub reports for the CODEOWNERS file of the branch.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"line": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The line of the error.",
+						},
+						"column": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The column of the error.",
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The kind of error.",
+						},
+						"source": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The content of the line with the error.",
+						},
+						"suggestion": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A suggestion to fix the error, if any.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The message describing the error.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The path of the file with the error.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// codeownersRule is a line of a CODEOWNERS file assigning owners to a pattern.
+type codeownersRule struct {
+	line    int
+	pattern string
+	owners  []string
+}
+
+// codeownersProblem is an invalid owner on a line of a CODEOWNERS file.
+type codeownersProblem struct {
+	line    int
+	owner   string
+	message string
+}
+
+func (p codeownersProblem) String() string {
+	return fmt.Sprintf("line %d: %s: %s", p.line, p.owner, p.message)
+}
+
+// parseCodeowners returns the rules of the CODEOWNERS content, and the owners which aren't a @user, an @org/team or an email address.
+func parseCodeowners(content string) ([]codeownersRule, []codeownersProblem) {
+	var rules []codeownersRule
+	var problems []codeownersProblem
+
+	n := 0
+	for line := range strings.Lines(content) {
+		n++
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		rule := codeownersRule{line: n, pattern: fields[0]}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+
+			if codeownersOwnerKind(owner) == "" {
+				problems = append(problems, codeownersProblem{line: n, owner: owner, message: "owner must be a @username, an @org/team-name or an email address"})
+				continue
+			}
+			rule.owners = append(rule.owners, owner)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, problems
+}
+
+// codeownersOwnerKind returns whether the owner is a "team", a "user" or an "email" address, or an empty string if it's none of them.
+func codeownersOwnerKind(owner string) string {
+	name, isHandle := strings.CutPrefix(owner, "@")
+	switch {
+	case isHandle && strings.Count(name, "/") == 1 && !strings.HasPrefix(name, "/") && !strings.HasSuffix(name, "/"):
+		return "team"
+	case isHandle && name != "" && !strings.Contains(name, "/"):
+		return "user"
+	case !isHandle && strings.Count(owner, "@") == 1 && !strings.HasSuffix(owner, "@"):
+		return "email"
+	default:
+		return ""
+	}
+}
+
+func dataSourceGithubRepositoryCodeownersRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta, _ := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+
+	repoName, _ := d.Get("repository").(string)
+	branch, _ := d.Get("branch").(string)
+	path, _ := d.Get("path").(string)
+	content, hasContent := d.GetOk("content")
+
+	ctx = tflog.SetField(ctx, "repository", repoName)
+	ctx = tflog.SetField(ctx, "owner", owner)
+
+	tflog.Debug(ctx, "Reading repository CODEOWNERS.")
+
+	// The content can be validated before the repository is created, e.g. in the plan creating both, so a missing repository is only an error without content.
+	repo, _, err := client.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		if ghErr, ok := errors.AsType[*github.ErrorResponse](err); !ok || ghErr.Response.StatusCode != http.StatusNotFound || !hasContent {
+			return diag.FromErr(err)
+		}
+		tflog.Debug(ctx, "Repository not found, not checking the access of CODEOWNERS owners.")
+		repo = nil
+	}
+
+	if repo != nil && branch == "" {
+		branch = repo.GetDefaultBranch()
+	}
+	ctx = tflog.SetField(ctx, "branch", branch)
+
+	if repo != nil && (path == "" || !hasContent) {
+		found, foundContent, err := readCodeowners(ctx, client, owner, repoName, branch, path)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if found == "" && !hasContent {
+			return diag.Errorf("no CODEOWNERS file found on branch %s of repository %s/%s", branch, owner, repoName)
+		}
+		if path == "" {
+			path = found
+		}
+		if !hasContent {
+			content = foundContent
+		}
+	}
+	if path == "" {
+		path = codeownersPaths[0]
+	}
+	ctx = tflog.SetField(ctx, "file", path)
+
+	rules, problems := parseCodeowners(content.(string))
+
+	if validate, _ := d.Get("validate_owners").(bool); validate {
+		tflog.Debug(ctx, "Validating CODEOWNERS owners.")
+
+		ownerProblems, err := validateCodeownersOwners(ctx, meta, repoName, repo != nil, rules)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		problems = append(problems, ownerProblems...)
+	}
+
+	if len(problems) > 0 {
+		slices.SortStableFunc(problems, func(a, b codeownersProblem) int { return cmp.Compare(a.line, b.line) })
+
+		var diags diag.Diagnostics
+		for _, p := range problems {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Invalid owner in %s", path),
+				Detail:        p.String(),
+				AttributePath: cty.GetAttrPath("content"),
+			})
+		}
+		return diags
+	}
+
+	var reported []*github.CodeownersError
+	if repo != nil {
+		codeownersErrors, _, err := client.Repositories.GetCodeownersErrors(ctx, owner, repoName, &github.GetCodeownersErrorsOptions{Ref: branch})
+		if err != nil {
+			if ghErr, ok := errors.AsType[*github.ErrorResponse](err); !ok || ghErr.Response.StatusCode != http.StatusNotFound {
+				return diag.FromErr(err)
+			}
+			tflog.Debug(ctx, "GitHub found no CODEOWNERS file on the branch.")
+		} else if codeownersErrors != nil {
+			reported = codeownersErrors.Errors
+		}
+	}
+
+	id, err := buildID(repoName, branch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := d.Set("repository_id", int(repo.GetID())); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("branch", branch); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("path", path); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("content", content); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("errors", flattenCodeownersErrors(reported)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// readCodeowners returns the path and content of the CODEOWNERS file GitHub uses on the branch, only looking at the given path if it's set; the path is empty if there's no file.
+func readCodeowners(ctx context.Context, client *github.Client, owner, repoName, branch, path string) (string, string, error) {
+	paths := codeownersPaths
+	if path != "" {
+		paths = []string{path}
+	}
+
+	for _, p := range paths {
+		fc, _, _, err := client.Repositories.GetContents(ctx, owner, repoName, p, &github.RepositoryContentGetOptions{Ref: branch})
+		if err != nil {
+			if ghErr, ok := errors.AsType[*github.ErrorResponse](err); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return "", "", err
+		}
+		if fc == nil {
+			continue
+		}
+
+		content, err := fc.GetContent()
+		if err != nil {
+			return "", "", err
+		}
+		return p, content, nil
+	}
+
+	return "", "", nil
+}
+
+// validateCodeownersOwners returns the owners of the rules which don't exist or don't have write access to the repository; access isn't checked if the repository doesn't exist yet.
+func validateCodeownersOwners(ctx context.Context, meta *Owner, repoName string, repoExists bool, rules []codeownersRule) ([]codeownersProblem, error) {
+	client := meta.v3client
+	owner := meta.name
+
+	resolved := make(map[string]string)
+	var problems []codeownersProblem
+	for _, rule := range rules {
+		for _, o := range rule.owners {
+			message, ok := resolved[strings.ToLower(o)]
+			if !ok {
+				var err error
+				message, err = resolveCodeownersOwner(ctx, client, owner, repoName, o, repoExists)
+				if err != nil {
+					return nil, err
+				}
+				resolved[strings.ToLower(o)] = message
+			}
+
+			if message != "" {
+				problems = append(problems, codeownersProblem{line: rule.line, owner: o, message: message})
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+// resolveCodeownersOwner returns why the owner can't own files in the repository, or an empty string if it can; email addresses can't be resolved and are always accepted.
+func resolveCodeownersOwner(ctx context.Context, client *github.Client, owner, repoName, codeowner string, checkAccess bool) (string, error) {
+	isNotFound := func(err error) bool {
+		ghErr, ok := errors.AsType[*github.ErrorResponse](err)
+		return ok && ghErr.Response.StatusCode == http.StatusNotFound
+	}
+
+	switch codeownersOwnerKind(codeowner) {
+	case "team":
+		org, slug, _ := strings.Cut(strings.TrimPrefix(codeowner, "@"), "/")
+		if !strings.EqualFold(org, owner) {
+			return fmt.Sprintf("team belongs to %s, not to the repository owner %s", org, owner), nil
+		}
+
+		if _, err := lookupTeamID(ctx, client, owner, slug); err != nil {
+			if isNotFound(err) {
+				return "team not found", nil
+			}
+			return "", err
+		}
+
+		if !checkAccess {
+			return "", nil
+		}
+
+		repo, _, err := client.Teams.IsTeamRepoBySlug(ctx, owner, slug, owner, repoName)
+		if err != nil {
+			if isNotFound(err) {
+				return "team has no access to the repository", nil
+			}
+			return "", err
+		}
+
+		if permissions := repo.GetPermissions(); !permissions.GetAdmin() && !permissions.GetMaintain() && !permissions.GetPush() {
+			return "team doesn't have write access to the repository", nil
+		}
+	case "user":
+		login := strings.TrimPrefix(codeowner, "@")
+		if _, _, err := client.Users.Get(ctx, login); err != nil {
+			if isNotFound(err) {
+				return "user not found", nil
+			}
+			return "", err
+		}
+
+		if !checkAccess {
+			return "", nil
+		}
+
+		level, _, err := client.Repositories.GetPermissionLevel(ctx, owner, repoName, login)
+		if err != nil {
+			if isNotFound(err) {
+				return "user has no access to the repository", nil
+			}
+			return "", err
+		}
+
+		if permission := level.GetPermission(); permission != "admin" && permission != "write" {
+			return "user doesn't have write access to the repository", nil
+		}
+	}
+
+	return "", nil
+}
+
+func flattenCodeownersErrors(codeownersErrors []*github.CodeownersError) []any {
+	items := make([]any, 0, len(codeownersErrors))
+	for _, e := range codeownersErrors {
+		items = append(items, map[string]any{
+			"line":       e.Line,
+			"column":     e.Column,
+			"kind":       e.Kind,
+			"source":     e.Source,
+			"suggestion": e.GetSuggestion(),
+			"message":    e.Message,
+			"path":       e.Path,
+		})
+	}
+
+	return items
+}
@@ -0,0 +1,198 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccGithubRepositoryCodeownersDataSource(t *testing.T) {
+	t.Parallel()
+
+	config := `
+data "github_repository_codeowners" "test" {
+  repository = "%s"
+  content    = "%s"
+}
+`
+
+	t.Run("validates_codeowners", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+		team := mustCreateTestTeam(t)
+
+		content := fmt.Sprintf(`# Owners\n* @%s/%s\n`, testAccConf.meta.name, team.GetSlug())
+
+		resource.Test(t, resource.TestCase{
+			PreCheck: func() {
+				skipUnlessHasOrgs(t)
+
+				if _, err := testAccConf.meta.v3client.Teams.AddTeamRepoBySlug(t.Context(), testAccConf.meta.name, team.GetSlug(), testAccConf.meta.name, repo.GetName(), &github.TeamAddTeamRepoOptions{Permission: "push"}); err != nil {
+					t.Fatalf("failed to add test team to repository: %v", err)
+				}
+			},
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, repo.GetName(), content),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("data.github_repository_codeowners.test", tfjsonpath.New("path"), knownvalue.StringExact(".github/CODEOWNERS")),
+						statecheck.ExpectKnownValue("data.github_repository_codeowners.test", tfjsonpath.New("branch"), knownvalue.StringExact(repo.GetDefaultBranch())),
+						statecheck.ExpectKnownValue("data.github_repository_codeowners.test", tfjsonpath.New("errors"), knownvalue.ListSizeExact(0)),
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("rejects_invalid_owners", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+		team := mustCreateTestTeam(t)
+
+		content := fmt.Sprintf(`* @%[1]s/%[2]s-missing\n/docs/ @%[1]s/%[2]s not-an-owner\n`, testAccConf.meta.name, team.GetSlug())
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnlessHasOrgs(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      fmt.Sprintf(config, repo.GetName(), content),
+					ExpectError: regexp.MustCompile(`(?s)line 1: .*-missing: team not found.*line 2: not-an-owner: owner must be.*line 2: .*: team has no access to the repository`),
+				},
+			},
+		})
+	})
+}
+
+func Test_dataSourceGithubRepositoryCodeownersRead(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/test-org/test-repo", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(github.Repository{ID: new(int64(7)), DefaultBranch: new("main")})
+	})
+	mux.HandleFunc("GET /repos/test-org/test-repo/contents/.github/CODEOWNERS", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /repos/test-org/test-repo/contents/CODEOWNERS", func(w http.ResponseWriter, r *http.Request) {
+		if ref := r.URL.Query().Get("ref"); ref != "main" {
+			t.Errorf("expected the default branch to be read, got %q", ref)
+		}
+		_ = json.NewEncoder(w).Encode(github.RepositoryContent{Type: new("file"), Content: new("* @octocat\n/docs/ docs@example.com\n")})
+	})
+	mux.HandleFunc("GET /repos/test-org/test-repo/codeowners/errors", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(github.CodeownersErrors{Errors: []*github.CodeownersError{{Line: 1, Column: 3, Kind: "Invalid owner", Message: "Unknown owner: make sure @octocat exists and has write access to the repository", Path: "CODEOWNERS"}}})
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	client, err := github.NewClient(github.WithURLs(new(ts.URL+"/"), nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	meta := &Owner{name: "test-org", v3client: client, maxPerPage: 100}
+
+	t.Run("reads_the_file_and_its_errors", func(t *testing.T) {
+		t.Parallel()
+
+		d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryCodeowners().Schema, map[string]any{
+			"repository":      "test-repo",
+			"validate_owners": false,
+		})
+		if diags := dataSourceGithubRepositoryCodeownersRead(t.Context(), d, meta); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+
+		if d.Id() != "test-repo:main" {
+			t.Errorf("expected ID test-repo:main, got %s", d.Id())
+		}
+		if path := d.Get("path"); path != "CODEOWNERS" {
+			t.Errorf("expected the detected path CODEOWNERS, got %v", path)
+		}
+		if line := d.Get("errors.0.line"); d.Get("errors.#") != 1 || line != 1 {
+			t.Errorf("expected a single error on line 1, got %v", d.Get("errors"))
+		}
+	})
+
+	t.Run("reports_invalid_owners", func(t *testing.T) {
+		t.Parallel()
+
+		d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryCodeowners().Schema, map[string]any{
+			"repository":      "test-repo",
+			"content":         "* bad-owner\n\n/docs/ also-bad\n",
+			"validate_owners": false,
+		})
+		diags := dataSourceGithubRepositoryCodeownersRead(t.Context(), d, meta)
+
+		if len(diags) != 2 || diags[0].Detail != "line 1: bad-owner: owner must be a @username, an @org/team-name or an email address" || !strings.HasPrefix(diags[1].Detail, "line 3: also-bad: ") {
+			t.Errorf("expected an error for each invalid owner, got %v", diags)
+		}
+	})
+}
+
+func Test_parseCodeowners(t *testing.T) {
+	t.Parallel()
+
+	rules, problems := parseCodeowners(`# Comment
+
+*                @org/maintainers
+/docs/           docs@example.com @octocat # inline comment @ignored
+/build/          @org/build/extra
+/unowned/
+`)
+
+	if len(rules) != 4 {
+		t.Fatalf("expected 4 rules, got %d", len(rules))
+	}
+
+	for i, want := range []codeownersRule{
+		{line: 3, pattern: "*", owners: []string{"@org/maintainers"}},
+		{line: 4, pattern: "/docs/", owners: []string{"docs@example.com", "@octocat"}},
+		{line: 5, pattern: "/build/"},
+		{line: 6, pattern: "/unowned/"},
+	} {
+		if got := rules[i]; got.line != want.line || got.pattern != want.pattern || !slices.Equal(got.owners, want.owners) {
+			t.Errorf("expected rule %d to be %+v, got %+v", i, want, got)
+		}
+	}
+
+	if len(problems) != 1 || problems[0].line != 5 || problems[0].owner != "@org/build/extra" {
+		t.Errorf("expected a single problem with @org/build/extra on line 5, got %v", problems)
+	}
+}
+
+func Test_codeownersOwnerKind(t *testing.T) {
+	t.Parallel()
+
+	for owner, want := range map[string]string{
+		"@octocat":         "user",
+		"@org/team":        "team",
+		"user@example.com": "email",
+		"@":                "",
+		"@org/":            "",
+		"@/team":           "",
+		"@org/team/extra":  "",
+		"octocat":          "",
+		"user@":            "",
+		"a@b@example.com":  "",
+	} {
+		if got := codeownersOwnerKind(owner); got != want {
+			t.Errorf("expected kind of %q to be %q, got %q", owner, want, got)
+		}
+	}
+}
@@ -73,8 +73,6 @@ func dataSourceGithubRepositoryFile() *schema.Resource {
 }
 
 func dataSourceGithubRepositoryFileRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	client := meta.(*Owner).v3client
-
 	owner := meta.(*Owner).name
 	repo := d.Get("repository").(string)
 	diags := make(diag.Diagnostics, 0)
@@ -97,6 +95,11 @@ func dataSourceGithubRepositoryFileRead(ctx context.Context, d *schema.ResourceD
 		opts.Ref = branch.(string)
 	}
 
+	client, err := meta.(*Owner).restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	fc, dc, _, err := client.Repositories.GetContents(ctx, owner, repo, file, opts)
 	if err != nil {
 		var ghErr *github.ErrorResponse
@@ -46,11 +46,14 @@ func dataSourceGithubRepositoryMilestone() *schema.Resource {
 }
 
 func dataSourceGithubRepositoryMilestoneRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*Owner).v3client
-
 	owner := d.Get("owner").(string)
 	repoName := d.Get("repository").(string)
 
+	conn, err := meta.(*Owner).restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	number := d.Get("number").(int)
 	milestone, _, err := conn.Issues.GetMilestone(ctx, owner, repoName, number)
 	if err != nil {
@@ -92,8 +92,6 @@ func dataSourceGithubRepositoryPullRequest() *schema.Resource {
 }
 
 func dataSourceGithubRepositoryPullRequestRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	client := meta.(*Owner).v3client
-
 	owner := meta.(*Owner).name
 	if expliclitOwner, ok := d.GetOk("owner"); ok {
 		owner = expliclitOwner.(string)
@@ -102,6 +100,11 @@ func dataSourceGithubRepositoryPullRequestRead(ctx context.Context, d *schema.Re
 	repository := d.Get("base_repository").(string)
 	number := d.Get("number").(int)
 
+	client, err := meta.(*Owner).restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	pullRequest, _, err := client.PullRequests.Get(ctx, owner, repository, number)
 	if err != nil {
 		return diag.FromErr(err)
@@ -131,7 +131,6 @@ func dataSourceGithubRepositoryPullRequests() *schema.Resource {
 
 func dataSourceGithubRepositoryPullRequestsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	meta, _ := m.(*Owner)
-	client := meta.v3client
 	owner := meta.name
 
 	if explicitOwner, ok := d.GetOk("owner"); ok {
@@ -156,6 +155,11 @@ func dataSourceGithubRepositoryPullRequestsRead(ctx context.Context, d *schema.R
 
 	results := make([]map[string]any, 0)
 
+	client, err := meta.restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	for {
 		pullRequests, resp, err := client.PullRequests.List(ctx, owner, baseRepository, options)
 		if err != nil {
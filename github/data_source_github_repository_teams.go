@@ -111,8 +111,13 @@ func dataSourceGithubRepositoryTeamsRead(ctx context.Context, d *schema.Resource
 		repoName, _ = d.Get("name").(string)
 	}
 
+	client, err := meta.restClient(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var teams []map[string]any
-	for team, err := range meta.v3client.Repositories.ListTeamsIter(ctx, owner, repoName, &github.ListOptions{PerPage: meta.maxPerPage}) {
+	for team, err := range client.Repositories.ListTeamsIter(ctx, owner, repoName, &github.ListOptions{PerPage: meta.maxPerPage}) {
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -0,0 +1,351 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ruleSuite is a rule suite of the rule insights API, which isn't supported by go-github yet.
+type ruleSuite struct {
+	ID               int64                 `json:"id"`
+	ActorName        string                `json:"actor_name"`
+	Ref              string                `json:"ref"`
+	RepositoryName   string                `json:"repository_name"`
+	PushedAt         *github.Timestamp     `json:"pushed_at"`
+	Result           string                `json:"result"`
+	EvaluationResult string                `json:"evaluation_result"`
+	RuleEvaluations  []ruleSuiteEvaluation `json:"rule_evaluations,omitempty"`
+}
+
+// ruleSuiteEvaluation is the evaluation of a rule in a rule suite.
+type ruleSuiteEvaluation struct {
+	RuleSource struct {
+		Type string `json:"type"`
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"rule_source"`
+	Enforcement string `json:"enforcement"`
+	Result      string `json:"result"`
+	RuleType    string `json:"rule_type"`
+}
+
+// ruleSuitesSummary aggregates the results of rule suites.
+type ruleSuitesSummary struct {
+	passCount          int
+	failCount          int
+	bypassCount        int
+	failingRuleTypes   []string
+	matchingRuleSuites []ruleSuite
+}
+
+func dataSourceGithubRulesetRuleSuites() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubRulesetRuleSuitesRead,
+
+		Description: "Data source to summarize the rule suites (ruleset insights) of a repository or an organization, including the rules of rulesets in evaluate mode.",
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the repository to read the rule suites of. The rule suites of the organization are read if it isn't set.",
+			},
+			"repository_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"repository"},
+				Description:   "The name of a repository of the organization to filter the organization rule suites by.",
+			},
+			"ruleset_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The ID of the ruleset to summarize the rule evaluations of. The results of all the rulesets are summarized if it isn't set.",
+			},
+			"ref": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the ref to filter the rule suites by.",
+			},
+			"time_period": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "day",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"hour", "day", "week", "month"}, false)),
+				Description:      "The time period to read the rule suites of. Can be one of: `hour`, `day`, `week`, `month`. Defaults to `day`.",
+			},
+			"actor_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The handle of the user who triggered the rule suites to filter them by.",
+			},
+			"result": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "all",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"pass", "fail", "bypass", "all"}, false)),
+				Description:      "The result of the rules of active rulesets to filter the rule suites by. Can be one of: `pass`, `fail`, `bypass`, `all`. Defaults to `all`.",
+			},
+			"max_rule_suites": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The maximum number of rule suites to read, most recent first. Reading the rule evaluations of a rule suite costs an API request, so this also caps those requests. Defaults to `100`.",
+			},
+			"truncated": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether more rule suites than `max_rule_suites` matched the filters, in which case only the most recent ones were read and summarized.",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of rule suites which were evaluated.",
+			},
+			"pass_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of rule suites in which all the rules passed, including the rules of rulesets in evaluate mode.",
+			},
+			"fail_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of rule suites in which a rule failed, including the rules of rulesets in evaluate mode.",
+			},
+			"bypass_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of rule suites in which the rules were bypassed. Always 0 when `ruleset_id` is set.",
+			},
+			"failing_rule_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The sorted types of the rules which failed.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"rule_suites": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rule suites which were evaluated.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the rule suite.",
+						},
+						"actor_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The handle of the user who triggered the rule suite.",
+						},
+						"ref": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ref the rule suite was evaluated for.",
+						},
+						"repository_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the repository the rule suite was evaluated for.",
+						},
+						"pushed_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the push which triggered the rule suite happened.",
+						},
+						"result": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The result of the rules of active rulesets.",
+						},
+						"evaluation_result": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The result of the rules of active rulesets and rulesets in evaluate mode.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubRulesetRuleSuitesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+	repository := d.Get("repository").(string)
+	rulesetID := int64(d.Get("ruleset_id").(int))
+	maxRuleSuites := d.Get("max_rule_suites").(int)
+
+	path := fmt.Sprintf("repos/%s/%s/rulesets/rule-suites", owner, repository)
+	if repository == "" {
+		if ok, diags := checkOrganizationOK(meta); !ok {
+			return diags
+		}
+		path = fmt.Sprintf("orgs/%s/rulesets/rule-suites", owner)
+	}
+
+	query := url.Values{}
+	query.Set("time_period", d.Get("time_period").(string))
+	query.Set("rule_suite_result", d.Get("result").(string))
+	query.Set("per_page", strconv.Itoa(min(meta.maxPerPage, maxRuleSuites)))
+	for _, key := range []string{"ref", "actor_name", "repository_name"} {
+		if v := d.Get(key).(string); v != "" {
+			query.Set(key, v)
+		}
+	}
+
+	var suites []ruleSuite
+	page := 1
+	for page != 0 && len(suites) < maxRuleSuites {
+		query.Set("page", strconv.Itoa(page))
+
+		req, err := client.NewRequest(ctx, "GET", path+"?"+query.Encode(), nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		var pageSuites []ruleSuite
+		resp, err := client.Do(req, &pageSuites)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		suites = append(suites, pageSuites...)
+		page = resp.NextPage
+	}
+
+	truncated := page != 0 || len(suites) > maxRuleSuites
+	if truncated {
+		tflog.Debug(ctx, "Limiting rule suites", map[string]any{"rule_suites": len(suites), "max_rule_suites": maxRuleSuites})
+		suites = suites[:min(len(suites), maxRuleSuites)]
+	}
+
+	// The rule evaluations are only returned for a single rule suite, so they're only read when they're needed.
+	for i, suite := range suites {
+		if rulesetID == 0 && suite.EvaluationResult != "fail" {
+			continue
+		}
+
+		req, err := client.NewRequest(ctx, "GET", fmt.Sprintf("%s/%d", path, suite.ID), nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if _, err := client.Do(req, &suites[i]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	summary := summarizeRuleSuites(suites, rulesetID)
+
+	tflog.Debug(ctx, "Summarized rule suites", map[string]any{
+		"owner":       owner,
+		"repository":  repository,
+		"ruleset_id":  rulesetID,
+		"rule_suites": len(suites),
+		"fail_count":  summary.failCount,
+	})
+
+	ruleSuites := make([]any, 0, len(summary.matchingRuleSuites))
+	for _, suite := range summary.matchingRuleSuites {
+		ruleSuites = append(ruleSuites, map[string]any{
+			"id":                suite.ID,
+			"actor_name":        suite.ActorName,
+			"ref":               suite.Ref,
+			"repository_name":   suite.RepositoryName,
+			"pushed_at":         suite.PushedAt.String(),
+			"result":            suite.Result,
+			"evaluation_result": suite.EvaluationResult,
+		})
+	}
+
+	id, err := buildID(owner, repository, d.Get("repository_name").(string), strconv.FormatInt(rulesetID, 10), d.Get("time_period").(string), d.Get("result").(string), d.Get("actor_name").(string), d.Get("ref").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := d.Set("truncated", truncated); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("total_count", len(summary.matchingRuleSuites)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("pass_count", summary.passCount); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("fail_count", summary.failCount); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("bypass_count", summary.bypassCount); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("failing_rule_types", summary.failingRuleTypes); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("rule_suites", ruleSuites); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// summarizeRuleSuites aggregates the results of the rule suites, or of the evaluations of the ruleset in them if rulesetID isn't 0.
+// Rule suites which the ruleset wasn't evaluated in are left out.
+func summarizeRuleSuites(suites []ruleSuite, rulesetID int64) ruleSuitesSummary {
+	summary := ruleSuitesSummary{failingRuleTypes: []string{}}
+
+	for _, suite := range suites {
+		result := suite.EvaluationResult
+		if rulesetID != 0 {
+			result = ""
+		}
+
+		for _, evaluation := range suite.RuleEvaluations {
+			if rulesetID != 0 {
+				if evaluation.RuleSource.Type != "ruleset" || evaluation.RuleSource.ID != rulesetID {
+					continue
+				}
+				if result == "" {
+					result = "pass"
+				}
+			}
+
+			if evaluation.Result == "fail" {
+				result = "fail"
+				if !slices.Contains(summary.failingRuleTypes, evaluation.RuleType) {
+					summary.failingRuleTypes = append(summary.failingRuleTypes, evaluation.RuleType)
+				}
+			}
+		}
+
+		switch result {
+		case "pass":
+			summary.passCount++
+		case "fail":
+			summary.failCount++
+		case "bypass":
+			summary.bypassCount++
+		default:
+			continue
+		}
+
+		summary.matchingRuleSuites = append(summary.matchingRuleSuites, suite)
+	}
+
+	slices.Sort(summary.failingRuleTypes)
+
+	return summary
+}
@@ -0,0 +1,213 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGithubRulesetRuleSuitesDataSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads_repository_rule_suites", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+
+		config := fmt.Sprintf(`
+data "github_ruleset_rule_suites" "test" {
+  repository  = "%s"
+  time_period = "hour"
+}
+`, repo.GetName())
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.github_ruleset_rule_suites.test", "total_count", "0"),
+						resource.TestCheckResourceAttr("data.github_ruleset_rule_suites.test", "fail_count", "0"),
+						resource.TestCheckResourceAttr("data.github_ruleset_rule_suites.test", "failing_rule_types.#", "0"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("reads_organization_rule_suites", func(t *testing.T) {
+		t.Parallel()
+
+		config := `
+data "github_ruleset_rule_suites" "test" {
+  time_period = "hour"
+  result      = "fail"
+}
+`
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnlessHasPaidOrgs(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet("data.github_ruleset_rule_suites.test", "total_count"),
+						resource.TestCheckResourceAttr("data.github_ruleset_rule_suites.test", "pass_count", "0"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func Test_dataSourceGithubRulesetRuleSuitesRead(t *testing.T) {
+	t.Parallel()
+
+	const path = "/repos/test-org/test-repo/rulesets/rule-suites"
+
+	var listRequests, ruleSuiteRequests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == path {
+			listRequests.Add(1)
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if page < 3 {
+				w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=%d>; rel="next"`, "http://"+r.Host, path, page+1))
+			}
+			_, _ = fmt.Fprintf(w, `[{"id": %d, "pushed_at": "2026-10-01T00:00:00Z", "evaluation_result": "pass"}, {"id": %d, "pushed_at": "2026-10-01T00:00:00Z", "evaluation_result": "pass"}]`, page*2-1, page*2)
+			return
+		}
+
+		if id, ok := strings.CutPrefix(r.URL.Path, path+"/"); ok {
+			ruleSuiteRequests.Add(1)
+			_, _ = fmt.Fprintf(w, `{"id": %s, "pushed_at": "2026-10-01T00:00:00Z", "evaluation_result": "pass", "rule_evaluations": [{"rule_source": {"type": "ruleset", "id": 42}, "result": "pass", "rule_type": "required_signatures"}]}`, id)
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(ts.Close)
+
+	client, err := github.NewClient(github.WithURLs(new(ts.URL+"/"), nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	meta := &Owner{name: "test-org", v3client: client, maxPerPage: 100}
+
+	r := dataSourceGithubRulesetRuleSuites()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]any{
+		"repository":      "test-repo",
+		"ruleset_id":      42,
+		"max_rule_suites": 3,
+	})
+
+	if diags := r.ReadContext(t.Context(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("total_count").(int); got != 3 {
+		t.Errorf("expected %d rule suites, got %d", 3, got)
+	}
+	if !d.Get("truncated").(bool) {
+		t.Error("expected the rule suites to be truncated")
+	}
+	if want := "test-org:test-repo::42:day:all::"; d.Id() != want {
+		t.Errorf("expected ID %q, got %q", want, d.Id())
+	}
+	if got := listRequests.Load(); got != 2 {
+		t.Errorf("expected %d list requests, got %d", 2, got)
+	}
+	if got := ruleSuiteRequests.Load(); got != 3 {
+		t.Errorf("expected %d rule suite requests, got %d", 3, got)
+	}
+}
+
+func Test_summarizeRuleSuites(t *testing.T) {
+	t.Parallel()
+
+	evaluation := func(rulesetID int64, ruleType, result string) ruleSuiteEvaluation {
+		e := ruleSuiteEvaluation{RuleType: ruleType, Result: result}
+		e.RuleSource.Type = "ruleset"
+		e.RuleSource.ID = rulesetID
+		return e
+	}
+
+	suites := []ruleSuite{
+		{ID: 1, EvaluationResult: "pass"},
+		{ID: 2, EvaluationResult: "fail", RuleEvaluations: []ruleSuiteEvaluation{
+			evaluation(10, "pull_request", "fail"),
+			evaluation(20, "required_signatures", "pass"),
+		}},
+		{ID: 3, EvaluationResult: "fail", RuleEvaluations: []ruleSuiteEvaluation{
+			evaluation(20, "required_signatures", "fail"),
+			evaluation(20, "non_fast_forward", "fail"),
+		}},
+		{ID: 4, EvaluationResult: "bypass"},
+	}
+
+	for _, tt := range []struct {
+		name             string
+		rulesetID        int64
+		wantPass         int
+		wantFail         int
+		wantBypass       int
+		wantRuleTypes    []string
+		wantRuleSuiteIDs []int64
+	}{
+		{
+			name:             "all_rulesets",
+			wantPass:         1,
+			wantFail:         2,
+			wantBypass:       1,
+			wantRuleTypes:    []string{"non_fast_forward", "pull_request", "required_signatures"},
+			wantRuleSuiteIDs: []int64{1, 2, 3, 4},
+		},
+		{
+			name:             "single_ruleset",
+			rulesetID:        20,
+			wantPass:         1,
+			wantFail:         1,
+			wantRuleTypes:    []string{"non_fast_forward", "required_signatures"},
+			wantRuleSuiteIDs: []int64{2, 3},
+		},
+		{
+			name:             "unevaluated_ruleset",
+			rulesetID:        30,
+			wantRuleTypes:    []string{},
+			wantRuleSuiteIDs: nil,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := summarizeRuleSuites(suites, tt.rulesetID)
+
+			if got.passCount != tt.wantPass || got.failCount != tt.wantFail || got.bypassCount != tt.wantBypass {
+				t.Errorf("expected %d passed, %d failed and %d bypassed, got %d, %d and %d", tt.wantPass, tt.wantFail, tt.wantBypass, got.passCount, got.failCount, got.bypassCount)
+			}
+
+			if !slices.Equal(got.failingRuleTypes, tt.wantRuleTypes) {
+				t.Errorf("expected failing rule types %v, got %v", tt.wantRuleTypes, got.failingRuleTypes)
+			}
+
+			var ids []int64
+			for _, suite := range got.matchingRuleSuites {
+				ids = append(ids, suite.ID)
+			}
+			if !slices.Equal(ids, tt.wantRuleSuiteIDs) {
+				t.Errorf("expected rule suites %v, got %v", tt.wantRuleSuiteIDs, ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package github
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ephemeralResourceGithubActionsOrganizationRegistrationToken() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubActionsOrganizationRegistrationTokenRead,
+
+		Description: "Ephemeral resource to create a token to register a self-hosted GitHub Actions runner with an organization, which is never stored in the plan or the state.",
+
+		Schema: map[string]*schema.Schema{
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The runner registration token.",
+			},
+			"expires_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The time the token expires at, as a Unix timestamp.",
+			},
+		},
+	}
+}
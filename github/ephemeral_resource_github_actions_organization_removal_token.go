@@ -0,0 +1,54 @@
+package github
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ephemeralResourceGithubActionsOrganizationRemovalToken() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ephemeralResourceGithubActionsOrganizationRemovalTokenRead,
+
+		Description: "Ephemeral resource to create a token to remove a self-hosted GitHub Actions runner from an organization, which is never stored in the plan or the state.",
+
+		Schema: map[string]*schema.Schema{
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The runner removal token.",
+			},
+			"expires_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The time the token expires at, as a Unix timestamp.",
+			},
+		},
+	}
+}
+
+func ephemeralResourceGithubActionsOrganizationRemovalTokenRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+
+	tflog.Debug(ctx, "Creating a GitHub Actions organization removal token", map[string]any{"owner": owner})
+
+	token, _, err := client.Actions.CreateOrganizationRemoveToken(ctx, owner)
+	if err != nil {
+		return diag.Errorf("error creating a GitHub Actions organization removal token for %s: %v", owner, err)
+	}
+
+	d.SetId(owner)
+	if err := d.Set("token", token.GetToken()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("expires_at", token.GetExpiresAt().Unix()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccGithubActionsOrganizationRemovalTokenEphemeralResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates_organization_removal_token", func(t *testing.T) {
+		t.Parallel()
+
+		config := `
+ephemeral "github_actions_organization_removal_token" "test" {}
+
+provider "echo" {
+  data = ephemeral.github_actions_organization_removal_token.test
+}
+
+resource "echo" "test" {}
+`
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:                 func() { skipUnlessHasOrgs(t) },
+			TerraformVersionChecks:   []tfversion.TerraformVersionCheck{tfversion.SkipBelow(tfversion.Version1_10_0)},
+			ProtoV5ProviderFactories: protoV5ProviderFactories,
+			ProtoV6ProviderFactories: echoProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("token"), knownvalue.NotNull()),
+						statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("expires_at"), knownvalue.NotNull()),
+					},
+				},
+			},
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package github
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ephemeralResourceGithubActionsRegistrationToken() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubActionsRegistrationTokenRead,
+
+		Description: "Ephemeral resource to create a token to register a self-hosted GitHub Actions runner with a repository, which is never stored in the plan or the state.",
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository to register the runner with.",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The runner registration token.",
+			},
+			"expires_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The time the token expires at, as a Unix timestamp.",
+			},
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ephemeralResourceGithubActionsRemovalToken() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ephemeralResourceGithubActionsRemovalTokenRead,
+
+		Description: "Ephemeral resource to create a token to remove a self-hosted GitHub Actions runner from a repository, which is never stored in the plan or the state.",
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository to remove the runner from.",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The runner removal token.",
+			},
+			"expires_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The time the token expires at, as a Unix timestamp.",
+			},
+		},
+	}
+}
+
+func ephemeralResourceGithubActionsRemovalTokenRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+	repoName := d.Get("repository").(string)
+
+	tflog.Debug(ctx, "Creating a GitHub Actions repository removal token", map[string]any{"owner": owner, "repository": repoName})
+
+	token, _, err := client.Actions.CreateRemoveToken(ctx, owner, repoName)
+	if err != nil {
+		return diag.Errorf("error creating a GitHub Actions repository removal token for %s/%s: %v", owner, repoName, err)
+	}
+
+	id, err := buildID(owner, repoName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	if err := d.Set("token", token.GetToken()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("expires_at", token.GetExpiresAt().Unix()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccGithubActionsRemovalTokenEphemeralResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates_repository_removal_token", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+
+		config := fmt.Sprintf(`
+ephemeral "github_actions_removal_token" "test" {
+  repository = "%s"
+}
+
+provider "echo" {
+  data = ephemeral.github_actions_removal_token.test
+}
+
+resource "echo" "test" {}
+`, repo.GetName())
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:                 func() { skipUnauthenticated(t) },
+			TerraformVersionChecks:   []tfversion.TerraformVersionCheck{tfversion.SkipBelow(tfversion.Version1_10_0)},
+			ProtoV5ProviderFactories: protoV5ProviderFactories,
+			ProtoV6ProviderFactories: echoProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("token"), knownvalue.NotNull()),
+						statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("expires_at"), knownvalue.NotNull()),
+					},
+				},
+			},
+		})
+	})
+}
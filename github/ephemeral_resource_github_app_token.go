@@ -0,0 +1,15 @@
+package github
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ephemeralResourceGithubAppToken() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubAppTokenRead,
+
+		Description: "Ephemeral resource to generate a GitHub App installation token, which is never stored in the plan or the state.",
+
+		Schema: dataSourceGithubAppToken().Schema,
+	}
+}
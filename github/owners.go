@@ -0,0 +1,359 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
+)
+
+// ownerArgumentName is the name of the argument added to org-scoped resources and data sources to select the owner to manage.
+const ownerArgumentName = "owner"
+
+// ownerImportSeparator separates an owner configured in the provider `owners` block from the resource ID when importing.
+const ownerImportSeparator = "@"
+
+// ownerArgumentExclusions lists the resources and data sources which aren't scoped to an organization or user account and therefore don't support the `owner` argument.
+var ownerArgumentExclusions = map[string]bool{
+	"github_app":                                     true,
+	"github_app_token":                               true,
+	"github_codespaces_user_public_key":              true,
+	"github_codespaces_user_secret":                  true,
+	"github_codespaces_user_secrets":                 true,
+	"github_enterprise":                              true,
+	"github_enterprise_actions_permissions":          true,
+	"github_enterprise_actions_runner_group":         true,
+	"github_enterprise_actions_workflow_permissions": true,
+	"github_enterprise_ip_allow_list_entry":          true,
+	"github_enterprise_organization":                 true,
+	"github_enterprise_ruleset":                      true,
+	"github_enterprise_security_analysis_settings":   true,
+	"github_ip_ranges":                               true,
+	"github_ssh_keys":                                true,
+	"github_user":                                    true,
+	"github_user_gpg_key":                            true,
+	"github_user_invitation_accepter":                true,
+	"github_user_ssh_key":                            true,
+	"github_users":                                   true,
+}
+
+// OwnerConfig is the configuration for an additional owner managed by the provider, as configured by the provider `owners` block.
+type OwnerConfig struct {
+	Name              string
+	Token             string
+	AppID             *string
+	AppInstallationID *string
+	AppPEM            []byte
+	AppPrivateKeyFile string
+	AppSignerCommand  []string
+	AppTokenScope     *ghclient.InstallationTokenScope
+}
+
+// hasAuth returns true if the owner has its own authentication configured, rather than using the provider authentication.
+func (c OwnerConfig) hasAuth() bool {
+	return c.Token != "" || c.AppID != nil
+}
+
+// ownerRegistry holds the lazily configured [Owner] for each additional owner managed by the provider.
+type ownerRegistry struct {
+	mu      sync.Mutex
+	entries map[string]*ownerEntry
+}
+
+// ownerEntry lazily configures an [Owner]. Only a configured owner is kept, so that configuring an owner which failed, e.g. due to a transient API error, is retried on its next use.
+type ownerEntry struct {
+	mu     sync.Mutex
+	config *OwnerConfig
+	owner  *Owner
+}
+
+// newOwnerRegistry creates a new ownerRegistry for the owners configured in the provider `owners` block.
+func newOwnerRegistry(owners []OwnerConfig) *ownerRegistry {
+	r := &ownerRegistry{entries: make(map[string]*ownerEntry, len(owners))}
+	for i := range owners {
+		r.entries[strings.ToLower(owners[i].Name)] = &ownerEntry{config: &owners[i]}
+	}
+	return r
+}
+
+// configured returns true if the owner was configured in the provider `owners` block.
+func (r *ownerRegistry) configured(name string) bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[strings.ToLower(name)]
+	return ok && e.config != nil
+}
+
+// entry returns the entry for the owner, creating one that uses the provider authentication if the owner wasn't configured in the provider `owners` block.
+func (r *ownerRegistry) entry(name string) *ownerEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := strings.ToLower(name)
+	e, ok := r.entries[key]
+	if !ok {
+		e = &ownerEntry{}
+		r.entries[key] = e
+	}
+	return e
+}
+
+// forOwner returns the [Owner] to use for resources owned by the given owner. The provider meta is returned for the configured owner or an empty owner; otherwise the owner is configured on first use, either with its own authentication from the provider `owners` block or by deriving the clients for the owner from the provider authentication.
+func (o *Owner) forOwner(ctx context.Context, name string) (*Owner, error) {
+	if name == "" || strings.EqualFold(name, o.name) {
+		return o, nil
+	}
+
+	// Configuring an owner is part of configuring the provider, so its requests are accounted to the provider rather than to the resource that first selects the owner.
+	ctx = ghclient.WithTelemetryScope(ctx, ghclient.TelemetryScopeProvider)
+
+	if o.owners == nil {
+		return o.deriveOwner(ctx, name)
+	}
+
+	e := o.owners.entry(name)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.owner != nil {
+		return e.owner, nil
+	}
+
+	var owner *Owner
+	var err error
+	if e.config != nil && e.config.hasAuth() {
+		owner, err = o.configureOwner(ctx, e.config)
+	} else {
+		owner, err = o.deriveOwner(ctx, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e.owner = owner
+	return owner, nil
+}
+
+// deriveOwner creates an [Owner] for the given owner using the provider authentication.
+func (o *Owner) deriveOwner(ctx context.Context, name string) (*Owner, error) {
+	tflog.Debug(ctx, "Configuring owner using the provider authentication.", map[string]any{"owner": name})
+
+	v3client, err := o.restClient(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	v4client, err := o.graphQLClient(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	v4httpClient, err := o.graphQLHTTPClient(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := &Owner{
+		name:           name,
+		v3client:       v3client,
+		v4client:       v4client,
+		v4httpClient:   v4httpClient,
+		source:         o.source,
+		StopContext:    o.StopContext,
+		maxPerPage:     o.maxPerPage,
+		publicKeys:     o.publicKeys,
+		userPublicKeys: newPublicKeyCache(),
+		graphQLURL:     o.graphQLURL,
+		scopedOwners:   &sync.Map{},
+	}
+
+	if err := owner.lookup(ctx); err != nil {
+		return nil, err
+	}
+
+	return owner, nil
+}
+
+// configureOwner creates an [Owner] for an owner with its own authentication configured in the provider `owners` block.
+func (o *Owner) configureOwner(ctx context.Context, oc *OwnerConfig) (*Owner, error) {
+	tflog.Debug(ctx, "Configuring owner using the owner authentication.", map[string]any{"owner": oc.Name, "app_id": oc.AppID})
+
+	c := *o.config
+	c.Owner = oc.Name
+	c.Owners = nil
+	c.Token = oc.Token
+	c.AppID = oc.AppID
+	c.AppInstallationID = oc.AppInstallationID
+	c.AppPEM = oc.AppPEM
+	c.AppPrivateKeyFile = oc.AppPrivateKeyFile
+	c.AppSignerCommand = oc.AppSignerCommand
+	c.AppTokenScope = oc.AppTokenScope
+	c.OIDC = nil
+
+	if c.LegacyClient && c.AppID != nil {
+		if err := c.exchangeAppToken(ctx); err != nil {
+			return nil, fmt.Errorf("failed to configure owner %q: %w", oc.Name, err)
+		}
+	}
+
+	owner, err := configureProviderMeta(ctx, o.version, &c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure owner %q: %w", oc.Name, err)
+	}
+	owner.StopContext = o.StopContext
+
+	return owner, nil
+}
+
+// ownerMeta returns the provider meta for the owner selected by the `owner` argument.
+func ownerMeta(ctx context.Context, owner any, m any) (any, error) {
+	meta, ok := m.(*Owner)
+	if !ok {
+		return m, nil
+	}
+
+	name, _ := owner.(string)
+	return meta.forOwner(ctx, name)
+}
+
+// addOwnerArguments adds the `owner` argument to the org-scoped resources and data sources of the provider.
+func addOwnerArguments(p *schema.Provider) {
+	for name, r := range p.ResourcesMap {
+		if ownerArgumentExclusions[name] {
+			continue
+		}
+		if _, ok := r.Schema[ownerArgumentName]; ok {
+			continue
+		}
+		withOwnerArgument(r, true)
+	}
+
+	for name, r := range p.DataSourcesMap {
+		if ownerArgumentExclusions[name] {
+			continue
+		}
+		if _, ok := r.Schema[ownerArgumentName]; ok {
+			continue
+		}
+		withOwnerArgument(r, false)
+	}
+}
+
+// withOwnerArgument adds the `owner` argument to the resource schema and wraps the resource functions so that they're called with the provider meta for the selected owner.
+func withOwnerArgument(r *schema.Resource, isResource bool) {
+	r.Schema[ownerArgumentName] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    isResource,
+		Description: "The GitHub organization or user account that owns the object; this defaults to the provider `owner`. Authentication for the owner can be configured in the provider `owners` block.",
+	}
+
+	r.CreateContext = wrapOwnerContextFunc(r.CreateContext)
+	r.ReadContext = wrapOwnerContextFunc(r.ReadContext)
+	r.UpdateContext = wrapOwnerContextFunc(r.UpdateContext)
+	r.DeleteContext = wrapOwnerContextFunc(r.DeleteContext)
+	r.Create = wrapOwnerFunc(r.Create)
+	r.Read = wrapOwnerFunc(r.Read)
+	r.Update = wrapOwnerFunc(r.Update)
+	r.Delete = wrapOwnerFunc(r.Delete)
+
+	if r.CustomizeDiff != nil {
+		fn := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+			meta, err := ownerMeta(ctx, d.Get(ownerArgumentName), m)
+			if err != nil {
+				return err
+			}
+			return fn(ctx, d, meta)
+		}
+	}
+
+	if r.Importer != nil {
+		r.Importer = wrapOwnerImporter(r.Importer)
+	}
+
+	for i, u := range r.StateUpgraders {
+		fn := u.Upgrade
+		r.StateUpgraders[i].Upgrade = func(ctx context.Context, rawState map[string]any, m any) (map[string]any, error) {
+			meta, err := ownerMeta(ctx, rawState[ownerArgumentName], m)
+			if err != nil {
+				return nil, err
+			}
+			return fn(ctx, rawState, meta)
+		}
+	}
+}
+
+// wrapOwnerContextFunc wraps a resource context function so that it's called with the provider meta for the selected owner.
+func wrapOwnerContextFunc[F ~func(context.Context, *schema.ResourceData, any) diag.Diagnostics](fn F) F {
+	if fn == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		meta, err := ownerMeta(ctx, d.Get(ownerArgumentName), m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return fn(ctx, d, meta)
+	}
+}
+
+// wrapOwnerFunc wraps a legacy resource function so that it's called with the provider meta for the selected owner.
+func wrapOwnerFunc[F ~func(*schema.ResourceData, any) error](fn F) F {
+	if fn == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, m any) error {
+		meta, err := ownerMeta(context.Background(), d.Get(ownerArgumentName), m)
+		if err != nil {
+			return err
+		}
+		return fn(d, meta)
+	}
+}
+
+// wrapOwnerImporter wraps a resource importer so that an import ID in the form `<owner>@<id>`, where the owner is configured in the provider `owners` block, imports the resource for that owner.
+func wrapOwnerImporter(importer *schema.ResourceImporter) *schema.ResourceImporter {
+	stateContext := importer.StateContext
+	if stateContext == nil && importer.State != nil {
+		state := importer.State
+		stateContext = func(_ context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+			return state(d, m)
+		}
+	}
+
+	if stateContext == nil {
+		return importer
+	}
+
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+			if meta, ok := m.(*Owner); ok {
+				if name, id, ok := strings.Cut(d.Id(), ownerImportSeparator); ok && meta.owners.configured(name) {
+					d.SetId(id)
+					if err := d.Set(ownerArgumentName, name); err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			meta, err := ownerMeta(ctx, d.Get(ownerArgumentName), m)
+			if err != nil {
+				return nil, err
+			}
+			return stateContext(ctx, d, meta)
+		},
+	}
+}
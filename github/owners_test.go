@@ -0,0 +1,448 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
+)
+
+func Test_getOwners(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name    string
+		raw     map[string]any
+		want    []OwnerConfig
+		wantErr string
+	}{
+		{
+			name: "no_owners",
+			raw:  map[string]any{},
+		},
+		{
+			name: "owners",
+			raw: map[string]any{
+				"owners": []any{
+					map[string]any{"name": "acme"},
+					map[string]any{"name": "octo-org", "token": "test-token"},
+					map[string]any{"name": "other-org", "app_auth": []any{map[string]any{"id": "111111", "installation_id": "999999", "pem_file": "pem"}}},
+				},
+			},
+			want: []OwnerConfig{
+				{Name: "acme"},
+				{Name: "octo-org", Token: "test-token"},
+				{Name: "other-org", AppID: new("111111"), AppInstallationID: new("999999"), AppPEM: []byte("pem")},
+			},
+		},
+		{
+			name: "owner_app_signer_and_token_scope",
+			raw: map[string]any{
+				"owners": []any{
+					map[string]any{"name": "acme", "app_auth": []any{map[string]any{"id": "111111", "installation_id": "999999", "signer_command": []any{"signer", "--key", "acme"}, "permissions": map[string]any{"contents": "read"}, "repositories": []any{"repo"}}}},
+					map[string]any{"name": "other-org", "app_auth": []any{map[string]any{"id": "111111", "installation_id": "888888", "private_key_file": "/keys/app.pem"}}},
+				},
+			},
+			want: []OwnerConfig{
+				{Name: "acme", AppID: new("111111"), AppInstallationID: new("999999"), AppSignerCommand: []string{"signer", "--key", "acme"}, AppTokenScope: &ghclient.InstallationTokenScope{Permissions: map[string]string{"contents": "read"}, Repositories: []string{"repo"}}},
+				{Name: "other-org", AppID: new("111111"), AppInstallationID: new("888888"), AppPrivateKeyFile: "/keys/app.pem"},
+			},
+		},
+		{
+			name: "errors_on_multiple_app_keys",
+			raw: map[string]any{
+				"owners": []any{
+					map[string]any{"name": "acme", "app_auth": []any{map[string]any{"id": "111111", "installation_id": "999999", "pem_file": "pem", "private_key_file": "/keys/app.pem"}}},
+				},
+			},
+			wantErr: "only one of pem_file, private_key_file or signer_command can be set",
+		},
+		{
+			name: "errors_on_missing_app_key",
+			raw: map[string]any{
+				"owners": []any{
+					map[string]any{"name": "acme", "app_auth": []any{map[string]any{"id": "111111", "installation_id": "999999"}}},
+				},
+			},
+			wantErr: "required fields are missing",
+		},
+		{
+			name: "errors_on_duplicate_owner",
+			raw: map[string]any{
+				"owners": []any{
+					map[string]any{"name": "acme"},
+					map[string]any{"name": "ACME"},
+				},
+			},
+			wantErr: "configured more than once",
+		},
+		{
+			name: "errors_on_token_and_app_auth",
+			raw: map[string]any{
+				"owners": []any{
+					map[string]any{"name": "acme", "token": "test-token", "app_auth": []any{map[string]any{"id": "111111", "installation_id": "999999", "pem_file": "pem"}}},
+				},
+			},
+			wantErr: "only one of token or app_auth can be set",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, NewProvider("test", "none")().Schema, tt.raw)
+
+			got, err := getOwners(d)
+			if err != nil {
+				if tt.wantErr == "" {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if !regexp.MustCompile(regexp.QuoteMeta(tt.wantErr)).MatchString(err.Error()) {
+					t.Fatalf("expected error to match %q, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if tt.wantErr != "" {
+				t.Fatalf("expected error %q, got nil", tt.wantErr)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d owners, got %d", len(tt.want), len(got))
+			}
+
+			for i, want := range tt.want {
+				if got[i].Name != want.Name || got[i].Token != want.Token || got[i].AppID == nil != (want.AppID == nil) || string(got[i].AppPEM) != string(want.AppPEM) ||
+					got[i].AppPrivateKeyFile != want.AppPrivateKeyFile || !slices.Equal(got[i].AppSignerCommand, want.AppSignerCommand) || !reflect.DeepEqual(got[i].AppTokenScope, want.AppTokenScope) {
+					t.Errorf("expected owner %d to be %+v, got %+v", i, want, got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOwner_forOwner(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/test-org":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 123456, "type": "Organization"}`))
+		case "/users/other-org":
+			if r.Header.Get("Authorization") != "Bearer other-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 654321, "type": "Organization"}`))
+		case "/users/other-user":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 111111, "type": "User"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	meta, err := configureProviderMeta(t.Context(), "test", &Config{
+		BaseURL: mustNewURL(t, ts.URL),
+		Owner:   "test-org",
+		Token:   "test-token",
+		Owners: []OwnerConfig{
+			{Name: "other-org", Token: "other-token"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name      string
+		owner     string
+		wantName  string
+		wantIsOrg bool
+		wantId    int64
+		wantSame  bool
+		wantErr   bool
+	}{
+		{
+			name:      "default_owner",
+			owner:     "",
+			wantName:  "test-org",
+			wantIsOrg: true,
+			wantId:    123456,
+			wantSame:  true,
+		},
+		{
+			name:      "provider_owner",
+			owner:     "TEST-ORG",
+			wantName:  "test-org",
+			wantIsOrg: true,
+			wantId:    123456,
+			wantSame:  true,
+		},
+		{
+			name:      "configured_owner",
+			owner:     "other-org",
+			wantName:  "other-org",
+			wantIsOrg: true,
+			wantId:    654321,
+		},
+		{
+			name:     "derived_owner",
+			owner:    "other-user",
+			wantName: "other-user",
+		},
+		{
+			name:    "errors_on_non_existent_owner",
+			owner:   "missing",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := meta.forOwner(t.Context(), tt.owner)
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if tt.wantErr {
+				t.Fatal("expected error, got nil")
+			}
+
+			if (got == meta) != tt.wantSame {
+				t.Errorf("expected provider meta to be returned to be %v", tt.wantSame)
+			}
+
+			if got.name != tt.wantName {
+				t.Errorf("expected owner name to be %q, got %q", tt.wantName, got.name)
+			}
+
+			if got.IsOrganization != tt.wantIsOrg {
+				t.Errorf("expected IsOrganization to be %v, got %v", tt.wantIsOrg, got.IsOrganization)
+			}
+
+			if got.id != tt.wantId {
+				t.Errorf("expected owner id to be %d, got %d", tt.wantId, got.id)
+			}
+
+			again, err := meta.forOwner(t.Context(), tt.owner)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if again != got {
+				t.Error("expected owner to be cached and reused")
+			}
+		})
+	}
+}
+
+func TestOwner_forOwner_withoutOwners(t *testing.T) {
+	t.Parallel()
+
+	var lookups atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/test-org":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 123456, "type": "Organization"}`))
+		case "/users/other-org":
+			lookups.Add(1)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 654321, "type": "Organization"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	meta, err := configureProviderMeta(t.Context(), "test", &Config{
+		BaseURL: mustNewURL(t, ts.URL),
+		Owner:   "test-org",
+		Token:   "test-token",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := meta.forOwner(t.Context(), "other-org")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	again, err := meta.forOwner(t.Context(), "other-org")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if again != got {
+		t.Error("expected derived owner to be cached and reused")
+	}
+
+	if n := lookups.Load(); n != 1 {
+		t.Errorf("expected the owner to be looked up once, got %d requests", n)
+	}
+
+	if got.publicKeys != meta.publicKeys {
+		t.Error("expected derived owner to share the secrets public keys")
+	}
+
+	if got.userPublicKeys == meta.userPublicKeys {
+		t.Error("expected derived owner to have its own user secrets public keys")
+	}
+}
+
+func TestOwner_forOwner_retriesFailures(t *testing.T) {
+	t.Parallel()
+
+	var lookups atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/test-org":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 123456, "type": "Organization"}`))
+		case "/users/other-org":
+			if lookups.Add(1) == 1 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 654321, "type": "Organization"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	meta, err := configureProviderMeta(t.Context(), "test", &Config{
+		BaseURL: mustNewURL(t, ts.URL),
+		Owner:   "test-org",
+		Token:   "test-token",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := meta.forOwner(t.Context(), "other-org"); err == nil {
+		t.Fatal("expected the first lookup of the owner to fail")
+	}
+
+	got, err := meta.forOwner(t.Context(), "other-org")
+	if err != nil {
+		t.Fatalf("expected configuring the owner to be retried, got %v", err)
+	}
+
+	if got.name != "other-org" {
+		t.Errorf("expected owner %q, got %q", "other-org", got.name)
+	}
+
+	if n := lookups.Load(); n != 2 {
+		t.Errorf("expected the owner to be looked up twice, got %d requests", n)
+	}
+}
+
+func Test_withOwnerArgument(t *testing.T) {
+	t.Parallel()
+
+	meta := &Owner{name: "test-org"}
+	other := &Owner{name: "other-org"}
+	meta.owners = newOwnerRegistry([]OwnerConfig{{Name: "other-org"}})
+	e := meta.owners.entry("other-org")
+	e.owner = other
+
+	var gotMeta any
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true, ForceNew: true},
+		},
+		ReadContext: func(_ context.Context, _ *schema.ResourceData, m any) diag.Diagnostics {
+			gotMeta = m
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(_ context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+				gotMeta = m
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+	}
+	withOwnerArgument(r, true)
+
+	if s, ok := r.Schema[ownerArgumentName]; !ok || !s.Optional || !s.ForceNew {
+		t.Fatal("expected optional force new owner argument to be added")
+	}
+
+	t.Run("read_default_owner", func(t *testing.T) {
+		d := r.TestResourceData()
+		if diags := r.ReadContext(t.Context(), d, meta); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+
+		if gotMeta != meta {
+			t.Error("expected provider meta for default owner")
+		}
+	})
+
+	t.Run("read_other_owner", func(t *testing.T) {
+		d := r.TestResourceData()
+		_ = d.Set(ownerArgumentName, "other-org")
+		if diags := r.ReadContext(t.Context(), d, meta); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+
+		if gotMeta != other {
+			t.Error("expected meta for other owner")
+		}
+	})
+
+	t.Run("import_other_owner", func(t *testing.T) {
+		d := r.TestResourceData()
+		d.SetId("other-org@my-repo")
+		if _, err := r.Importer.StateContext(t.Context(), d, meta); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if gotMeta != other {
+			t.Error("expected meta for other owner")
+		}
+
+		if d.Id() != "my-repo" {
+			t.Errorf("expected id to be %q, got %q", "my-repo", d.Id())
+		}
+
+		if d.Get(ownerArgumentName) != "other-org" {
+			t.Errorf("expected owner to be %q, got %q", "other-org", d.Get(ownerArgumentName))
+		}
+	})
+
+	t.Run("import_unconfigured_owner", func(t *testing.T) {
+		d := r.TestResourceData()
+		d.SetId("my-repo:feature@1")
+		if _, err := r.Importer.StateContext(t.Context(), d, meta); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if gotMeta != meta {
+			t.Error("expected provider meta for default owner")
+		}
+
+		if d.Id() != "my-repo:feature@1" {
+			t.Errorf("expected id to be unchanged, got %q", d.Id())
+		}
+	})
+}
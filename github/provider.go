@@ -8,8 +8,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -31,8 +33,13 @@ func init() {
 
 // NewProvider returns a function that returns the schema.Provider for this provider.
 func NewProvider(version, commit string) func() *schema.Provider {
+	return newProvider(version, commit, nil)
+}
+
+// newProvider returns a function that returns the schema.Provider for this provider, recording or replaying the interactions with the GitHub API with the recorder if it's not nil; only acceptance tests set a recorder.
+func newProvider(version, commit string, recorder *ghclient.Recorder) func() *schema.Provider {
 	return func() *schema.Provider {
-		return &schema.Provider{
+		p := &schema.Provider{
 			Schema: map[string]*schema.Schema{
 				"base_url": {
 					Type:        schema.TypeString,
@@ -57,8 +64,8 @@ func NewProvider(version, commit string) func() *schema.Provider {
 					Type:             schema.TypeString,
 					Optional:         true,
 					DefaultFunc:      schema.EnvDefaultFunc("GITHUB_AUTH_MODE", "auto"),
-					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"auto", "app", "token", "none"}, false)),
-					Description:      "The authentication mode to use; this can be one of `auto`, `app`, `token` or `none` and defaults to `auto` which will detect the highest priority authentication mode available (`app` -> `token` -> `none`). This can also be set by the `GITHUB_AUTH_MODE` environment variable.",
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"auto", "app", "oidc", "token", "none"}, false)),
+					Description:      "The authentication mode to use; this can be one of `auto`, `app`, `oidc`, `token` or `none` and defaults to `auto` which will detect the highest priority authentication mode available (`app` -> `oidc` -> `token` -> `none`), except that an explicit `token` takes precedence over OIDC configured only by environment variables. This can also be set by the `GITHUB_AUTH_MODE` environment variable.",
 				},
 				"token_env_name": {
 					Type:        schema.TypeString,
@@ -100,6 +107,136 @@ func NewProvider(version, commit string) func() *schema.Provider {
 								Sensitive:   true,
 								Description: "The GitHub App's PEM file content; `\\n` can be used for newlines. This can also be set by the `GITHUB_APP_PEM_FILE` environment variable when `app_auth_env_prefix` is `GITHUB_APP_` (modify the prefix as needed).",
 							},
+							"private_key_file": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The path to the GitHub App's PEM encoded private key file; this can be used instead of `pem_file`. This can also be set by the `GITHUB_APP_PRIVATE_KEY_FILE` environment variable when `app_auth_env_prefix` is `GITHUB_APP_` (modify the prefix as needed).",
+							},
+							"signer_command": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+								Description: "The external command (and arguments) used to sign the GitHub App's JWTs so that the private key never needs to be available to the provider, e.g. when it's held in a KMS or HSM; this can be used instead of `pem_file`. This can also be set by the `GITHUB_APP_SIGNER_COMMAND` environment variable (space separated) when `app_auth_env_prefix` is `GITHUB_APP_` (modify the prefix as needed).",
+							},
+							"permissions": {
+								Type:             schema.TypeMap,
+								Optional:         true,
+								Elem:             &schema.Schema{Type: schema.TypeString},
+								ValidateDiagFunc: validation.MapValueMatch(regexp.MustCompile(`^(read|write|admin)$`), "must be one of read, write or admin"),
+								Description:      "The permissions to request for the installation token, mapping permission names (e.g. `contents`) to `read`, `write` or `admin`; if not set the token has all of the installation's permissions.",
+							},
+							"repositories": {
+								Type:        schema.TypeSet,
+								Optional:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+								Description: "The names of the repositories, owned by the `owner`, to restrict the installation token to; if not set the token can access all of the installation's repositories.",
+							},
+						},
+					},
+				},
+				"oidc_auth": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "Authenticate by exchanging a signed OIDC JWT (e.g. a CI workload identity token) for a GitHub token with a token broker implementing the RFC 8693 token exchange.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"token_url": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The token broker endpoint to exchange the JWT with. This can also be set by the `GITHUB_OIDC_TOKEN_URL` environment variable.",
+							},
+							"id_token_file": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The path to a file containing the JWT; the file is read for every exchange so rotated tokens are picked up. This can also be set by the `GITHUB_OIDC_ID_TOKEN_FILE` environment variable.",
+							},
+							"id_token_env_name": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The environment variable name containing the JWT, used when `id_token_file` is not set. This defaults to `GITHUB_OIDC_ID_TOKEN`.",
+							},
+							"audience": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The audience to request from the token broker. This can also be set by the `GITHUB_OIDC_AUDIENCE` environment variable.",
+							},
+							"scope": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The scope to request from the token broker. This can also be set by the `GITHUB_OIDC_SCOPE` environment variable.",
+							},
+						},
+					},
+				},
+				"owners": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Additional GitHub organizations or user accounts to manage; org-scoped resources and data sources select one of these with their `owner` argument. Each owner uses the provider authentication unless its own authentication is configured.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+								Description:      "The GitHub organization or user account name.",
+							},
+							"token": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Sensitive:   true,
+								Description: "GitHub OAuth or Personal Access Token (PAT) to use for authentication for this owner.",
+							},
+							"app_auth": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								MaxItems:    1,
+								Description: "Authenticate using a GitHub App for this owner.",
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"id": {
+											Type:        schema.TypeString,
+											Required:    true,
+											Description: "The GitHub App's identifier.",
+										},
+										"installation_id": {
+											Type:        schema.TypeString,
+											Required:    true,
+											Description: "The GitHub App's installation identifier for this owner.",
+										},
+										"pem_file": {
+											Type:        schema.TypeString,
+											Optional:    true,
+											Sensitive:   true,
+											Description: "The GitHub App's PEM file content; `\\n` can be used for newlines.",
+										},
+										"private_key_file": {
+											Type:        schema.TypeString,
+											Optional:    true,
+											Description: "The path to the GitHub App's PEM encoded private key file; this can be used instead of `pem_file`.",
+										},
+										"signer_command": {
+											Type:        schema.TypeList,
+											Optional:    true,
+											Elem:        &schema.Schema{Type: schema.TypeString},
+											Description: "The external command (and arguments) used to sign the GitHub App's JWTs so that the private key never needs to be available to the provider; this can be used instead of `pem_file`.",
+										},
+										"permissions": {
+											Type:             schema.TypeMap,
+											Optional:         true,
+											Elem:             &schema.Schema{Type: schema.TypeString},
+											ValidateDiagFunc: validation.MapValueMatch(regexp.MustCompile(`^(read|write|admin)$`), "must be one of read, write or admin"),
+											Description:      "The permissions to request for the installation token, mapping permission names (e.g. `contents`) to `read`, `write` or `admin`; if not set the token has all of the installation's permissions.",
+										},
+										"repositories": {
+											Type:        schema.TypeSet,
+											Optional:    true,
+											Elem:        &schema.Schema{Type: schema.TypeString},
+											Description: "The names of the repositories, owned by this owner, to restrict the installation token to; if not set the token can access all of the installation's repositories.",
+										},
+									},
+								},
+							},
 						},
 					},
 				},
@@ -169,6 +306,49 @@ func NewProvider(version, commit string) func() *schema.Provider {
 					DefaultFunc: schema.EnvDefaultFunc("GITHUB_CACHE_PATH", ""),
 					Description: "The path to the cache directory for persisting GitHub API requests between runs; if not set there will be no caching between runs. This can also be set by the `GITHUB_CACHE_PATH` environment variable.",
 				},
+				"app_token_cache": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("GITHUB_APP_TOKEN_CACHE", false),
+					Description: "Persist GitHub App installation tokens in an encrypted cache under `cache_path` so that they're reused between runs and by parallel Terraform processes until shortly before they expire; this requires `cache_path` to be set. This can also be set by the `GITHUB_APP_TOKEN_CACHE` environment variable.",
+				},
+				"rate_limit_budget": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "Guard the GitHub API rate limits so that the provider fails fast with an error naming the rate limit category and reset time instead of waiting for a rate limit to reset for longer than `max_wait_seconds`.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"min_remaining": {
+								Type:             schema.TypeInt,
+								Optional:         true,
+								Default:          0,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+								Description:      "The minimum number of requests to keep remaining in each rate limit category; once fewer remain, requests in the category wait for the rate limit to reset if it's within `max_wait_seconds`, otherwise they fail. This defaults to `0`.",
+							},
+							"max_wait_seconds": {
+								Type:             schema.TypeInt,
+								Optional:         true,
+								Default:          300,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+								Description:      "The maximum time in seconds to wait for a primary or secondary rate limit to reset; requests that would wait longer fail instead. This defaults to `300`.",
+							},
+							"warn_remaining": {
+								Type:             schema.TypeInt,
+								Optional:         true,
+								Default:          0,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+								Description:      "Log a warning once per rate limit category and window when this many requests or fewer remain; `0` disables the warning. This defaults to `0`.",
+							},
+						},
+					},
+				},
+				"telemetry_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("GITHUB_TELEMETRY_FILE", ""),
+					Description: "The path to a JSON Lines file that a summary of the GitHub API requests made by the provider, per resource type and data source, is appended to when the provider shuts down; the summary is always logged at the `INFO` level. This can also be set by the `GITHUB_TELEMETRY_FILE` environment variable.",
+				},
 			},
 
 			ResourcesMap: map[string]*schema.Resource{
@@ -187,6 +367,7 @@ func NewProvider(version, commit string) func() *schema.Provider {
 				"github_actions_repository_oidc_subject_claim_customization_template":   resourceGithubActionsRepositoryOIDCSubjectClaimCustomizationTemplate(),
 				"github_actions_repository_permissions":                                 resourceGithubActionsRepositoryPermissions(),
 				"github_actions_runner_group":                                           resourceGithubActionsRunnerGroup(),
+				"github_actions_runner_jit_config":                                      resourceGithubActionsRunnerJITConfig(),
 				"github_actions_hosted_runner":                                          resourceGithubActionsHostedRunner(),
 				"github_actions_secret":                                                 resourceGithubActionsSecret(),
 				"github_actions_variable":                                               resourceGithubActionsVariable(),
@@ -236,6 +417,7 @@ func NewProvider(version, commit string) func() *schema.Provider {
 				"github_repository_environment":                                         resourceGithubRepositoryEnvironment(),
 				"github_repository_environment_deployment_policy":                       resourceGithubRepositoryEnvironmentDeploymentPolicy(),
 				"github_repository_file":                                                resourceGithubRepositoryFile(),
+				"github_repository_files":                                               resourceGithubRepositoryFiles(),
 				"github_repository_milestone":                                           resourceGithubRepositoryMilestone(),
 				"github_repository_pages":                                               resourceGithubRepositoryPages(),
 				"github_repository_project":                                             resourceGithubRepositoryProject(),
@@ -244,6 +426,7 @@ func NewProvider(version, commit string) func() *schema.Provider {
 				"github_repository_topics":                                              resourceGithubRepositoryTopics(),
 				"github_repository_webhook":                                             resourceGithubRepositoryWebhook(),
 				"github_repository_vulnerability_alerts":                                resourceGithubRepositoryVulnerabilityAlerts(),
+				"github_secret_set":                                                     resourceGithubSecretSet(),
 				"github_team":                                                           resourceGithubTeam(),
 				"github_team_members":                                                   resourceGithubTeamMembers(),
 				"github_team_membership":                                                resourceGithubTeamMembership(),
@@ -255,6 +438,7 @@ func NewProvider(version, commit string) func() *schema.Provider {
 				"github_user_ssh_key":                                                   resourceGithubUserSshKey(),
 				"github_enterprise_organization":                                        resourceGithubEnterpriseOrganization(),
 				"github_enterprise_actions_runner_group":                                resourceGithubActionsEnterpriseRunnerGroup(),
+				"github_enterprise_ruleset":                                             resourceGithubEnterpriseRuleset(),
 				"github_enterprise_ip_allow_list_entry":                                 resourceGithubEnterpriseIpAllowListEntry(),
 				"github_enterprise_actions_workflow_permissions":                        resourceGithubEnterpriseActionsWorkflowPermissions(),
 				"github_actions_organization_workflow_permissions":                      resourceGithubActionsOrganizationWorkflowPermissions(),
@@ -279,7 +463,9 @@ func NewProvider(version, commit string) func() *schema.Provider {
 				"github_app":                                                            dataSourceGithubApp(),
 				"github_app_token":                                                      dataSourceGithubAppToken(),
 				"github_branch":                                                         dataSourceGithubBranch(),
+				"github_branch_effective_rules":                                         dataSourceGithubBranchEffectiveRules(),
 				"github_branch_protection_rules":                                        dataSourceGithubBranchProtectionRules(),
+				"github_branch_protection_ruleset":                                      dataSourceGithubBranchProtectionRuleset(),
 				"github_collaborators":                                                  dataSourceGithubCollaborators(),
 				"github_codespaces_organization_public_key":                             dataSourceGithubCodespacesOrganizationPublicKey(),
 				"github_codespaces_organization_secrets":                                dataSourceGithubCodespacesOrganizationSecrets(),
@@ -320,6 +506,7 @@ func NewProvider(version, commit string) func() *schema.Provider {
 				"github_repository":                                                     dataSourceGithubRepository(),
 				"github_repository_autolink_references":                                 dataSourceGithubRepositoryAutolinkReferences(),
 				"github_repository_branches":                                            dataSourceGithubRepositoryBranches(),
+				"github_repository_codeowners":                                          dataSourceGithubRepositoryCodeowners(),
 				"github_repository_custom_properties":                                   dataSourceGithubRepositoryCustomProperties(),
 				"github_repository_environments":                                        dataSourceGithubRepositoryEnvironments(),
 				"github_repository_deploy_keys":                                         dataSourceGithubRepositoryDeployKeys(),
@@ -332,6 +519,7 @@ func NewProvider(version, commit string) func() *schema.Provider {
 				"github_repository_teams":                                               dataSourceGithubRepositoryTeams(),
 				"github_repository_webhooks":                                            dataSourceGithubRepositoryWebhooks(),
 				"github_rest_api":                                                       dataSourceGithubRestApi(),
+				"github_ruleset_rule_suites":                                            dataSourceGithubRulesetRuleSuites(),
 				"github_ssh_keys":                                                       dataSourceGithubSshKeys(),
 				"github_team":                                                           dataSourceGithubTeam(),
 				"github_team_members":                                                   dataSourceGithubTeamMembers(),
@@ -344,14 +532,20 @@ func NewProvider(version, commit string) func() *schema.Provider {
 				"github_repository_environment_deployment_policies":                     dataSourceGithubRepositoryEnvironmentDeploymentPolicies(),
 			},
 
-			ConfigureContextFunc: configureProvider(version, commit),
+			ConfigureContextFunc: configureProvider(version, commit, recorder),
 		}
+
+		addTelemetryScopes(p)
+		addOwnerArguments(p)
+
+		return p
 	}
 }
 
 // configureProvider initializes the provider meta parameter with the necessary clients and owner information based on the provided configuration. It returns the initialized meta parameter or an error if the configuration is invalid or if there are issues initializing the clients.
-func configureProvider(version, commit string) func(context.Context, *schema.ResourceData) (any, diag.Diagnostics) {
+func configureProvider(version, commit string, recorder *ghclient.Recorder) func(context.Context, *schema.ResourceData) (any, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
+		ctx = ghclient.WithTelemetryScope(ctx, ghclient.TelemetryScopeProvider)
 		tflog.Debug(ctx, "Configuring provider.", map[string]any{"name": providerName, "url": providerURL, "version": version, "commit": commit})
 
 		baseURL, err := url.Parse(DotComAPIURL)
@@ -414,11 +608,19 @@ func configureProvider(version, commit string) func(context.Context, *schema.Res
 		}
 
 		if authMode == "app" || authMode == "auto" {
-			if appID, appInstallationID, appPEM, ok := getAppAuth(d, appAuthEnvPrefix); ok {
-				tflog.Debug(ctx, "Using GitHub App authentication.", map[string]any{"app_id": appID, "app_installation_id": appInstallationID})
+			appPrivateKeyFile, appSignerCommand := getAppSigner(d, appAuthEnvPrefix)
+			if appID, appInstallationID, appPEM, ok := getAppAuth(d, appAuthEnvPrefix, appPrivateKeyFile != "" || len(appSignerCommand) > 0); ok {
+				tflog.Debug(ctx, "Using GitHub App authentication.", map[string]any{"app_id": appID, "app_installation_id": appInstallationID, "app_private_key_file": appPrivateKeyFile, "app_signer_command": appSignerCommand})
 				config.AppID = appID
 				config.AppInstallationID = appInstallationID
 				config.AppPEM = appPEM
+				config.AppPrivateKeyFile = appPrivateKeyFile
+				config.AppSignerCommand = appSignerCommand
+				config.AppTokenScope = getAppTokenScope(d)
+
+				if countNonEmpty(len(appPEM) > 0, appPrivateKeyFile != "", len(appSignerCommand) > 0) > 1 {
+					return nil, diag.Errorf("only one of pem_file, private_key_file or signer_command can be set for github app authentication")
+				}
 			}
 
 			if config.AppID != nil && config.Owner == "" {
@@ -435,7 +637,24 @@ func configureProvider(version, commit string) func(context.Context, *schema.Res
 				return nil, diag.Errorf("app_auth block is set but required fields are missing or contains empty values")
 			}
 
-			if authMode != "none" {
+			if useOIDCAuth(d, authMode) {
+				if oidc, ok := getOIDCAuth(d); ok {
+					tflog.Debug(ctx, "Using OIDC token exchange authentication.", map[string]any{"token_url": oidc.TokenURL, "id_token_file": oidc.IDTokenFile})
+					config.OIDC = oidc
+				}
+			}
+
+			if config.OIDC == nil {
+				if authMode == "oidc" {
+					return nil, diag.Errorf("auth_mode is set to oidc but required fields for oidc authentication are missing or contain empty values")
+				}
+
+				if _, ok := d.GetOk("oidc_auth"); ok {
+					return nil, diag.Errorf("oidc_auth block is set but required fields are missing or contains empty values")
+				}
+			}
+
+			if authMode != "none" && config.OIDC == nil {
 				if v, ok := d.GetOk("token"); ok {
 					if s, ok := v.(string); ok && s != "" {
 						tflog.Debug(ctx, "Using token from provider configuration.")
@@ -453,18 +672,51 @@ func configureProvider(version, commit string) func(context.Context, *schema.Res
 			}
 		}
 
+		if v, ok := d.GetOk("cache_path"); ok {
+			if s, ok := v.(string); ok && s != "" {
+				tflog.Debug(ctx, "Using cache path from provider configuration.", map[string]any{"cache_path": s})
+				config.CachePath = s
+			}
+		}
+
+		if v, ok := d.GetOk("app_token_cache"); ok {
+			if b, ok := v.(bool); ok && b {
+				if config.CachePath == "" {
+					return nil, diag.Errorf("cache_path must be set to use app_token_cache")
+				}
+
+				tflog.Debug(ctx, "Using persistent GitHub App token cache.", map[string]any{"cache_path": config.CachePath})
+				config.AppTokenCache = true
+			}
+		}
+
+		config.Recorder = recorder
+
+		var telemetryFile string
+		if v, ok := d.GetOk("telemetry_file"); ok {
+			if s, ok := v.(string); ok && s != "" {
+				tflog.Debug(ctx, "Using telemetry file from provider configuration.", map[string]any{"telemetry_file": s})
+				telemetryFile = s
+			}
+		}
+		config.Telemetry = providerTelemetry(telemetryFile)
+
+		if budget, ok := getRateLimitBudget(d); ok {
+			tflog.Debug(ctx, "Using rate limit budget from provider configuration.", map[string]any{"min_remaining": budget.MinRemaining, "max_wait": budget.MaxWait.String(), "warn_remaining": budget.WarnRemaining})
+			config.RateLimitBudget = budget
+		}
+
 		if config.LegacyClient {
 			if config.AppID != nil {
-				pathSuffix := RESTAPIPath
-				if config.IsGHES {
-					pathSuffix = GHESRESTAPIPath
+				if err := config.exchangeAppToken(ctx); err != nil {
+					return nil, diag.FromErr(err)
 				}
+			}
 
-				appToken, err := GenerateOAuthTokenFromApp(config.BaseURL.JoinPath(pathSuffix), *config.AppID, *config.AppInstallationID, string(config.AppPEM))
-				if err != nil {
+			if config.OIDC != nil {
+				if err := config.exchangeOIDCToken(); err != nil {
 					return nil, diag.FromErr(err)
 				}
-				config.Token = appToken
 			}
 
 			if authMode == "auto" && config.Token == "" {
@@ -477,6 +729,14 @@ func configureProvider(version, commit string) func(context.Context, *schema.Res
 			return nil, diag.Errorf("auth_mode is set to token but no token was provided")
 		}
 
+		tflog.Info(ctx, "Using authentication mode.", map[string]any{"auth_mode": authMode, "selected_auth_mode": selectedAuthMode(config)})
+
+		owners, err := getOwners(d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		config.Owners = owners
+
 		if v, ok := d.GetOk("read_delay_ms"); ok {
 			if i, ok := v.(int); ok {
 				tflog.Debug(ctx, "Using read delay from provider configuration.", map[string]any{"read_delay_ms": i})
@@ -546,13 +806,6 @@ func configureProvider(version, commit string) func(context.Context, *schema.Res
 			}
 		}
 
-		if v, ok := d.GetOk("cache_path"); ok {
-			if s, ok := v.(string); ok && s != "" {
-				tflog.Debug(ctx, "Using cache path from provider configuration.", map[string]any{"cache_path": s})
-				config.CachePath = s
-			}
-		}
-
 		meta, err := configureProviderMeta(ctx, version, config)
 		if err != nil {
 			return nil, diag.FromErr(err)
@@ -565,8 +818,14 @@ func configureProvider(version, commit string) func(context.Context, *schema.Res
 // configureProviderMeta initializes the provider metadata, including setting up the GitHub API clients based on the provided configuration. It returns the initialized metadata or an error if the configuration is invalid or if there are issues initializing the clients.
 func configureProviderMeta(ctx context.Context, version string, c *Config) (*Owner, error) {
 	owner := &Owner{
-		name:       c.Owner,
-		maxPerPage: c.MaxPerPage,
+		name:           c.Owner,
+		maxPerPage:     c.MaxPerPage,
+		config:         c,
+		version:        version,
+		publicKeys:     newPublicKeyCache(),
+		userPublicKeys: newPublicKeyCache(),
+		graphQLURL:     c.graphQLURL(),
+		scopedOwners:   &sync.Map{},
 	}
 
 	if c.LegacyClient {
@@ -588,6 +847,7 @@ func configureProviderMeta(ctx context.Context, version string, c *Config) (*Own
 			return nil, err
 		}
 		owner.v4client = v4client
+		owner.v4httpClient = client
 
 		if owner.name == "" && c.Token != "" {
 			user, _, err := owner.v3client.Users.Get(ctx, "")
@@ -615,15 +875,36 @@ func configureProviderMeta(ctx context.Context, version string, c *Config) (*Own
 			RetryMax:      c.MaxRetries,
 			RetryWaitMin:  c.RetryDelay,
 			RetryWaitMax:  c.RetryDelay,
+			Recorder:      c.Recorder,
+			Telemetry:     c.Telemetry,
+			Budget:        c.RateLimitBudget,
 		}
 
 		var source ghclient.Source
 		if c.AppID != nil {
-			appSource, err := ghclient.NewAppSource(*c.AppID, c.AppPEM, options)
+			signer, err := c.appSigner(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			tokenCache, err := c.appTokenCache()
+			if err != nil {
+				return nil, fmt.Errorf("failed to create app token cache: %w", err)
+			}
+			options.TokenCache = tokenCache
+			options.TokenScope = c.AppTokenScope
+
+			appSource, err := ghclient.NewAppSourceFromSigner(*c.AppID, signer, options)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create app source: %w", err)
 			}
 			source = appSource
+		} else if c.OIDC != nil {
+			oidcSource, err := ghclient.NewOIDCSource(*c.OIDC, options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create oidc source: %w", err)
+			}
+			source = oidcSource
 		} else if c.Token != "" {
 			tokenSource, err := ghclient.NewTokenSource(c.Token, options)
 			if err != nil {
@@ -648,22 +929,25 @@ func configureProviderMeta(ctx context.Context, version string, c *Config) (*Own
 			return nil, fmt.Errorf("failed to create graphql client for owner %q: %w", owner.name, err)
 		}
 
+		v4httpClient, err := source.OwnerGraphQLHTTPClient(ctx, owner.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create graphql client for owner %q: %w", owner.name, err)
+		}
+
 		owner.v3client = v3client
 		owner.v4client = v4client
+		owner.v4httpClient = v4httpClient
+		owner.source = source
 	}
 
 	if owner.name != "" {
-		o, _, err := owner.v3client.Users.Get(ctx, owner.name)
-		if err != nil {
-			return nil, fmt.Errorf("failed to lookup owner %q: %w", owner.name, err)
-		}
-
-		if o.GetType() == "Organization" {
-			owner.IsOrganization = true
-			owner.id = o.GetID()
+		if err := owner.lookup(ctx); err != nil {
+			return nil, err
 		}
 	}
 
+	owner.owners = newOwnerRegistry(c.Owners)
+
 	return owner, nil
 }
 
@@ -701,8 +985,8 @@ func tokenFromGHCLI(ctx context.Context, u *url.URL) string {
 	return strings.TrimSpace(string(out))
 }
 
-// getAppAuth retrieves GitHub App authentication parameters from the provider configuration, environment variables, or defaults, and validates them. It returns the app ID, installation ID, PEM file content, and a boolean indicating whether valid app authentication parameters were found.
-func getAppAuth(d *schema.ResourceData, envPrefix string) (*string, *string, []byte, bool) {
+// getAppAuth retrieves GitHub App authentication parameters from the provider configuration, environment variables, or defaults, and validates them; the PEM file content is optional if the app has a signer. It returns the app ID, installation ID, PEM file content, and a boolean indicating whether valid app authentication parameters were found.
+func getAppAuth(d *schema.ResourceData, envPrefix string, hasSigner bool) (*string, *string, []byte, bool) {
 	envPrefix = strings.TrimSuffix(envPrefix, "_") + "_"
 
 	appID := os.Getenv(envPrefix + "ID")
@@ -711,17 +995,17 @@ func getAppAuth(d *schema.ResourceData, envPrefix string) (*string, *string, []b
 
 	v, ok := d.GetOk("app_auth")
 	if !ok {
-		return validateAppAuth(appID, appInstallationID, appPEM)
+		return validateAppAuth(appID, appInstallationID, appPEM, hasSigner)
 	}
 
 	c, ok := v.([]any)
 	if !ok || len(c) == 0 || c[0] == nil {
-		return validateAppAuth(appID, appInstallationID, appPEM)
+		return validateAppAuth(appID, appInstallationID, appPEM, hasSigner)
 	}
 
 	appAuthAttr, ok := c[0].(map[string]any)
 	if !ok {
-		return validateAppAuth(appID, appInstallationID, appPEM)
+		return validateAppAuth(appID, appInstallationID, appPEM, hasSigner)
 	}
 
 	if o, ok := appAuthAttr["id"]; ok {
@@ -742,18 +1026,273 @@ func getAppAuth(d *schema.ResourceData, envPrefix string) (*string, *string, []b
 		}
 	}
 
-	return validateAppAuth(appID, appInstallationID, appPEM)
+	return validateAppAuth(appID, appInstallationID, appPEM, hasSigner)
+}
+
+// getOIDCAuth retrieves the OIDC token exchange authentication parameters from the provider configuration or environment variables. It returns the options and a boolean indicating whether a token URL and a JWT source were found.
+func getOIDCAuth(d *schema.ResourceData) (*ghclient.OIDCOptions, bool) {
+	opts := &ghclient.OIDCOptions{
+		TokenURL:    os.Getenv("GITHUB_OIDC_TOKEN_URL"),
+		IDTokenFile: os.Getenv("GITHUB_OIDC_ID_TOKEN_FILE"),
+		Audience:    os.Getenv("GITHUB_OIDC_AUDIENCE"),
+		Scope:       os.Getenv("GITHUB_OIDC_SCOPE"),
+	}
+	idTokenEnvName := "GITHUB_OIDC_ID_TOKEN"
+
+	if v, ok := d.GetOk("oidc_auth"); ok {
+		if c, ok := v.([]any); ok && len(c) > 0 && c[0] != nil {
+			if oidcAuthAttr, ok := c[0].(map[string]any); ok {
+				if s, ok := oidcAuthAttr["token_url"].(string); ok && s != "" {
+					opts.TokenURL = s
+				}
+
+				if s, ok := oidcAuthAttr["id_token_file"].(string); ok && s != "" {
+					opts.IDTokenFile = s
+				}
+
+				if s, ok := oidcAuthAttr["id_token_env_name"].(string); ok && s != "" {
+					idTokenEnvName = s
+				}
+
+				if s, ok := oidcAuthAttr["audience"].(string); ok && s != "" {
+					opts.Audience = s
+				}
+
+				if s, ok := oidcAuthAttr["scope"].(string); ok && s != "" {
+					opts.Scope = s
+				}
+			}
+		}
+	}
+
+	if opts.IDTokenFile == "" {
+		opts.IDToken = os.Getenv(idTokenEnvName)
+	}
+
+	if opts.TokenURL == "" || (opts.IDTokenFile == "" && opts.IDToken == "") {
+		return nil, false
+	}
+
+	return opts, true
+}
+
+// useOIDCAuth returns whether OIDC authentication is used if it's available; in auto mode an explicit token takes precedence over OIDC only configured by the environment, e.g. of a CI job.
+func useOIDCAuth(d *schema.ResourceData, authMode string) bool {
+	switch authMode {
+	case "oidc":
+		return true
+	case "auto":
+		_, hasOIDCAuth := d.GetOk("oidc_auth")
+		_, hasToken := d.GetOk("token")
+		return hasOIDCAuth || !hasToken
+	default:
+		return false
+	}
+}
+
+// selectedAuthMode returns the authentication mode used by the configuration.
+func selectedAuthMode(config *Config) string {
+	switch {
+	case config.AppID != nil:
+		return "app"
+	case config.OIDC != nil:
+		return "oidc"
+	case config.Token != "":
+		return "token"
+	default:
+		return "none"
+	}
+}
+
+// getOwners retrieves the additional owners from the provider `owners` block.
+func getOwners(d *schema.ResourceData) ([]OwnerConfig, error) {
+	v, ok := d.GetOk("owners")
+	if !ok {
+		return nil, nil
+	}
+
+	c, ok := v.([]any)
+	if !ok {
+		return nil, nil
+	}
+
+	owners := make([]OwnerConfig, 0, len(c))
+	seen := make(map[string]bool, len(c))
+	for _, o := range c {
+		ownerAttr, ok := o.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		oc := OwnerConfig{}
+		oc.Name, _ = ownerAttr["name"].(string)
+		oc.Token, _ = ownerAttr["token"].(string)
+
+		if seen[strings.ToLower(oc.Name)] {
+			return nil, fmt.Errorf("owner %q is configured more than once in the owners block", oc.Name)
+		}
+		seen[strings.ToLower(oc.Name)] = true
+
+		if a, ok := ownerAttr["app_auth"].([]any); ok && len(a) > 0 && a[0] != nil {
+			appAuthAttr, _ := a[0].(map[string]any)
+			appID, _ := appAuthAttr["id"].(string)
+			appInstallationID, _ := appAuthAttr["installation_id"].(string)
+			appPEM, _ := appAuthAttr["pem_file"].(string)
+			appPrivateKeyFile, _ := appAuthAttr["private_key_file"].(string)
+			signerCommand, _ := appAuthAttr["signer_command"].([]any)
+			appSignerCommand := expandStringList(signerCommand)
+
+			if countNonEmpty(appPEM != "", appPrivateKeyFile != "", len(appSignerCommand) > 0) > 1 {
+				return nil, fmt.Errorf("only one of pem_file, private_key_file or signer_command can be set in the app_auth block for owner %q", oc.Name)
+			}
+
+			id, installationID, pem, ok := validateAppAuth(appID, appInstallationID, appPEM, appPrivateKeyFile != "" || len(appSignerCommand) > 0)
+			if !ok {
+				return nil, fmt.Errorf("app_auth block for owner %q is set but required fields are missing or contain empty values", oc.Name)
+			}
+			oc.AppID = id
+			oc.AppInstallationID = installationID
+			oc.AppPEM = pem
+			oc.AppPrivateKeyFile = appPrivateKeyFile
+			oc.AppSignerCommand = appSignerCommand
+			oc.AppTokenScope = expandAppTokenScope(appAuthAttr)
+		}
+
+		if oc.Token != "" && oc.AppID != nil {
+			return nil, fmt.Errorf("only one of token or app_auth can be set for owner %q", oc.Name)
+		}
+
+		owners = append(owners, oc)
+	}
+
+	return owners, nil
 }
 
-// validateAppAuth checks if the provided app authentication parameters are valid (non-empty) and returns them along with a boolean indicating validity.
-func validateAppAuth(appID, appInstallationID, appPEM string) (*string, *string, []byte, bool) {
-	if appID == "" || appInstallationID == "" || appPEM == "" {
+// validateAppAuth checks if the provided app authentication parameters are valid (non-empty, with the PEM file content optional if the app has a signer) and returns them along with a boolean indicating validity.
+func validateAppAuth(appID, appInstallationID, appPEM string, hasSigner bool) (*string, *string, []byte, bool) {
+	if appID == "" || appInstallationID == "" || (appPEM == "" && !hasSigner) {
 		return nil, nil, nil, false
 	}
 
+	if appPEM == "" {
+		return &appID, &appInstallationID, nil, true
+	}
+
 	return &appID, &appInstallationID, []byte(strings.ReplaceAll(appPEM, `\n`, "\n")), true
 }
 
+// getAppSigner retrieves the GitHub App signer parameters from the provider configuration or environment variables. It returns the private key file path and the external signer command.
+func getAppSigner(d *schema.ResourceData, envPrefix string) (string, []string) {
+	envPrefix = strings.TrimSuffix(envPrefix, "_") + "_"
+
+	privateKeyFile := os.Getenv(envPrefix + "PRIVATE_KEY_FILE")
+	signerCommand := strings.Fields(os.Getenv(envPrefix + "SIGNER_COMMAND"))
+
+	if v, ok := d.GetOk("app_auth"); ok {
+		if c, ok := v.([]any); ok && len(c) > 0 && c[0] != nil {
+			if appAuthAttr, ok := c[0].(map[string]any); ok {
+				if s, ok := appAuthAttr["private_key_file"].(string); ok && s != "" {
+					privateKeyFile = s
+				}
+
+				if l, ok := appAuthAttr["signer_command"].([]any); ok && len(l) > 0 {
+					signerCommand = make([]string, 0, len(l))
+					for _, a := range l {
+						if s, ok := a.(string); ok {
+							signerCommand = append(signerCommand, s)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return privateKeyFile, signerCommand
+}
+
+// getAppTokenScope retrieves the permissions and repositories that GitHub App installation tokens are restricted to from the provider configuration. It returns nil if the tokens aren't restricted.
+func getAppTokenScope(d *schema.ResourceData) *ghclient.InstallationTokenScope {
+	v, ok := d.GetOk("app_auth")
+	if !ok {
+		return nil
+	}
+
+	c, ok := v.([]any)
+	if !ok || len(c) == 0 || c[0] == nil {
+		return nil
+	}
+
+	appAuthAttr, ok := c[0].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	return expandAppTokenScope(appAuthAttr)
+}
+
+// expandAppTokenScope returns the permissions and repositories that GitHub App installation tokens are restricted to by an `app_auth` block, or nil if the tokens aren't restricted.
+func expandAppTokenScope(appAuthAttr map[string]any) *ghclient.InstallationTokenScope {
+	scope := &ghclient.InstallationTokenScope{}
+
+	if m, ok := appAuthAttr["permissions"].(map[string]any); ok && len(m) > 0 {
+		scope.Permissions = make(map[string]string, len(m))
+		for k, a := range m {
+			if s, ok := a.(string); ok {
+				scope.Permissions[k] = s
+			}
+		}
+	}
+
+	if set, ok := appAuthAttr["repositories"].(*schema.Set); ok && set.Len() > 0 {
+		for _, a := range set.List() {
+			if s, ok := a.(string); ok {
+				scope.Repositories = append(scope.Repositories, s)
+			}
+		}
+	}
+
+	if scope.IsEmpty() {
+		return nil
+	}
+
+	return scope
+}
+
+// getRateLimitBudget returns the rate limit budget configured by the `rate_limit_budget` block, if set.
+func getRateLimitBudget(d *schema.ResourceData) (*ghclient.RateLimitBudget, bool) {
+	v, ok := d.GetOk("rate_limit_budget")
+	if !ok {
+		return nil, false
+	}
+
+	c, ok := v.([]any)
+	if !ok || len(c) == 0 || c[0] == nil {
+		return nil, false
+	}
+
+	budgetAttr, ok := c[0].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	minRemaining, _ := budgetAttr["min_remaining"].(int)
+	maxWaitSeconds, _ := budgetAttr["max_wait_seconds"].(int)
+	warnRemaining, _ := budgetAttr["warn_remaining"].(int)
+
+	return ghclient.NewRateLimitBudget(minRemaining, time.Duration(maxWaitSeconds)*time.Second, warnRemaining), true
+}
+
+// countNonEmpty returns the number of true values.
+func countNonEmpty(values ...bool) int {
+	n := 0
+	for _, v := range values {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
 // getDefaultRetryableErrors returns the default set of retryable errors.
 func getDefaultRetryableErrors() map[int]bool {
 	return map[int]bool{
@@ -0,0 +1,174 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ephemeralResourceTelemetryScopePrefix prefixes the telemetry scope of ephemeral resources, so that they're distinguished from resources and data sources of the same type.
+const ephemeralResourceTelemetryScopePrefix = "ephemeral."
+
+// NewProviderServer returns a function that returns the gRPC server for this provider, which serves the ephemeral resources of the provider alongside the SDK provider.
+func NewProviderServer(version, commit string) func() tfprotov5.ProviderServer {
+	return func() tfprotov5.ProviderServer {
+		return newProviderServer(NewProvider(version, commit)())
+	}
+}
+
+// ephemeralResourcesProvider returns the provider holding the ephemeral resources of this provider.
+// The SDK doesn't support ephemeral resources, so they're implemented as data sources of this provider whose results are returned to Terraform as ephemeral values, which are never stored in the plan or the state.
+func ephemeralResourcesProvider() *schema.Provider {
+	p := &schema.Provider{
+		DataSourcesMap: map[string]*schema.Resource{
+			"github_actions_organization_registration_token": ephemeralResourceGithubActionsOrganizationRegistrationToken(),
+			"github_actions_organization_removal_token":      ephemeralResourceGithubActionsOrganizationRemovalToken(),
+			"github_actions_registration_token":              ephemeralResourceGithubActionsRegistrationToken(),
+			"github_actions_removal_token":                   ephemeralResourceGithubActionsRemovalToken(),
+			"github_app_token":                               ephemeralResourceGithubAppToken(),
+		},
+	}
+
+	for name, r := range p.DataSourcesMap {
+		withTelemetryScope(r, ephemeralResourceTelemetryScopePrefix+name)
+	}
+
+	addOwnerArguments(p)
+
+	return p
+}
+
+// providerServer serves the SDK provider along with its ephemeral resources, which are read by a server for the provider returned by [ephemeralResourcesProvider] using the meta of the configured SDK provider.
+type providerServer struct {
+	*schema.GRPCProviderServer
+
+	provider        *schema.Provider
+	ephemeral       *schema.Provider
+	ephemeralServer *schema.GRPCProviderServer
+}
+
+// newProviderServer creates a new providerServer for the SDK provider.
+func newProviderServer(p *schema.Provider) *providerServer {
+	ephemeral := ephemeralResourcesProvider()
+
+	return &providerServer{
+		GRPCProviderServer: schema.NewGRPCProviderServer(p),
+		provider:           p,
+		ephemeral:          ephemeral,
+		ephemeralServer:    schema.NewGRPCProviderServer(ephemeral),
+	}
+}
+
+// isEphemeralResource returns true if the type is an ephemeral resource of the provider.
+func (s *providerServer) isEphemeralResource(typeName string) bool {
+	_, ok := s.ephemeral.DataSourcesMap[typeName]
+	return ok
+}
+
+func (s *providerServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	resp, err := s.GRPCProviderServer.GetMetadata(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	for typeName := range s.ephemeral.DataSourcesMap {
+		resp.EphemeralResources = append(resp.EphemeralResources, tfprotov5.EphemeralResourceMetadata{TypeName: typeName})
+	}
+	slices.SortFunc(resp.EphemeralResources, func(a, b tfprotov5.EphemeralResourceMetadata) int {
+		return cmp.Compare(a.TypeName, b.TypeName)
+	})
+
+	return resp, nil
+}
+
+func (s *providerServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.GRPCProviderServer.GetProviderSchema(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	ephemeralResp, err := s.ephemeralServer.GetProviderSchema(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.EphemeralResourceSchemas = ephemeralResp.DataSourceSchemas
+	resp.Diagnostics = append(resp.Diagnostics, ephemeralResp.Diagnostics...)
+
+	return resp, nil
+}
+
+func (s *providerServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	resp, err := s.GRPCProviderServer.ConfigureProvider(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	s.ephemeral.SetMeta(s.provider.Meta())
+
+	return resp, nil
+}
+
+func (s *providerServer) StopProvider(ctx context.Context, req *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error) {
+	if _, err := s.ephemeralServer.StopProvider(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.GRPCProviderServer.StopProvider(ctx, req)
+}
+
+func (s *providerServer) ValidateEphemeralResourceConfig(ctx context.Context, req *tfprotov5.ValidateEphemeralResourceConfigRequest) (*tfprotov5.ValidateEphemeralResourceConfigResponse, error) {
+	if !s.isEphemeralResource(req.TypeName) {
+		return s.GRPCProviderServer.ValidateEphemeralResourceConfig(ctx, req)
+	}
+
+	resp, err := s.ephemeralServer.ValidateDataSourceConfig(ctx, &tfprotov5.ValidateDataSourceConfigRequest{
+		TypeName: req.TypeName,
+		Config:   req.Config,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.ValidateEphemeralResourceConfigResponse{Diagnostics: resp.Diagnostics}, nil
+}
+
+func (s *providerServer) OpenEphemeralResource(ctx context.Context, req *tfprotov5.OpenEphemeralResourceRequest) (*tfprotov5.OpenEphemeralResourceResponse, error) {
+	if !s.isEphemeralResource(req.TypeName) {
+		return s.GRPCProviderServer.OpenEphemeralResource(ctx, req)
+	}
+
+	resp, err := s.ephemeralServer.ReadDataSource(ctx, &tfprotov5.ReadDataSourceRequest{
+		TypeName: req.TypeName,
+		Config:   req.Config,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.OpenEphemeralResourceResponse{
+		Result:      resp.State,
+		Diagnostics: resp.Diagnostics,
+	}, nil
+}
+
+func (s *providerServer) RenewEphemeralResource(ctx context.Context, req *tfprotov5.RenewEphemeralResourceRequest) (*tfprotov5.RenewEphemeralResourceResponse, error) {
+	if !s.isEphemeralResource(req.TypeName) {
+		return s.GRPCProviderServer.RenewEphemeralResource(ctx, req)
+	}
+
+	// The credentials can't be renewed, so Terraform is never asked to renew them.
+	return &tfprotov5.RenewEphemeralResourceResponse{}, nil
+}
+
+func (s *providerServer) CloseEphemeralResource(ctx context.Context, req *tfprotov5.CloseEphemeralResourceRequest) (*tfprotov5.CloseEphemeralResourceResponse, error) {
+	if !s.isEphemeralResource(req.TypeName) {
+		return s.GRPCProviderServer.CloseEphemeralResource(ctx, req)
+	}
+
+	// The credentials are left to expire, as they're usually passed on to be used after Terraform has finished.
+	return &tfprotov5.CloseEphemeralResourceResponse{}, nil
+}
@@ -0,0 +1,189 @@
+package github
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testDynamicValue returns a value of the schema block with the attributes set, and the other attributes and blocks unset, as Terraform sends it to the provider.
+func testDynamicValue(t *testing.T, block *tfprotov5.SchemaBlock, attributes map[string]tftypes.Value) *tfprotov5.DynamicValue {
+	t.Helper()
+
+	typ := block.ValueType().(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for _, a := range block.Attributes {
+		values[a.Name] = tftypes.NewValue(typ.AttributeTypes[a.Name], nil)
+	}
+	for _, b := range block.BlockTypes {
+		values[b.TypeName] = tftypes.NewValue(typ.AttributeTypes[b.TypeName], []tftypes.Value{})
+	}
+	maps.Copy(values, attributes)
+
+	v, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, values))
+	if err != nil {
+		t.Fatalf("failed to create value: %v", err)
+	}
+
+	return &v
+}
+
+// testDiagnostics fails the test if there are any error diagnostics.
+func testDiagnostics(t *testing.T, diags []*tfprotov5.Diagnostic) {
+	t.Helper()
+
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+func TestProviderServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ephemeral_resources_are_valid", func(t *testing.T) {
+		t.Parallel()
+
+		if err := ephemeralResourcesProvider().InternalValidate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("serves_ephemeral_resources", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewProviderServer("dev", "")()
+		ctx := t.Context()
+
+		metadata, err := s.GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var typeNames []string
+		for _, r := range metadata.EphemeralResources {
+			typeNames = append(typeNames, r.TypeName)
+		}
+		if !slices.Contains(typeNames, "github_app_token") || !slices.Contains(typeNames, "github_actions_registration_token") {
+			t.Errorf("expected the ephemeral resources in the metadata, got %v", typeNames)
+		}
+		if len(metadata.DataSources) == 0 || len(metadata.Resources) == 0 {
+			t.Errorf("expected the data sources and resources of the SDK provider in the metadata")
+		}
+
+		schemas, err := s.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		testDiagnostics(t, schemas.Diagnostics)
+
+		if len(schemas.EphemeralResourceSchemas) != len(typeNames) {
+			t.Fatalf("expected %d ephemeral resource schemas, got %d", len(typeNames), len(schemas.EphemeralResourceSchemas))
+		}
+
+		attributes := map[string]*tfprotov5.SchemaAttribute{}
+		for _, a := range schemas.EphemeralResourceSchemas["github_actions_registration_token"].Block.Attributes {
+			attributes[a.Name] = a
+		}
+		if a, ok := attributes["token"]; !ok || !a.Sensitive || !a.Computed {
+			t.Errorf("expected a computed sensitive token attribute, got %v", a)
+		}
+		if _, ok := attributes[ownerArgumentName]; !ok {
+			t.Errorf("expected the %s argument", ownerArgumentName)
+		}
+	})
+
+	t.Run("validates_ephemeral_resource_config", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewProviderServer("dev", "")()
+		ctx := t.Context()
+
+		schemas, err := s.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		resp, err := s.ValidateEphemeralResourceConfig(ctx, &tfprotov5.ValidateEphemeralResourceConfigRequest{
+			TypeName: "github_actions_registration_token",
+			Config:   testDynamicValue(t, schemas.EphemeralResourceSchemas["github_actions_registration_token"].Block, nil),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Diagnostics) == 0 {
+			t.Error("expected an error for a token without a repository")
+		}
+
+		resp, err = s.ValidateEphemeralResourceConfig(ctx, &tfprotov5.ValidateEphemeralResourceConfigRequest{TypeName: "github_repository"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Diagnostics) == 0 {
+			t.Error("expected an error for an unknown ephemeral resource")
+		}
+	})
+
+	t.Run("opens_ephemeral_resources_with_the_provider_meta", func(t *testing.T) {
+		t.Parallel()
+
+		fake, _ := newFakeGitHub(t, "test-org")
+
+		s := NewProviderServer("dev", "")()
+		ctx := t.Context()
+
+		schemas, err := s.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		configured, err := s.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{
+			TerraformVersion: "1.11.0",
+			Config: testDynamicValue(t, schemas.Provider.Block, map[string]tftypes.Value{
+				"base_url": tftypes.NewValue(tftypes.String, fake.URL+"/"),
+				"token":    tftypes.NewValue(tftypes.String, "fake-token"),
+				"owner":    tftypes.NewValue(tftypes.String, "test-org"),
+			}),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		testDiagnostics(t, configured.Diagnostics)
+
+		tokenSchema := schemas.EphemeralResourceSchemas["github_actions_organization_registration_token"]
+		opened, err := s.OpenEphemeralResource(ctx, &tfprotov5.OpenEphemeralResourceRequest{
+			TypeName: "github_actions_organization_registration_token",
+			Config:   testDynamicValue(t, tokenSchema.Block, nil),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		testDiagnostics(t, opened.Diagnostics)
+
+		result, err := opened.Result.Unmarshal(tokenSchema.ValueType())
+		if err != nil {
+			t.Fatalf("failed to unmarshal result: %v", err)
+		}
+		var values map[string]tftypes.Value
+		if err := result.As(&values); err != nil {
+			t.Fatalf("failed to convert result: %v", err)
+		}
+
+		var token string
+		if err := values["token"].As(&token); err != nil || token == "" {
+			t.Errorf("expected a registration token, got %q (%v)", token, err)
+		}
+
+		renewed, err := s.RenewEphemeralResource(ctx, &tfprotov5.RenewEphemeralResourceRequest{TypeName: "github_actions_organization_registration_token"})
+		if err != nil || len(renewed.Diagnostics) != 0 {
+			t.Errorf("expected the ephemeral resource to renew without error, got %v %v", err, renewed.Diagnostics)
+		}
+		closed, err := s.CloseEphemeralResource(ctx, &tfprotov5.CloseEphemeralResourceRequest{TypeName: "github_actions_organization_registration_token"})
+		if err != nil || len(closed.Diagnostics) != 0 {
+			t.Errorf("expected the ephemeral resource to close without error, got %v %v", err, closed.Diagnostics)
+		}
+	})
+}
@@ -3,8 +3,14 @@ package github
 import (
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
 )
 
 func TestProvider(t *testing.T) {
@@ -51,6 +57,46 @@ func Test_configureProviderMeta(t *testing.T) {
 			wantIsOrg: true,
 			wantOrgId: 123456,
 		},
+		{
+			name:        "app_auth_private_key_file",
+			installResp: new(`{"id": 999999}`),
+			userResp:    new(`{"id": 123456, "type": "Organization"}`),
+			conf: &Config{
+				AppID:             new("111111"),
+				AppInstallationID: new("999999"),
+				AppPrivateKeyFile: testGitHubAppPrivateKeyFile,
+				Owner:             "test-org",
+			},
+			wantName:  "test-org",
+			wantIsOrg: true,
+			wantOrgId: 123456,
+		},
+		{
+			name:        "app_auth_token_cache",
+			installResp: new(`{"id": 999999}`),
+			userResp:    new(`{"id": 123456, "type": "Organization"}`),
+			conf: &Config{
+				AppID:             new("111111"),
+				AppInstallationID: new("999999"),
+				AppPEM:            mustNewPEM(t),
+				CachePath:         t.TempDir(),
+				AppTokenCache:     true,
+				Owner:             "test-org",
+			},
+			wantName:  "test-org",
+			wantIsOrg: true,
+			wantOrgId: 123456,
+		},
+		{
+			name: "app_auth_errors_on_failed_signer_command",
+			conf: &Config{
+				AppID:             new("111111"),
+				AppInstallationID: new("999999"),
+				AppSignerCommand:  []string{"false"},
+				Owner:             "test-org",
+			},
+			wantErr: "failed to create github app signer",
+		},
 		{
 			name:        "app_auth_user",
 			installResp: new(`{"id": 999999}`),
@@ -83,6 +129,25 @@ func Test_configureProviderMeta(t *testing.T) {
 			},
 			wantName: "test-user",
 		},
+		{
+			name:     "oidc_auth_organization",
+			userResp: new(`{"id": 123456, "type": "Organization"}`),
+			conf: &Config{
+				Owner: "test-org",
+				OIDC:  &ghclient.OIDCOptions{IDToken: "test-jwt"},
+			},
+			wantName:  "test-org",
+			wantIsOrg: true,
+			wantOrgId: 123456,
+		},
+		{
+			name: "oidc_auth_errors_on_rejected_id_token",
+			conf: &Config{
+				Owner: "test-org",
+				OIDC:  &ghclient.OIDCOptions{IDToken: "invalid-jwt"},
+			},
+			wantErr: "failed to lookup owner",
+		},
 		{
 			name: "errors_on_missing_owner",
 			conf: &Config{
@@ -186,6 +251,18 @@ func Test_configureProviderMeta(t *testing.T) {
 			t.Parallel()
 
 			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/oidc/token" {
+					if r.FormValue("subject_token") != "test-jwt" {
+						w.WriteHeader(http.StatusUnauthorized)
+						_, _ = w.Write([]byte(`{"error": "invalid_grant"}`))
+						return
+					}
+
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{"access_token": "test-token", "token_type": "Bearer", "expires_in": 3600}`))
+					return
+				}
+
 				if regexp.MustCompile(`/access_tokens$`).MatchString(r.URL.Path) {
 					w.WriteHeader(http.StatusOK)
 					_, _ = w.Write([]byte(`{"token": "test-token", "expires_at": "2024-12-31T23:59:59Z"}`))
@@ -230,6 +307,9 @@ func Test_configureProviderMeta(t *testing.T) {
 			t.Cleanup(ts.Close)
 
 			tt.conf.BaseURL = mustNewURL(t, ts.URL)
+			if tt.conf.OIDC != nil {
+				tt.conf.OIDC.TokenURL = ts.URL + "/oidc/token"
+			}
 
 			meta, err := configureProviderMeta(t.Context(), "test", tt.conf)
 			if err != nil {
@@ -271,6 +351,153 @@ func Test_configureProviderMeta(t *testing.T) {
 	}
 }
 
+func Test_getOIDCAuth(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name   string
+		raw    map[string]any
+		want   *ghclient.OIDCOptions
+		wantOk bool
+	}{
+		{
+			name: "not_configured",
+			raw:  map[string]any{},
+		},
+		{
+			name: "id_token_file",
+			raw: map[string]any{
+				"oidc_auth": []any{map[string]any{"token_url": "https://sts.example.com/exchange", "id_token_file": "/var/run/secrets/token", "audience": "github", "scope": "octo-org"}},
+			},
+			want:   &ghclient.OIDCOptions{TokenURL: "https://sts.example.com/exchange", IDTokenFile: "/var/run/secrets/token", Audience: "github", Scope: "octo-org"},
+			wantOk: true,
+		},
+		{
+			name: "missing_id_token",
+			raw: map[string]any{
+				"oidc_auth": []any{map[string]any{"token_url": "https://sts.example.com/exchange", "id_token_env_name": "TEST_GET_OIDC_AUTH_MISSING_ID_TOKEN"}},
+			},
+		},
+		{
+			name: "missing_token_url",
+			raw: map[string]any{
+				"oidc_auth": []any{map[string]any{"id_token_file": "/var/run/secrets/token"}},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, NewProvider("test", "none")().Schema, tt.raw)
+
+			got, ok := getOIDCAuth(d)
+			if ok != tt.wantOk {
+				t.Fatalf("expected ok to be %v, got %v", tt.wantOk, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if *got != *tt.want {
+				t.Errorf("expected oidc options to be %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_useOIDCAuth(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name     string
+		authMode string
+		raw      map[string]any
+		want     bool
+	}{
+		{
+			name:     "auto",
+			authMode: "auto",
+			raw:      map[string]any{},
+			want:     true,
+		},
+		{
+			name:     "auto_with_token",
+			authMode: "auto",
+			raw:      map[string]any{"token": "test-token"},
+		},
+		{
+			name:     "auto_with_token_and_oidc_auth",
+			authMode: "auto",
+			raw: map[string]any{
+				"token":     "test-token",
+				"oidc_auth": []any{map[string]any{"token_url": "https://sts.example.com/exchange"}},
+			},
+			want: true,
+		},
+		{
+			name:     "oidc_with_token",
+			authMode: "oidc",
+			raw:      map[string]any{"token": "test-token"},
+			want:     true,
+		},
+		{
+			name:     "token",
+			authMode: "token",
+			raw:      map[string]any{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, NewProvider("test", "none")().Schema, tt.raw)
+
+			if got := useOIDCAuth(d, tt.authMode); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_getAppTokenScope(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name string
+		raw  map[string]any
+		want *ghclient.InstallationTokenScope
+	}{
+		{
+			name: "not_configured",
+			raw:  map[string]any{},
+		},
+		{
+			name: "unscoped",
+			raw: map[string]any{
+				"app_auth": []any{map[string]any{"id": "111111", "installation_id": "999999"}},
+			},
+		},
+		{
+			name: "scoped",
+			raw: map[string]any{
+				"app_auth": []any{map[string]any{"id": "111111", "installation_id": "999999", "permissions": map[string]any{"contents": "read"}, "repositories": []any{"octo-repo"}}},
+			},
+			want: &ghclient.InstallationTokenScope{Permissions: map[string]string{"contents": "read"}, Repositories: []string{"octo-repo"}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, NewProvider("test", "none")().Schema, tt.raw)
+
+			got := getAppTokenScope(d)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected token scope to be %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_ghCLIHostFromAPIHost(t *testing.T) {
 	t.Parallel()
 
@@ -317,3 +544,54 @@ func Test_ghCLIHostFromAPIHost(t *testing.T) {
 		})
 	}
 }
+
+func Test_getRateLimitBudget(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name              string
+		raw               map[string]any
+		wantOK            bool
+		wantMinRemaining  int
+		wantMaxWait       time.Duration
+		wantWarnRemaining int
+	}{
+		{
+			name: "not_configured",
+			raw:  map[string]any{},
+		},
+		{
+			name:        "defaults",
+			raw:         map[string]any{"rate_limit_budget": []any{map[string]any{}}},
+			wantOK:      true,
+			wantMaxWait: 5 * time.Minute,
+		},
+		{
+			name:              "configured",
+			raw:               map[string]any{"rate_limit_budget": []any{map[string]any{"min_remaining": 100, "max_wait_seconds": 60, "warn_remaining": 500}}},
+			wantOK:            true,
+			wantMinRemaining:  100,
+			wantMaxWait:       time.Minute,
+			wantWarnRemaining: 500,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, NewProvider("test", "none")().Schema, tt.raw)
+
+			got, ok := getRateLimitBudget(d)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok to be %t, got %t", tt.wantOK, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if got.MinRemaining != tt.wantMinRemaining || got.MaxWait != tt.wantMaxWait || got.WarnRemaining != tt.wantWarnRemaining {
+				t.Errorf("expected budget to be %d/%s/%d, got %d/%s/%d", tt.wantMinRemaining, tt.wantMaxWait, tt.wantWarnRemaining, got.MinRemaining, got.MaxWait, got.WarnRemaining)
+			}
+		})
+	}
+}
@@ -2,8 +2,8 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -70,21 +70,36 @@ func resourceGithubActionsEnvironmentSecret() *schema.Resource {
 				Optional:      true,
 				Computed:      true,
 				RequiredWith:  []string{"value_encrypted"},
-				ConflictsWith: []string{"value", "plaintext_value"},
+				ConflictsWith: []string{"value", "value_wo", "plaintext_value"},
 				Description:   "ID of the public key used to encrypt the secret. This is required when setting `value_encrypted`.",
 			},
 			"value": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				ExactlyOneOf: []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				Description:  "Plaintext value to be encrypted.",
 			},
+			"value_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
+				RequiredWith: []string{"value_wo_version"},
+				Description:  "Write-only plaintext value to be encrypted, which is never stored in the state. Requires Terraform 1.11 or later.",
+			},
+			"value_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"value_wo"},
+				Description:  "Version of `value_wo`, which must be changed to update the secret with a new `value_wo`.",
+			},
 			"value_encrypted": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Sensitive:        true,
-				ExactlyOneOf:     []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf:     []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 				Description:      "Value encrypted with the GitHub public key, defined by `key_id`, in Base64 format.",
 			},
@@ -92,7 +107,7 @@ func resourceGithubActionsEnvironmentSecret() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Sensitive:        true,
-				ExactlyOneOf:     []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf:     []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 				Description:      "Encrypted value of the secret using the GitHub public key in Base64 format.",
 				Deprecated:       "Use `value_encrypted` and `key_id`.",
@@ -101,7 +116,7 @@ func resourceGithubActionsEnvironmentSecret() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				ExactlyOneOf: []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				Description:  "Plaintext value of the secret to be encrypted.",
 				Deprecated:   "Use `value`.",
 			},
@@ -120,6 +135,12 @@ func resourceGithubActionsEnvironmentSecret() *schema.Resource {
 				Computed:    true,
 				Description: "Timestamp for when the secret was last updated.",
 			},
+			"drift_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(secretDriftPolicies, false)),
+				Description:      "How to plan the secret when it has been updated outside of Terraform. Must be one of `overwrite_in_place`, `recreate`, `ignore` or `error`; defaults to `overwrite_in_place`.",
+			},
 		},
 	}
 }
@@ -143,33 +164,23 @@ func resourceGithubActionsEnvironmentSecretCreate(ctx context.Context, d *schema
 	}
 	repoID := int(repo.GetID())
 
-	var publicKey string
-	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getEnvironmentPublicKeyDetails(ctx, meta, owner, repoName, escapedEnvName)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		keyID = ki
-		publicKey = pk
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue, _ = resourceKeysGetOk[string](d, "value", "plaintext_value")
 	}
 
-	if len(encryptedValue) == 0 {
-		plaintextValue, _ := resourceKeysGetOk[string](d, "value", "plaintext_value")
-
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return diag.FromErr(err)
+	keyID, err = putSecret(ctx, meta, keyID, encryptedValue, plaintextValue, func() (string, string, error) {
+		return getEnvironmentPublicKeyDetails(ctx, meta, owner, repoName, escapedEnvName)
+	}, func(keyID, encryptedValue string) error {
+		secretReq := github.SecretRequest{
+			EncryptedValue: encryptedValue,
+			KeyID:          keyID,
 		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
-	}
-
-	secretReq := github.SecretRequest{
-		EncryptedValue: encryptedValue,
-		KeyID:          keyID,
-	}
 
-	if _, err := client.Actions.CreateOrUpdateEnvSecret(ctx, owner, repoName, escapedEnvName, secretName, secretReq); err != nil {
+		_, err := client.Actions.CreateOrUpdateEnvSecret(ctx, owner, repoName, escapedEnvName, secretName, secretReq)
+		return err
+	})
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -250,6 +261,10 @@ func resourceGithubActionsEnvironmentSecretRead(ctx context.Context, d *schema.R
 }
 
 func resourceGithubActionsEnvironmentSecretUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	if onlySecretDriftPolicyChanged(d) {
+		return nil
+	}
+
 	meta, _ := m.(*Owner)
 	client := meta.v3client
 	owner := meta.name
@@ -262,33 +277,23 @@ func resourceGithubActionsEnvironmentSecretUpdate(ctx context.Context, d *schema
 
 	escapedEnvName := url.PathEscape(envName)
 
-	var publicKey string
-	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getEnvironmentPublicKeyDetails(ctx, meta, owner, repoName, escapedEnvName)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		keyID = ki
-		publicKey = pk
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue, _ = resourceKeysGetOk[string](d, "value", "plaintext_value")
 	}
 
-	if len(encryptedValue) == 0 {
-		plaintextValue, _ := resourceKeysGetOk[string](d, "value", "plaintext_value")
-
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return diag.FromErr(err)
+	keyID, err := putSecret(ctx, meta, keyID, encryptedValue, plaintextValue, func() (string, string, error) {
+		return getEnvironmentPublicKeyDetails(ctx, meta, owner, repoName, escapedEnvName)
+	}, func(keyID, encryptedValue string) error {
+		secretReq := github.SecretRequest{
+			EncryptedValue: encryptedValue,
+			KeyID:          keyID,
 		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
-	}
-
-	secretReq := github.SecretRequest{
-		EncryptedValue: encryptedValue,
-		KeyID:          keyID,
-	}
 
-	if _, err := client.Actions.CreateOrUpdateEnvSecret(ctx, owner, repoName, escapedEnvName, secretName, secretReq); err != nil {
+		_, err := client.Actions.CreateOrUpdateEnvSecret(ctx, owner, repoName, escapedEnvName, secretName, secretReq)
+		return err
+	})
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -390,10 +395,8 @@ func resourceGithubActionsEnvironmentSecretImport(ctx context.Context, d *schema
 func getEnvironmentPublicKeyDetails(ctx context.Context, meta *Owner, owner, repoName, envNameEscaped string) (string, string, error) {
 	client := meta.v3client
 
-	publicKey, _, err := client.Actions.GetEnvPublicKey(ctx, owner, repoName, envNameEscaped)
-	if err != nil {
-		return "", "", err
-	}
-
-	return publicKey.GetKeyID(), publicKey.GetKey(), nil
+	return meta.publicKeys.get(ctx, fmt.Sprintf("repos/%s/%s/environments/%s/secrets/public-key", owner, repoName, envNameEscaped), func() (*github.PublicKey, error) {
+		publicKey, _, err := client.Actions.GetEnvPublicKey(ctx, owner, repoName, envNameEscaped)
+		return publicKey, err
+	})
 }
@@ -2,8 +2,8 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/google/go-github/v89/github"
@@ -53,21 +53,36 @@ func resourceGithubActionsOrganizationSecret() *schema.Resource {
 				Optional:      true,
 				Computed:      true,
 				RequiredWith:  []string{"value_encrypted"},
-				ConflictsWith: []string{"value", "plaintext_value"},
+				ConflictsWith: []string{"value", "value_wo", "plaintext_value"},
 				Description:   "ID of the public key used to encrypt the secret.",
 			},
 			"value": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				ExactlyOneOf: []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				Description:  "Plaintext value to be encrypted.",
 			},
+			"value_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
+				RequiredWith: []string{"value_wo_version"},
+				Description:  "Write-only plaintext value to be encrypted, which is never stored in the state. Requires Terraform 1.11 or later.",
+			},
+			"value_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"value_wo"},
+				Description:  "Version of `value_wo`, which must be changed to update the secret with a new `value_wo`.",
+			},
 			"value_encrypted": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Sensitive:        true,
-				ExactlyOneOf:     []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf:     []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 				Description:      "Value encrypted with the GitHub public key, defined by key_id, in Base64 format.",
 			},
@@ -75,7 +90,7 @@ func resourceGithubActionsOrganizationSecret() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Sensitive:        true,
-				ExactlyOneOf:     []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf:     []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 				Description:      "Encrypted value of the secret using the GitHub public key in Base64 format.",
 				Deprecated:       "Use value_encrypted and key_id.",
@@ -84,7 +99,7 @@ func resourceGithubActionsOrganizationSecret() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				ExactlyOneOf: []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				Description:  "Plaintext value of the secret to be encrypted.",
 				Deprecated:   "Use value.",
 			},
@@ -119,10 +134,16 @@ func resourceGithubActionsOrganizationSecret() *schema.Resource {
 				Computed:    true,
 				Description: "Timestamp for when the secret was last updated.",
 			},
+			"drift_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(secretDriftPolicies, false)),
+				Description:      "How to plan the secret when it has been updated outside of Terraform. Must be one of `overwrite_in_place`, `recreate`, `ignore` or `error`; defaults to `overwrite_in_place`.",
+			},
 			"destroy_on_drift": {
 				Type:       schema.TypeBool,
 				Optional:   true,
-				Deprecated: "This is no longer required and will be removed in a future release. Drift detection is now always performed, and external changes will result in the secret being updated to match the Terraform configuration. If you want to ignore external changes, you can use the `lifecycle` block with `ignore_changes` on the `updated_at` field.",
+				Deprecated: "This is no longer required and will be removed in a future release. Drift detection is now always performed, and external changes are handled as set by `drift_policy`.",
 			},
 		},
 	}
@@ -148,35 +169,25 @@ func resourceGithubActionsOrganizationSecretCreate(ctx context.Context, d *schem
 		}
 	}
 
-	var publicKey string
-	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getOrganizationPublicKeyDetails(ctx, meta)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		keyID = ki
-		publicKey = pk
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue, _ = resourceKeysGetOk[string](d, "value", "plaintext_value")
 	}
 
-	if len(encryptedValue) == 0 {
-		plaintextValue, _ := resourceKeysGetOk[string](d, "value", "plaintext_value")
-
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return diag.FromErr(err)
+	keyID, err := putSecret(ctx, meta, keyID, encryptedValue, plaintextValue, func() (string, string, error) {
+		return getOrganizationPublicKeyDetails(ctx, meta)
+	}, func(keyID, encryptedValue string) error {
+		secretReq := github.OrgSecretRequest{
+			KeyID:                 keyID,
+			EncryptedValue:        encryptedValue,
+			Visibility:            visibility,
+			SelectedRepositoryIDs: repoIDs,
 		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
-	}
-
-	secretReq := github.OrgSecretRequest{
-		KeyID:                 keyID,
-		EncryptedValue:        encryptedValue,
-		Visibility:            visibility,
-		SelectedRepositoryIDs: repoIDs,
-	}
 
-	if _, err := client.Actions.CreateOrUpdateOrgSecret(ctx, owner, secretName, secretReq); err != nil {
+		_, err := client.Actions.CreateOrUpdateOrgSecret(ctx, owner, secretName, secretReq)
+		return err
+	})
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -266,6 +277,10 @@ func resourceGithubActionsOrganizationSecretRead(ctx context.Context, d *schema.
 }
 
 func resourceGithubActionsOrganizationSecretUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	if onlySecretDriftPolicyChanged(d) {
+		return nil
+	}
+
 	meta, _ := m.(*Owner)
 	client := meta.v3client
 	owner := meta.name
@@ -285,35 +300,25 @@ func resourceGithubActionsOrganizationSecretUpdate(ctx context.Context, d *schem
 		}
 	}
 
-	var publicKey string
-	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getOrganizationPublicKeyDetails(ctx, meta)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		keyID = ki
-		publicKey = pk
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue, _ = resourceKeysGetOk[string](d, "value", "plaintext_value")
 	}
 
-	if len(encryptedValue) == 0 {
-		plaintextValue, _ := resourceKeysGetOk[string](d, "value", "plaintext_value")
-
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return diag.FromErr(err)
+	keyID, err := putSecret(ctx, meta, keyID, encryptedValue, plaintextValue, func() (string, string, error) {
+		return getOrganizationPublicKeyDetails(ctx, meta)
+	}, func(keyID, encryptedValue string) error {
+		secretReq := github.OrgSecretRequest{
+			KeyID:                 keyID,
+			EncryptedValue:        encryptedValue,
+			Visibility:            visibility,
+			SelectedRepositoryIDs: repoIDs,
 		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
-	}
-
-	secretReq := github.OrgSecretRequest{
-		KeyID:                 keyID,
-		EncryptedValue:        encryptedValue,
-		Visibility:            visibility,
-		SelectedRepositoryIDs: repoIDs,
-	}
 
-	if _, err := client.Actions.CreateOrUpdateOrgSecret(ctx, owner, secretName, secretReq); err != nil {
+		_, err := client.Actions.CreateOrUpdateOrgSecret(ctx, owner, secretName, secretReq)
+		return err
+	})
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -391,10 +396,8 @@ func getOrganizationPublicKeyDetails(ctx context.Context, meta *Owner) (string,
 	client := meta.v3client
 	owner := meta.name
 
-	publicKey, _, err := client.Actions.GetOrgPublicKey(ctx, owner)
-	if err != nil {
-		return "", "", err
-	}
-
-	return publicKey.GetKeyID(), publicKey.GetKey(), err
+	return meta.publicKeys.get(ctx, fmt.Sprintf("orgs/%s/actions/secrets/public-key", owner), func() (*github.PublicKey, error) {
+		publicKey, _, err := client.Actions.GetOrgPublicKey(ctx, owner)
+		return publicKey, err
+	})
 }
@@ -0,0 +1,202 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceGithubActionsRunnerJITConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Registers a just-in-time self-hosted GitHub Actions runner with a repository or an organization, and removes the runner when destroyed.",
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The repository to register the runner with; the runner is registered with the organization if unset.",
+			},
+			"repository_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the repository, or `0` for an organization runner.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the runner.",
+			},
+			"runner_group_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     1,
+				Description: "The ID of the runner group to register the runner in, defaults to the default runner group.",
+			},
+			"labels": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				MaxItems:    100,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The custom labels of the runner.",
+			},
+			"work_folder": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "_work",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+				Description:      "The working directory of the runner, relative to its installation directory.",
+			},
+			"runner_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the runner.",
+			},
+			"encoded_jit_config": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The base64 encoded runner configuration to pass to the runner's `--jitconfig` option.",
+			},
+		},
+
+		CreateContext: resourceGithubActionsRunnerJITConfigCreate,
+		ReadContext:   resourceGithubActionsRunnerJITConfigRead,
+		DeleteContext: resourceGithubActionsRunnerJITConfigDelete,
+	}
+}
+
+func resourceGithubActionsRunnerJITConfigCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta, _ := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+
+	repoName, _ := d.Get("repository").(string)
+	runnerGroupID, _ := d.Get("runner_group_id").(int)
+	workFolder, _ := d.Get("work_folder").(string)
+
+	req := github.CreateJITConfigRequest{
+		RunnerGroupID: int64(runnerGroupID),
+		WorkFolder:    new(workFolder),
+	}
+	req.Name, _ = d.Get("name").(string)
+	for _, label := range d.Get("labels").(*schema.Set).List() {
+		req.Labels = append(req.Labels, label.(string))
+	}
+
+	var config *github.JITRunnerConfig
+	var repoID int
+	if repoName == "" {
+		if err := checkOrganization(m); err != nil {
+			return diag.FromErr(err)
+		}
+
+		tflog.Info(ctx, "Registering organization runner.", map[string]any{"name": req.Name})
+
+		var err error
+		config, _, err = client.Actions.CreateOrgJITConfig(ctx, owner, req)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		repo, _, err := client.Repositories.Get(ctx, owner, repoName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		repoID = int(repo.GetID())
+
+		tflog.Info(ctx, "Registering repository runner.", map[string]any{"name": req.Name, "repository": repoName})
+
+		config, _, err = client.Actions.CreateRepoJITConfig(ctx, owner, repoName, req)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	runnerID := config.GetRunner().GetID()
+
+	id, err := buildID(repoName, strconv.FormatInt(runnerID, 10))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := d.Set("repository_id", repoID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("runner_id", int(runnerID)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("encoded_jit_config", config.GetEncodedJITConfig()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubActionsRunnerJITConfigRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta, _ := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+
+	repoName, _ := d.Get("repository").(string)
+	runnerIDInt, _ := d.Get("runner_id").(int)
+	runnerID := int64(runnerIDInt)
+
+	var err error
+	if repoName == "" {
+		_, _, err = client.Actions.GetOrganizationRunner(ctx, owner, runnerID)
+	} else {
+		_, _, err = client.Actions.GetRunner(ctx, owner, repoName, runnerID)
+	}
+	if err != nil {
+		if ghErr, ok := errors.AsType[*github.ErrorResponse](err); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+			// A just-in-time runner removes itself after running a job, so it's registered again on the next apply.
+			tflog.Info(ctx, "Removing runner JIT config from state because the runner no longer exists in GitHub", map[string]any{"repository": repoName, "runner_id": runnerID})
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubActionsRunnerJITConfigDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta, _ := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+
+	repoName, _ := d.Get("repository").(string)
+	runnerIDInt, _ := d.Get("runner_id").(int)
+	runnerID := int64(runnerIDInt)
+
+	tflog.Info(ctx, "Removing runner.", map[string]any{"repository": repoName, "runner_id": runnerID})
+
+	var err error
+	if repoName == "" {
+		_, err = client.Actions.RemoveOrganizationRunner(ctx, owner, runnerID)
+	} else {
+		_, err = client.Actions.RemoveRunner(ctx, owner, repoName, runnerID)
+	}
+	if err != nil {
+		if ghErr, ok := errors.AsType[*github.ErrorResponse](err); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
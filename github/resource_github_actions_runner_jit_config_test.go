@@ -0,0 +1,109 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccGithubActionsRunnerJITConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("registers_organization_runner", func(t *testing.T) {
+		t.Parallel()
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		config := fmt.Sprintf(`
+resource "github_actions_runner_jit_config" "test" {
+  name   = "%srunner-%s"
+  labels = ["self-hosted", "test"]
+}
+`, testResourcePrefix, randomID)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnlessHasOrgs(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_actions_runner_jit_config.test", tfjsonpath.New("runner_id"), knownvalue.NotNull()),
+						statecheck.ExpectKnownValue("github_actions_runner_jit_config.test", tfjsonpath.New("repository_id"), knownvalue.Int64Exact(0)),
+						statecheck.ExpectSensitiveValue("github_actions_runner_jit_config.test", tfjsonpath.New("encoded_jit_config")),
+					},
+				},
+			},
+		})
+	})
+}
+
+func TestGithubActionsRunnerJITConfig(t *testing.T) {
+	t.Parallel()
+
+	skipUnlessTerraform(t)
+
+	t.Run("registers_and_removes_repository_runner", func(t *testing.T) {
+		t.Parallel()
+
+		fake, providerConfig := newFakeGitHub(t, "test-org")
+		client := fake.Client(t)
+
+		if _, _, err := client.Repositories.Create(t.Context(), "test-org", &github.Repository{Name: new("test-repo")}); err != nil {
+			t.Fatalf("failed to create repository: %v", err)
+		}
+
+		config := providerConfig + `
+resource "github_actions_runner_jit_config" "test" {
+  repository = "test-repo"
+  name       = "runner"
+  labels     = ["self-hosted"]
+}
+`
+
+		expectRunners := func(want int) resource.TestCheckFunc {
+			return func(*terraform.State) error {
+				if runners := fake.Runners("test-org", "test-repo"); len(runners) != want {
+					return fmt.Errorf("expected %d runners, got %d", want, len(runners))
+				}
+				return nil
+			}
+		}
+
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: providerFactories,
+			CheckDestroy:      expectRunners(0),
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check:  expectRunners(1),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_actions_runner_jit_config.test", tfjsonpath.New("encoded_jit_config"), knownvalue.NotNull()),
+					},
+				},
+				{
+					PreConfig: func() {
+						runner := fake.Runners("test-org", "test-repo")[0]
+						if _, err := client.Actions.RemoveRunner(t.Context(), "test-org", "test-repo", runner.GetID()); err != nil {
+							t.Fatalf("failed to remove runner: %v", err)
+						}
+					},
+					Config: config,
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_actions_runner_jit_config.test", plancheck.ResourceActionCreate),
+						},
+					},
+					Check: expectRunners(1),
+				},
+			},
+		})
+	})
+}
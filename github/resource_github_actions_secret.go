@@ -70,21 +70,36 @@ func resourceGithubActionsSecret() *schema.Resource {
 				Optional:      true,
 				Computed:      true,
 				RequiredWith:  []string{"value_encrypted"},
-				ConflictsWith: []string{"value", "plaintext_value"},
+				ConflictsWith: []string{"value", "value_wo", "plaintext_value"},
 				Description:   "ID of the public key used to encrypt the secret.",
 			},
 			"value": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				ExactlyOneOf: []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				Description:  "Plaintext value to be encrypted.",
 			},
+			"value_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
+				RequiredWith: []string{"value_wo_version"},
+				Description:  "Write-only plaintext value to be encrypted, which is never stored in the state. Requires Terraform 1.11 or later.",
+			},
+			"value_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"value_wo"},
+				Description:  "Version of `value_wo`, which must be changed to update the secret with a new `value_wo`.",
+			},
 			"value_encrypted": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Sensitive:        true,
-				ExactlyOneOf:     []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf:     []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 				Description:      "Value encrypted with the GitHub public key, defined by key_id, in Base64 format.",
 			},
@@ -92,7 +107,7 @@ func resourceGithubActionsSecret() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Sensitive:        true,
-				ExactlyOneOf:     []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf:     []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 				Description:      "Encrypted value of the secret using the GitHub public key in Base64 format.",
 				Deprecated:       "Use value_encrypted and key_id.",
@@ -101,7 +116,7 @@ func resourceGithubActionsSecret() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				ExactlyOneOf: []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				Description:  "Plaintext value of the secret to be encrypted.",
 				Deprecated:   "Use value.",
 			},
@@ -120,10 +135,16 @@ func resourceGithubActionsSecret() *schema.Resource {
 				Computed:    true,
 				Description: "Timestamp for when the secret was last updated.",
 			},
+			"drift_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(secretDriftPolicies, false)),
+				Description:      "How to plan the secret when it has been updated outside of Terraform. Must be one of `overwrite_in_place`, `recreate`, `ignore` or `error`; defaults to `overwrite_in_place`.",
+			},
 			"destroy_on_drift": {
 				Type:       schema.TypeBool,
 				Optional:   true,
-				Deprecated: "This is no longer required and will be removed in a future release. Drift detection is now always performed, and external changes will result in the secret being updated to match the Terraform configuration. If you want to ignore external changes, you can use the `lifecycle` block with `ignore_changes` on the `updated_at` field.",
+				Deprecated: "This is no longer required and will be removed in a future release. Drift detection is now always performed, and external changes are handled as set by `drift_policy`.",
 			},
 		},
 	}
@@ -145,33 +166,23 @@ func resourceGithubActionsSecretCreate(ctx context.Context, d *schema.ResourceDa
 	}
 	repoID := int(repo.GetID())
 
-	var publicKey string
-	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getPublicKeyDetails(ctx, meta, repoName)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		keyID = ki
-		publicKey = pk
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue, _ = resourceKeysGetOk[string](d, "value", "plaintext_value")
 	}
 
-	if len(encryptedValue) == 0 {
-		plaintextValue, _ := resourceKeysGetOk[string](d, "value", "plaintext_value")
-
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return diag.FromErr(err)
+	keyID, err = putSecret(ctx, meta, keyID, encryptedValue, plaintextValue, func() (string, string, error) {
+		return getPublicKeyDetails(ctx, meta, repoName)
+	}, func(keyID, encryptedValue string) error {
+		secretReq := github.SecretRequest{
+			KeyID:          keyID,
+			EncryptedValue: encryptedValue,
 		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
-	}
-
-	secretReq := github.SecretRequest{
-		KeyID:          keyID,
-		EncryptedValue: encryptedValue,
-	}
 
-	if _, err = client.Actions.CreateOrUpdateRepoSecret(ctx, owner, repoName, secretName, secretReq); err != nil {
+		_, err := client.Actions.CreateOrUpdateRepoSecret(ctx, owner, repoName, secretName, secretReq)
+		return err
+	})
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -251,6 +262,10 @@ func resourceGithubActionsSecretRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceGithubActionsSecretUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	if onlySecretDriftPolicyChanged(d) {
+		return nil
+	}
+
 	meta, _ := m.(*Owner)
 	client := meta.v3client
 	owner := meta.name
@@ -260,33 +275,23 @@ func resourceGithubActionsSecretUpdate(ctx context.Context, d *schema.ResourceDa
 	keyID, _ := d.Get("key_id").(string)
 	encryptedValue, _ := resourceKeysGetOk[string](d, "value_encrypted", "encrypted_value")
 
-	var publicKey string
-	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getPublicKeyDetails(ctx, meta, repoName)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		keyID = ki
-		publicKey = pk
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue, _ = resourceKeysGetOk[string](d, "value", "plaintext_value")
 	}
 
-	if len(encryptedValue) == 0 {
-		plaintextValue, _ := resourceKeysGetOk[string](d, "value", "plaintext_value")
-
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return diag.FromErr(err)
+	keyID, err := putSecret(ctx, meta, keyID, encryptedValue, plaintextValue, func() (string, string, error) {
+		return getPublicKeyDetails(ctx, meta, repoName)
+	}, func(keyID, encryptedValue string) error {
+		secretReq := github.SecretRequest{
+			KeyID:          keyID,
+			EncryptedValue: encryptedValue,
 		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
-	}
-
-	secretReq := github.SecretRequest{
-		KeyID:          keyID,
-		EncryptedValue: encryptedValue,
-	}
 
-	if _, err := client.Actions.CreateOrUpdateRepoSecret(ctx, owner, repoName, secretName, secretReq); err != nil {
+		_, err := client.Actions.CreateOrUpdateRepoSecret(ctx, owner, repoName, secretName, secretReq)
+		return err
+	})
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -382,12 +387,10 @@ func getPublicKeyDetails(ctx context.Context, meta *Owner, repository string) (s
 	client := meta.v3client
 	owner := meta.name
 
-	publicKey, _, err := client.Actions.GetRepoPublicKey(ctx, owner, repository)
-	if err != nil {
-		return "", "", err
-	}
-
-	return publicKey.GetKeyID(), publicKey.GetKey(), err
+	return meta.publicKeys.get(ctx, fmt.Sprintf("repos/%s/%s/actions/secrets/public-key", owner, repository), func() (*github.PublicKey, error) {
+		publicKey, _, err := client.Actions.GetRepoPublicKey(ctx, owner, repository)
+		return publicKey, err
+	})
 }
 
 func encryptPlaintext(plaintext, publicKeyB64 string) ([]byte, error) {
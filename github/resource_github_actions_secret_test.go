@@ -3,6 +3,7 @@ package github
 import (
 	"encoding/base64"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -10,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccGithubActionsSecret(t *testing.T) {
@@ -61,6 +63,55 @@ resource "github_actions_secret" "test" {
 		})
 	})
 
+	t.Run("with_value_wo", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+
+		config := fmt.Sprintf(`
+resource "github_actions_secret" "test" {
+  repository       = "%s"
+  secret_name      = "TEST"
+  value_wo         = "%%s"
+  value_wo_version = %%d
+}
+`, repo.GetName())
+
+		resource.Test(t, resource.TestCase{
+			ProviderFactories: providerFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_11_0),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, "super_secret_value", 1),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_actions_secret.test", tfjsonpath.New("repository_id"), knownvalue.NotNull()),
+						statecheck.ExpectKnownValue("github_actions_secret.test", tfjsonpath.New("key_id"), knownvalue.NotNull()),
+						statecheck.ExpectKnownValue("github_actions_secret.test", tfjsonpath.New("value_wo"), knownvalue.Null()),
+						statecheck.ExpectKnownValue("github_actions_secret.test", tfjsonpath.New("created_at"), knownvalue.NotNull()),
+						statecheck.ExpectKnownValue("github_actions_secret.test", tfjsonpath.New("updated_at"), knownvalue.NotNull()),
+						statecheck.ExpectKnownValue("github_actions_secret.test", tfjsonpath.New("remote_updated_at"), knownvalue.NotNull()),
+					},
+				},
+				{
+					Config: fmt.Sprintf(config, "super_secret_value_2", 2),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_actions_secret.test", plancheck.ResourceActionUpdate),
+						},
+					},
+				},
+				{
+					ResourceName:            "github_actions_secret.test",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"key_id", "value_wo_version"},
+				},
+			},
+		})
+	})
+
 	t.Run("with_value_encrypted", func(t *testing.T) {
 		t.Parallel()
 
@@ -268,6 +319,110 @@ resource "github_actions_secret" "test" {
 		})
 	})
 
+	t.Run("recreates_on_drift_with_recreate_policy", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+		secretName := "TEST"
+
+		config := fmt.Sprintf(`
+resource "github_actions_secret" "test" {
+  repository   = "%s"
+  secret_name  = "%s"
+  value        = "super_secret_value"
+  drift_policy = "recreate"
+}
+`, repo.GetName(), secretName)
+
+		resource.Test(t, resource.TestCase{
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+				},
+				{
+					PreConfig: func() {
+						mustUpdateTestRepositorySecret(t, repo, secretName, "super_secret_value_2")
+					},
+					Config: config,
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_actions_secret.test", plancheck.ResourceActionReplace),
+						},
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("ignores_drift_with_ignore_policy", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+		secretName := "TEST"
+
+		config := fmt.Sprintf(`
+resource "github_actions_secret" "test" {
+  repository   = "%s"
+  secret_name  = "%s"
+  value        = "super_secret_value"
+  drift_policy = "ignore"
+}
+`, repo.GetName(), secretName)
+
+		resource.Test(t, resource.TestCase{
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+				},
+				{
+					PreConfig: func() {
+						mustUpdateTestRepositorySecret(t, repo, secretName, "super_secret_value_2")
+					},
+					Config: config,
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_actions_secret.test", plancheck.ResourceActionNoop),
+						},
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("errors_on_drift_with_error_policy", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+		secretName := "TEST"
+
+		config := fmt.Sprintf(`
+resource "github_actions_secret" "test" {
+  repository   = "%s"
+  secret_name  = "%s"
+  value        = "super_secret_value"
+  drift_policy = "error"
+}
+`, repo.GetName(), secretName)
+
+		resource.Test(t, resource.TestCase{
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+				},
+				{
+					PreConfig: func() {
+						mustUpdateTestRepositorySecret(t, repo, secretName, "super_secret_value_2")
+					},
+					Config:      config,
+					ExpectError: regexp.MustCompile(`rotated externally`),
+				},
+			},
+		})
+	})
+
 	t.Run("updates_renamed_repo", func(t *testing.T) {
 		t.Parallel()
 
@@ -5,10 +5,12 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/google/go-github/v89/github"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
@@ -152,3 +154,93 @@ resource "github_actions_variable" "test" {
 		})
 	})
 }
+
+func TestGithubActionsVariable(t *testing.T) {
+	t.Parallel()
+
+	skipUnlessTerraform(t)
+
+	t.Run("manages_variable_and_detects_drift", func(t *testing.T) {
+		t.Parallel()
+
+		fake, providerConfig := newFakeGitHub(t, "test-org")
+		client := fake.Client(t)
+
+		if _, _, err := client.Repositories.Create(t.Context(), "test-org", &github.Repository{Name: new("test-repo")}); err != nil {
+			t.Fatalf("failed to create repository: %v", err)
+		}
+
+		config := providerConfig + `
+resource "github_actions_variable" "test" {
+  repository    = "test-repo"
+  variable_name = "TEST"
+  value         = "%s"
+}
+`
+
+		expectValue := func(want string) resource.TestCheckFunc {
+			return func(*terraform.State) error {
+				variable, _, err := client.Actions.GetRepoVariable(t.Context(), "test-org", "test-repo", "TEST")
+				if err != nil {
+					return err
+				}
+				if variable.Value != want {
+					return fmt.Errorf("expected variable value %q, got %q", want, variable.Value)
+				}
+				return nil
+			}
+		}
+
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, "my-value"),
+					Check:  expectValue("my-value"),
+				},
+				{
+					Config: fmt.Sprintf(config, "my-value-2"),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_actions_variable.test", plancheck.ResourceActionUpdate),
+						},
+					},
+					Check: expectValue("my-value-2"),
+				},
+				{
+					PreConfig: func() {
+						if _, err := client.Actions.UpdateRepoVariable(t.Context(), "test-org", "test-repo", "TEST", github.ActionsVariableUpdateRequest{Value: new("changed")}); err != nil {
+							t.Fatalf("failed to change variable: %v", err)
+						}
+					},
+					Config: fmt.Sprintf(config, "my-value-2"),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_actions_variable.test", plancheck.ResourceActionUpdate),
+						},
+					},
+					Check: expectValue("my-value-2"),
+				},
+				{
+					PreConfig: func() {
+						if _, err := client.Actions.DeleteRepoVariable(t.Context(), "test-org", "test-repo", "TEST"); err != nil {
+							t.Fatalf("failed to delete variable: %v", err)
+						}
+					},
+					Config: fmt.Sprintf(config, "my-value-2"),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_actions_variable.test", plancheck.ResourceActionCreate),
+						},
+					},
+					Check: expectValue("my-value-2"),
+				},
+				{
+					ResourceName:      "github_actions_variable.test",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+}
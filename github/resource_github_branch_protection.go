@@ -136,6 +136,44 @@ func resourceGithubBranchProtection() *schema.Resource {
 							Description: "The list of status checks to require in order to merge into this branch. No status checks are required by default.",
 							Elem:        &schema.Schema{Type: schema.TypeString},
 						},
+						PROTECTION_REQUIRED_STATUS_CHECKS: {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The status checks to require in order to merge into this branch, optionally pinned to the GitHub App which must set them. Conflicts with 'contexts'.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									PROTECTION_REQUIRED_STATUS_CHECK_CONTEXT: {
+										Type:             schema.TypeString,
+										Required:         true,
+										Description:      "The name of the status check.",
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+									},
+									PROTECTION_REQUIRED_STATUS_CHECK_APP_ID: {
+										Type:             schema.TypeInt,
+										Optional:         true,
+										Description:      "The ID of the GitHub App which must set the status check, or '-1' to allow any app. Defaults to the app which most recently set the status check.",
+										ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(-1)),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			PROTECTION_REQUIRES_DEPLOYMENTS: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Require deployments to environments to succeed before merging.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						PROTECTION_REQUIRED_DEPLOYMENT_ENVIRONMENTS: {
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Description: "The environments which must be successfully deployed to before merging into this branch.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
 					},
 				},
 			},
@@ -231,6 +269,11 @@ func resourceGithubBranchProtectionCreate(d *schema.ResourceData, meta any) erro
 	data.BypassForcePushActorIDs = bypassForcePushIds
 	data.BypassPullRequestActorIDs = bypassPullRequestIds
 
+	statusChecks, err := githubv4RequiredStatusChecks(data, meta)
+	if err != nil {
+		return err
+	}
+
 	input := githubv4.CreateBranchProtectionRuleInput{
 		AllowsDeletions:                new(githubv4.Boolean(data.AllowsDeletions)),
 		AllowsForcePushes:              new(githubv4.Boolean(data.AllowsForcePushes)),
@@ -243,11 +286,14 @@ func resourceGithubBranchProtectionCreate(d *schema.ResourceData, meta any) erro
 		PushActorIDs:                   new(githubv4IDSlice(data.PushActorIDs)),
 		RepositoryID:                   new(githubv4.ID(data.RepositoryID)),
 		RequiredApprovingReviewCount:   new(githubv4.Int(data.RequiredApprovingReviewCount)),
-		RequiredStatusCheckContexts:    new(githubv4StringSliceEmpty(data.RequiredStatusCheckContexts)),
+		RequiredStatusCheckContexts:    githubv4RequiredStatusCheckContexts(data),
+		RequiredStatusChecks:           statusChecks,
+		RequiredDeploymentEnvironments: new(githubv4StringSliceEmpty(data.RequiredDeploymentEnvironments)),
 		RequiresApprovingReviews:       new(githubv4.Boolean(data.RequiresApprovingReviews)),
 		RequiresCodeOwnerReviews:       new(githubv4.Boolean(data.RequiresCodeOwnerReviews)),
 		RequiresCommitSignatures:       new(githubv4.Boolean(data.RequiresCommitSignatures)),
 		RequiresConversationResolution: new(githubv4.Boolean(data.RequiresConversationResolution)),
+		RequiresDeployments:            new(githubv4.Boolean(data.RequiresDeployments)),
 		RequiresLinearHistory:          new(githubv4.Boolean(data.RequiresLinearHistory)),
 		RequiresStatusChecks:           new(githubv4.Boolean(data.RequiresStatusChecks)),
 		RequiresStrictStatusChecks:     new(githubv4.Boolean(data.RequiresStrictStatusChecks)),
@@ -339,7 +385,11 @@ func resourceGithubBranchProtectionRead(d *schema.ResourceData, meta any) error
 		log.Printf("[DEBUG] Problem setting '%s' in %s %s branch protection (%s)", PROTECTION_REQUIRES_APPROVING_REVIEWS, protection.Repository.Name, protection.Pattern, d.Id())
 	}
 
-	statusChecks := setStatusChecks(protection)
+	data.RequiredStatusChecks = expandBranchProtectionStatusChecks(d.Get(PROTECTION_REQUIRES_STATUS_CHECKS))
+	if v, ok := d.Get(PROTECTION_REQUIRES_STATUS_CHECKS + ".0." + PROTECTION_REQUIRED_STATUS_CHECK_CONTEXTS).(*schema.Set); ok {
+		data.RequiredStatusCheckContexts = expandStringList(v.List())
+	}
+	statusChecks := setStatusChecks(protection, data)
 	err = d.Set(PROTECTION_REQUIRES_STATUS_CHECKS, statusChecks)
 	if err != nil {
 		log.Printf("[DEBUG] Problem setting '%s' in %s %s branch protection (%s)", PROTECTION_REQUIRES_STATUS_CHECKS, protection.Repository.Name, protection.Pattern, d.Id())
@@ -357,6 +407,12 @@ func resourceGithubBranchProtectionRead(d *schema.ResourceData, meta any) error
 		log.Printf("[DEBUG] Problem setting '%s' in %s %s branch protection (%s)", PROTECTION_FORCE_PUSHES_BYPASSERS, protection.Repository.Name, protection.Pattern, d.Id())
 	}
 
+	deployments := setDeployments(protection)
+	err = d.Set(PROTECTION_REQUIRES_DEPLOYMENTS, deployments)
+	if err != nil {
+		log.Printf("[DEBUG] Problem setting '%s' in %s %s branch protection (%s)", PROTECTION_REQUIRES_DEPLOYMENTS, protection.Repository.Name, protection.Pattern, d.Id())
+	}
+
 	err = d.Set(PROTECTION_LOCK_BRANCH, protection.LockBranch)
 	if err != nil {
 		log.Printf("[DEBUG] Problem setting '%s' in %s %s branch protection (%s)", PROTECTION_LOCK_BRANCH, protection.Repository.Name, protection.Pattern, d.Id())
@@ -404,6 +460,11 @@ func resourceGithubBranchProtectionUpdate(d *schema.ResourceData, meta any) erro
 	data.BypassForcePushActorIDs = bypassForcePushIds
 	data.BypassPullRequestActorIDs = bypassPullRequestIds
 
+	statusChecks, err := githubv4RequiredStatusChecks(data, meta)
+	if err != nil {
+		return err
+	}
+
 	input := githubv4.UpdateBranchProtectionRuleInput{
 		BranchProtectionRuleID:         d.Id(),
 		AllowsDeletions:                new(githubv4.Boolean(data.AllowsDeletions)),
@@ -416,11 +477,14 @@ func resourceGithubBranchProtectionUpdate(d *schema.ResourceData, meta any) erro
 		Pattern:                        new(githubv4.String(data.Pattern)),
 		PushActorIDs:                   new(githubv4IDSlice(data.PushActorIDs)),
 		RequiredApprovingReviewCount:   new(githubv4.Int(data.RequiredApprovingReviewCount)),
-		RequiredStatusCheckContexts:    new(githubv4StringSliceEmpty(data.RequiredStatusCheckContexts)),
+		RequiredStatusCheckContexts:    githubv4RequiredStatusCheckContexts(data),
+		RequiredStatusChecks:           statusChecks,
+		RequiredDeploymentEnvironments: new(githubv4StringSliceEmpty(data.RequiredDeploymentEnvironments)),
 		RequiresApprovingReviews:       new(githubv4.Boolean(data.RequiresApprovingReviews)),
 		RequiresCodeOwnerReviews:       new(githubv4.Boolean(data.RequiresCodeOwnerReviews)),
 		RequiresCommitSignatures:       new(githubv4.Boolean(data.RequiresCommitSignatures)),
 		RequiresConversationResolution: new(githubv4.Boolean(data.RequiresConversationResolution)),
+		RequiresDeployments:            new(githubv4.Boolean(data.RequiresDeployments)),
 		RequiresLinearHistory:          new(githubv4.Boolean(data.RequiresLinearHistory)),
 		RequiresStatusChecks:           new(githubv4.Boolean(data.RequiresStatusChecks)),
 		RequiresStrictStatusChecks:     new(githubv4.Boolean(data.RequiresStrictStatusChecks)),
@@ -228,6 +228,93 @@ func TestAccGithubBranchProtectionV4(t *testing.T) {
 		})
 	})
 
+	t.Run("configures required status checks with apps and required deployments", func(t *testing.T) {
+		t.Parallel()
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		testRepoName := fmt.Sprintf("%sbranch-protection-%s", testResourcePrefix, randomID)
+		config := fmt.Sprintf(`
+
+			resource "github_repository" "test" {
+			  name      = "%s"
+			  auto_init = true
+			}
+
+			resource "github_repository_environment" "test" {
+			  repository  = github_repository.test.name
+			  environment = "staging"
+			}
+
+			resource "github_branch_protection" "test" {
+
+			  repository_id = github_repository.test.node_id
+			  pattern       = "main"
+
+			  required_status_checks {
+			    strict = true
+
+			    checks {
+			      context = "github/foo"
+			      app_id  = -1
+			    }
+
+			    checks {
+			      context = "github/bar"
+			      app_id  = -1
+			    }
+			  }
+
+			  required_deployments {
+			    environments = [github_repository_environment.test.environment]
+			  }
+
+			}
+
+	`, testRepoName)
+
+		check := resource.ComposeAggregateTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_branch_protection.test", "required_status_checks.0.checks.#", "2",
+			),
+			resource.TestCheckTypeSetElemNestedAttrs(
+				"github_branch_protection.test", "required_status_checks.0.checks.*", map[string]string{
+					"context": "github/foo",
+					"app_id":  "-1",
+				},
+			),
+			resource.TestCheckTypeSetElemNestedAttrs(
+				"github_branch_protection.test", "required_status_checks.0.checks.*", map[string]string{
+					"context": "github/bar",
+					"app_id":  "-1",
+				},
+			),
+			resource.TestCheckResourceAttr(
+				"github_branch_protection.test", "required_deployments.0.environments.#", "1",
+			),
+			resource.TestCheckTypeSetElemAttr(
+				"github_branch_protection.test", "required_deployments.0.environments.*", "staging",
+			),
+		)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check:  check,
+				},
+				{
+					ResourceName:      "github_branch_protection.test",
+					ImportState:       true,
+					ImportStateVerify: true,
+					ImportStateIdFunc: importBranchProtectionByRepoID(
+						"github_repository.test", "main"),
+				},
+			},
+		})
+	})
+
 	t.Run("configures required pull request reviews", func(t *testing.T) {
 		t.Parallel()
 
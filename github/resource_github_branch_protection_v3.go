@@ -193,6 +193,23 @@ func resourceGithubBranchProtectionV3() *schema.Resource {
 					},
 				},
 			},
+			"required_deployments": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Require deployments to environments to succeed before merging.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"environments": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Description: "The environments which must be successfully deployed to before merging into this branch.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 			"enforce_admins": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -257,6 +274,10 @@ func resourceGithubBranchProtectionV3Create(d *schema.ResourceData, meta any) er
 		return err
 	}
 
+	if err = requiredDeploymentsUpdate(d, meta); err != nil {
+		return err
+	}
+
 	return resourceGithubBranchProtectionV3Read(d, meta)
 }
 
@@ -335,6 +356,10 @@ func resourceGithubBranchProtectionV3Read(d *schema.ResourceData, meta any) erro
 		return fmt.Errorf("error setting signed commit restriction: %w", err)
 	}
 
+	if err := requiredDeploymentsRead(d, meta); err != nil {
+		return fmt.Errorf("error setting required_deployments: %w", err)
+	}
+
 	return nil
 }
 
@@ -389,6 +414,10 @@ func resourceGithubBranchProtectionV3Update(d *schema.ResourceData, meta any) er
 		return err
 	}
 
+	if err = requiredDeploymentsUpdate(d, meta); err != nil {
+		return err
+	}
+
 	return resourceGithubBranchProtectionV3Read(d, meta)
 }
 
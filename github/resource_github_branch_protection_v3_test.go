@@ -525,6 +525,54 @@ func TestAccGithubBranchProtectionV3(t *testing.T) {
 		})
 	})
 
+	t.Run("configures required deployments", func(t *testing.T) {
+		t.Parallel()
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		testRepoName := fmt.Sprintf("%sbranch-protection-%s", testResourcePrefix, randomID)
+		config := fmt.Sprintf(`
+		resource "github_repository" "test" {
+			name      = "%s"
+			auto_init = true
+		}
+
+		resource "github_repository_environment" "test" {
+			repository  = github_repository.test.name
+			environment = "staging"
+		}
+
+		resource "github_branch_protection_v3" "test" {
+			repository  = github_repository.test.name
+			branch      = "main"
+
+			required_deployments {
+				environments = [github_repository_environment.test.environment]
+			}
+		}
+		`, testRepoName)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnlessHasOrgs(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("github_branch_protection_v3.test", "required_deployments.#", "1"),
+						resource.TestCheckResourceAttr("github_branch_protection_v3.test", "required_deployments.0.environments.#", "1"),
+						resource.TestCheckTypeSetElemAttr("github_branch_protection_v3.test", "required_deployments.0.environments.*", "staging"),
+					),
+				},
+				{
+					ResourceName:            "github_branch_protection_v3.test",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"etag"},
+				},
+			},
+		})
+	})
+
 	t.Run("configures required pull request reviews", func(t *testing.T) {
 		t.Parallel()
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/go-github/v89/github"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
 )
 
 func buildProtectionRequest(d *schema.ResourceData) (*github.ProtectionRequest, error) {
@@ -126,6 +127,116 @@ func requireSignedCommitsUpdate(d *schema.ResourceData, meta any) (err error) {
 	return err
 }
 
+// requiredDeploymentsRead reads the required deployments from the GraphQL branch protection rule of the branch, which the REST API doesn't expose.
+func requiredDeploymentsRead(d *schema.ResourceData, meta any) error {
+	repoName, branch, err := parseID2(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var query struct {
+		Repository struct {
+			BranchProtectionRules struct {
+				Nodes []struct {
+					Pattern                        githubv4.String
+					RequiresDeployments            githubv4.Boolean
+					RequiredDeploymentEnvironments []githubv4.String
+				}
+				PageInfo PageInfo
+			} `graphql:"branchProtectionRules(first: $first, after: $cursor)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	variables := map[string]any{
+		"owner":  githubv4.String(meta.(*Owner).name),
+		"name":   githubv4.String(repoName),
+		"first":  githubv4.Int(meta.(*Owner).maxPerPage),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	client := meta.(*Owner).v4client
+	for {
+		if err := client.Query(ctx, &query, variables); err != nil {
+			return err
+		}
+
+		for _, rule := range query.Repository.BranchProtectionRules.Nodes {
+			if string(rule.Pattern) != branch {
+				continue
+			}
+
+			if !rule.RequiresDeployments {
+				return d.Set("required_deployments", []any{})
+			}
+
+			environments := make([]any, 0, len(rule.RequiredDeploymentEnvironments))
+			for _, e := range rule.RequiredDeploymentEnvironments {
+				environments = append(environments, string(e))
+			}
+
+			return d.Set("required_deployments", []any{
+				map[string]any{
+					"environments": schema.NewSet(schema.HashString, environments),
+				},
+			})
+		}
+
+		if !query.Repository.BranchProtectionRules.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = new(query.Repository.BranchProtectionRules.PageInfo.EndCursor)
+	}
+
+	return d.Set("required_deployments", []any{})
+}
+
+// requiredDeploymentsUpdate sets the required deployments on the GraphQL branch protection rule of the branch, as the REST API doesn't support them.
+func requiredDeploymentsUpdate(d *schema.ResourceData, meta any) error {
+	environments := make([]string, 0)
+	if v, _ := d.Get("required_deployments").([]any); len(v) > 0 && v[0] != nil {
+		environments = expandNestedSet(v[0].(map[string]any), "environments")
+	}
+
+	if len(environments) == 0 && !d.HasChange("required_deployments") {
+		return nil
+	}
+
+	ruleID, err := branchProtectionV3RuleID(d, meta)
+	if err != nil {
+		return err
+	}
+
+	var mutate struct {
+		UpdateBranchProtectionRule struct {
+			ClientMutationId githubv4.ID
+		} `graphql:"updateBranchProtectionRule(input: $input)"`
+	}
+	input := githubv4.UpdateBranchProtectionRuleInput{
+		BranchProtectionRuleID:         ruleID,
+		RequiresDeployments:            new(githubv4.Boolean(len(environments) > 0)),
+		RequiredDeploymentEnvironments: new(githubv4StringSliceEmpty(environments)),
+	}
+
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	client := meta.(*Owner).v4client
+	return client.Mutate(ctx, &mutate, input, nil)
+}
+
+// branchProtectionV3RuleID returns the node ID of the GraphQL branch protection rule backing the protection of the branch.
+func branchProtectionV3RuleID(d *schema.ResourceData, meta any) (githubv4.ID, error) {
+	repoName, branch, err := parseID2(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	repoID, err := getRepositoryID(repoName, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return getBranchProtectionID(repoID, branch, meta)
+}
+
 func flattenBypassPullRequestAllowances(bpra *github.BypassPullRequestAllowances) []any {
 	if bpra == nil {
 		return nil
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
@@ -40,7 +39,7 @@ func resourceGithubCodespacesOrganizationSecret() *schema.Resource {
 				ForceNew:         true,
 				Optional:         true,
 				Sensitive:        true,
-				ConflictsWith:    []string{"plaintext_value"},
+				ConflictsWith:    []string{"plaintext_value", "value_wo"},
 				Description:      "Encrypted value of the secret using the GitHub public key in Base64 format.",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 			},
@@ -50,7 +49,23 @@ func resourceGithubCodespacesOrganizationSecret() *schema.Resource {
 				Optional:      true,
 				Sensitive:     true,
 				Description:   "Plaintext value of the secret to be encrypted.",
-				ConflictsWith: []string{"encrypted_value"},
+				ConflictsWith: []string{"encrypted_value", "value_wo"},
+			},
+			"value_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"encrypted_value", "plaintext_value"},
+				RequiredWith:  []string{"value_wo_version"},
+				Description:   "Write-only plaintext value of the secret to be encrypted, which is never stored in the state. Requires Terraform 1.11 or later.",
+			},
+			"value_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"value_wo"},
+				Description:  "Version of `value_wo`, which must be changed to recreate the secret with a new `value_wo`.",
 			},
 			"visibility": {
 				Type:             schema.TypeString,
@@ -89,7 +104,10 @@ func resourceGithubCodespacesOrganizationSecretCreateOrUpdate(d *schema.Resource
 	ctx := context.Background()
 
 	secretName := d.Get("secret_name").(string)
-	plaintextValue := d.Get("plaintext_value").(string)
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue = d.Get("plaintext_value").(string)
+	}
 	var encryptedValue string
 
 	visibility := d.Get("visibility").(string)
@@ -109,31 +127,25 @@ func resourceGithubCodespacesOrganizationSecretCreateOrUpdate(d *schema.Resource
 		}
 	}
 
-	keyId, publicKey, err := getCodespacesOrganizationPublicKeyDetails(owner, meta)
-	if err != nil {
-		return err
-	}
-
 	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
 		encryptedValue = encryptedText.(string)
-	} else {
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return err
-		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
 	}
 
-	// Create an EncryptedSecret and encrypt the plaintext value into it
-	eSecret := &github.EncryptedSecret{
-		Name:                  secretName,
-		KeyID:                 keyId,
-		Visibility:            visibility,
-		SelectedRepositoryIDs: selectedRepositoryIDs,
-		EncryptedValue:        encryptedValue,
-	}
+	_, err := putSecret(ctx, meta.(*Owner), "", encryptedValue, plaintextValue, func() (string, string, error) {
+		return getCodespacesOrganizationPublicKeyDetails(owner, meta)
+	}, func(keyID, encryptedValue string) error {
+		// Create an EncryptedSecret and encrypt the plaintext value into it
+		eSecret := &github.EncryptedSecret{
+			Name:                  secretName,
+			KeyID:                 keyID,
+			Visibility:            visibility,
+			SelectedRepositoryIDs: selectedRepositoryIDs,
+			EncryptedValue:        encryptedValue,
+		}
 
-	_, err = client.Codespaces.CreateOrUpdateOrgSecret(ctx, owner, eSecret)
+		_, err := client.Codespaces.CreateOrUpdateOrgSecret(ctx, owner, eSecret)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -242,10 +254,8 @@ func getCodespacesOrganizationPublicKeyDetails(owner string, meta any) (keyId, p
 	client := meta.(*Owner).v3client
 	ctx := context.Background()
 
-	publicKey, _, err := client.Codespaces.GetOrgPublicKey(ctx, owner)
-	if err != nil {
-		return keyId, pkValue, err
-	}
-
-	return publicKey.GetKeyID(), publicKey.GetKey(), err
+	return meta.(*Owner).publicKeys.get(ctx, fmt.Sprintf("orgs/%s/codespaces/secrets/public-key", owner), func() (*github.PublicKey, error) {
+		publicKey, _, err := client.Codespaces.GetOrgPublicKey(ctx, owner)
+		return publicKey, err
+	})
 }
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
@@ -41,7 +40,7 @@ func resourceGithubCodespacesSecret() *schema.Resource {
 				ForceNew:      true,
 				Optional:      true,
 				Sensitive:     true,
-				ConflictsWith: []string{"plaintext_value"},
+				ConflictsWith: []string{"plaintext_value", "value_wo"},
 				Description:   "Encrypted value of the secret using the GitHub public key in Base64 format.",
 			},
 			"plaintext_value": {
@@ -49,9 +48,25 @@ func resourceGithubCodespacesSecret() *schema.Resource {
 				ForceNew:      true,
 				Optional:      true,
 				Sensitive:     true,
-				ConflictsWith: []string{"encrypted_value"},
+				ConflictsWith: []string{"encrypted_value", "value_wo"},
 				Description:   "Plaintext value of the secret to be encrypted.",
 			},
+			"value_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"encrypted_value", "plaintext_value"},
+				RequiredWith:  []string{"value_wo_version"},
+				Description:   "Write-only plaintext value of the secret to be encrypted, which is never stored in the state. Requires Terraform 1.11 or later.",
+			},
+			"value_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"value_wo"},
+				Description:  "Version of `value_wo`, which must be changed to recreate the secret with a new `value_wo`.",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -73,32 +88,29 @@ func resourceGithubCodespacesSecretCreateOrUpdate(d *schema.ResourceData, meta a
 
 	repo := d.Get("repository").(string)
 	secretName := d.Get("secret_name").(string)
-	plaintextValue := d.Get("plaintext_value").(string)
-	var encryptedValue string
-
-	keyId, publicKey, err := getCodespacesPublicKeyDetails(owner, repo, meta)
-	if err != nil {
-		return err
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue = d.Get("plaintext_value").(string)
 	}
+	var encryptedValue string
 
 	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
 		encryptedValue = encryptedText.(string)
-	} else {
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return err
-		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
 	}
 
-	// Create an EncryptedSecret and encrypt the plaintext value into it
-	eSecret := &github.EncryptedSecret{
-		Name:           secretName,
-		KeyID:          keyId,
-		EncryptedValue: encryptedValue,
-	}
+	_, err := putSecret(ctx, meta.(*Owner), "", encryptedValue, plaintextValue, func() (string, string, error) {
+		return getCodespacesPublicKeyDetails(owner, repo, meta)
+	}, func(keyID, encryptedValue string) error {
+		// Create an EncryptedSecret and encrypt the plaintext value into it
+		eSecret := &github.EncryptedSecret{
+			Name:           secretName,
+			KeyID:          keyID,
+			EncryptedValue: encryptedValue,
+		}
 
-	_, err = client.Codespaces.CreateOrUpdateRepoSecret(ctx, owner, repo, eSecret)
+		_, err := client.Codespaces.CreateOrUpdateRepoSecret(ctx, owner, repo, eSecret)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -229,10 +241,8 @@ func getCodespacesPublicKeyDetails(owner, repository string, meta any) (keyId, p
 	client := meta.(*Owner).v3client
 	ctx := context.Background()
 
-	publicKey, _, err := client.Codespaces.GetRepoPublicKey(ctx, owner, repository)
-	if err != nil {
-		return keyId, pkValue, err
-	}
-
-	return publicKey.GetKeyID(), publicKey.GetKey(), err
+	return meta.(*Owner).publicKeys.get(ctx, fmt.Sprintf("repos/%s/%s/codespaces/secrets/public-key", owner, repository), func() (*github.PublicKey, error) {
+		publicKey, _, err := client.Codespaces.GetRepoPublicKey(ctx, owner, repository)
+		return publicKey, err
+	})
 }
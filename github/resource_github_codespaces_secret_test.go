@@ -9,6 +9,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccGithubCodespacesSecret(t *testing.T) {
@@ -131,6 +133,53 @@ func TestAccGithubCodespacesSecret(t *testing.T) {
 		})
 	})
 
+	t.Run("creates and recreates write-only secrets without error", func(t *testing.T) {
+		t.Parallel()
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		repoName := fmt.Sprintf("%srepo-codespaces-%s", testResourcePrefix, randomID)
+
+		config := `
+			resource "github_repository" "test" {
+			  name = "%s"
+			}
+
+			resource "github_codespaces_secret" "write_only_secret" {
+			  repository       = github_repository.test.name
+			  secret_name      = "test_write_only_secret"
+			  value_wo         = "%s"
+			  value_wo_version = %d
+			}
+			`
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_11_0),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, repoName, "super_secret_value", 1),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckNoResourceAttr("github_codespaces_secret.write_only_secret", "value_wo"),
+						resource.TestCheckResourceAttr("github_codespaces_secret.write_only_secret", "value_wo_version", "1"),
+						resource.TestCheckResourceAttrSet("github_codespaces_secret.write_only_secret", "updated_at"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(config, repoName, "updated_super_secret_value", 2),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_codespaces_secret.write_only_secret", plancheck.ResourceActionReplace),
+						},
+					},
+					Check: resource.TestCheckResourceAttr("github_codespaces_secret.write_only_secret", "value_wo_version", "2"),
+				},
+			},
+		})
+	})
+
 	t.Run("creates and updates repository name without error", func(t *testing.T) {
 		t.Parallel()
 
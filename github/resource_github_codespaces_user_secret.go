@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"log"
 	"net/http"
@@ -39,7 +38,7 @@ func resourceGithubCodespacesUserSecret() *schema.Resource {
 				ForceNew:         true,
 				Optional:         true,
 				Sensitive:        true,
-				ConflictsWith:    []string{"plaintext_value"},
+				ConflictsWith:    []string{"plaintext_value", "value_wo"},
 				Description:      "Encrypted value of the secret using the GitHub public key in Base64 format.",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 			},
@@ -49,7 +48,23 @@ func resourceGithubCodespacesUserSecret() *schema.Resource {
 				Optional:      true,
 				Sensitive:     true,
 				Description:   "Plaintext value of the secret to be encrypted.",
-				ConflictsWith: []string{"encrypted_value"},
+				ConflictsWith: []string{"encrypted_value", "value_wo"},
+			},
+			"value_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"encrypted_value", "plaintext_value"},
+				RequiredWith:  []string{"value_wo_version"},
+				Description:   "Write-only plaintext value of the secret to be encrypted, which is never stored in the state. Requires Terraform 1.11 or later.",
+			},
+			"value_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"value_wo"},
+				Description:  "Version of `value_wo`, which must be changed to recreate the secret with a new `value_wo`.",
 			},
 			"selected_repository_ids": {
 				Type: schema.TypeSet,
@@ -80,7 +95,10 @@ func resourceGithubCodespacesUserSecretCreateOrUpdate(d *schema.ResourceData, me
 	ctx := context.Background()
 
 	secretName := d.Get("secret_name").(string)
-	plaintextValue := d.Get("plaintext_value").(string)
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue = d.Get("plaintext_value").(string)
+	}
 	var encryptedValue string
 
 	selectedRepositories, hasSelectedRepositories := d.GetOk("selected_repository_ids")
@@ -95,30 +113,24 @@ func resourceGithubCodespacesUserSecretCreateOrUpdate(d *schema.ResourceData, me
 		}
 	}
 
-	keyId, publicKey, err := getCodespacesUserPublicKeyDetails(meta)
-	if err != nil {
-		return err
-	}
-
 	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
 		encryptedValue = encryptedText.(string)
-	} else {
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return err
-		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
 	}
 
-	// Create an EncryptedSecret and encrypt the plaintext value into it
-	eSecret := &github.EncryptedSecret{
-		Name:                  secretName,
-		KeyID:                 keyId,
-		SelectedRepositoryIDs: selectedRepositoryIDs,
-		EncryptedValue:        encryptedValue,
-	}
+	_, err := putSecret(ctx, meta.(*Owner), "", encryptedValue, plaintextValue, func() (string, string, error) {
+		return getCodespacesUserPublicKeyDetails(meta)
+	}, func(keyID, encryptedValue string) error {
+		// Create an EncryptedSecret and encrypt the plaintext value into it
+		eSecret := &github.EncryptedSecret{
+			Name:                  secretName,
+			KeyID:                 keyID,
+			SelectedRepositoryIDs: selectedRepositoryIDs,
+			EncryptedValue:        encryptedValue,
+		}
 
-	_, err = client.Codespaces.CreateOrUpdateUserSecret(ctx, eSecret)
+		_, err := client.Codespaces.CreateOrUpdateUserSecret(ctx, eSecret)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -220,10 +232,9 @@ func getCodespacesUserPublicKeyDetails(meta any) (keyId, pkValue string, err err
 	client := meta.(*Owner).v3client
 	ctx := context.Background()
 
-	publicKey, _, err := client.Codespaces.GetUserPublicKey(ctx)
-	if err != nil {
-		return keyId, pkValue, err
-	}
-
-	return publicKey.GetKeyID(), publicKey.GetKey(), err
+	// The key belongs to the authenticated user, so it's cached per owner, whose clients may be authenticated as different users, rather than in the keys shared with derived owners.
+	return meta.(*Owner).userPublicKeys.get(ctx, "user/codespaces/secrets/public-key", func() (*github.PublicKey, error) {
+		publicKey, _, err := client.Codespaces.GetUserPublicKey(ctx)
+		return publicKey, err
+	})
 }
@@ -2,8 +2,8 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/google/go-github/v89/github"
@@ -44,21 +44,36 @@ func resourceGithubDependabotOrganizationSecret() *schema.Resource {
 				Optional:      true,
 				Computed:      true,
 				RequiredWith:  []string{"value_encrypted"},
-				ConflictsWith: []string{"value", "plaintext_value"},
+				ConflictsWith: []string{"value", "value_wo", "plaintext_value"},
 				Description:   "ID of the public key used to encrypt the secret.",
 			},
 			"value": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				ExactlyOneOf: []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				Description:  "Plaintext value to be encrypted.",
 			},
+			"value_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
+				RequiredWith: []string{"value_wo_version"},
+				Description:  "Write-only plaintext value to be encrypted, which is never stored in the state. Requires Terraform 1.11 or later.",
+			},
+			"value_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"value_wo"},
+				Description:  "Version of `value_wo`, which must be changed to update the secret with a new `value_wo`.",
+			},
 			"value_encrypted": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Sensitive:        true,
-				ExactlyOneOf:     []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf:     []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 				Description:      "Value encrypted with the GitHub public key, defined by key_id, in Base64 format.",
 			},
@@ -66,7 +81,7 @@ func resourceGithubDependabotOrganizationSecret() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Sensitive:        true,
-				ExactlyOneOf:     []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf:     []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 				Description:      "Encrypted value of the secret using the GitHub public key in Base64 format.",
 				Deprecated:       "Use value_encrypted and key_id.",
@@ -75,7 +90,7 @@ func resourceGithubDependabotOrganizationSecret() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				ExactlyOneOf: []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				Description:  "Plaintext value of the secret to be encrypted.",
 				Deprecated:   "Use value.",
 			},
@@ -110,6 +125,12 @@ func resourceGithubDependabotOrganizationSecret() *schema.Resource {
 				Computed:    true,
 				Description: "Timestamp for when the secret was last updated.",
 			},
+			"drift_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(secretDriftPolicies, false)),
+				Description:      "How to plan the secret when it has been updated outside of Terraform. Must be one of `overwrite_in_place`, `recreate`, `ignore` or `error`; defaults to `overwrite_in_place`.",
+			},
 		},
 	}
 }
@@ -133,36 +154,25 @@ func resourceGithubDependabotOrganizationSecretCreate(ctx context.Context, d *sc
 		}
 	}
 
-	var publicKey string
-	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getDependabotOrganizationPublicKeyDetails(ctx, meta)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		keyID = ki
-		publicKey = pk
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue, _ = resourceKeysGetOk[string](d, "value", "plaintext_value")
 	}
 
-	if len(encryptedValue) == 0 {
-		plaintextValue, _ := resourceKeysGetOk[string](d, "value", "plaintext_value")
-
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return diag.FromErr(err)
+	keyID, err := putSecret(ctx, meta, keyID, encryptedValue, plaintextValue, func() (string, string, error) {
+		return getDependabotOrganizationPublicKeyDetails(ctx, meta)
+	}, func(keyID, encryptedValue string) error {
+		secret := github.DependabotEncryptedSecret{
+			Name:                  secretName,
+			KeyID:                 keyID,
+			EncryptedValue:        encryptedValue,
+			Visibility:            visibility,
+			SelectedRepositoryIDs: repoIDs,
 		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
-	}
 
-	secret := github.DependabotEncryptedSecret{
-		Name:                  secretName,
-		KeyID:                 keyID,
-		EncryptedValue:        encryptedValue,
-		Visibility:            visibility,
-		SelectedRepositoryIDs: repoIDs,
-	}
-
-	_, err := client.Dependabot.CreateOrUpdateOrgSecret(ctx, owner, &secret)
+		_, err := client.Dependabot.CreateOrUpdateOrgSecret(ctx, owner, &secret)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -264,6 +274,10 @@ func resourceGithubDependabotOrganizationSecretRead(ctx context.Context, d *sche
 }
 
 func resourceGithubDependabotOrganizationSecretUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	if onlySecretDriftPolicyChanged(d) {
+		return nil
+	}
+
 	meta, _ := m.(*Owner)
 	client := meta.v3client
 	owner := meta.name
@@ -282,36 +296,25 @@ func resourceGithubDependabotOrganizationSecretUpdate(ctx context.Context, d *sc
 		}
 	}
 
-	var publicKey string
-	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getDependabotOrganizationPublicKeyDetails(ctx, meta)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		keyID = ki
-		publicKey = pk
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue, _ = resourceKeysGetOk[string](d, "value", "plaintext_value")
 	}
 
-	if len(encryptedValue) == 0 {
-		plaintextValue, _ := resourceKeysGetOk[string](d, "value", "plaintext_value")
-
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return diag.FromErr(err)
+	keyID, err := putSecret(ctx, meta, keyID, encryptedValue, plaintextValue, func() (string, string, error) {
+		return getDependabotOrganizationPublicKeyDetails(ctx, meta)
+	}, func(keyID, encryptedValue string) error {
+		secret := github.DependabotEncryptedSecret{
+			Name:                  secretName,
+			KeyID:                 keyID,
+			EncryptedValue:        encryptedValue,
+			Visibility:            visibility,
+			SelectedRepositoryIDs: repoIDs,
 		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
-	}
-
-	secret := github.DependabotEncryptedSecret{
-		Name:                  secretName,
-		KeyID:                 keyID,
-		EncryptedValue:        encryptedValue,
-		Visibility:            visibility,
-		SelectedRepositoryIDs: repoIDs,
-	}
 
-	_, err := client.Dependabot.CreateOrUpdateOrgSecret(ctx, owner, &secret)
+		_, err := client.Dependabot.CreateOrUpdateOrgSecret(ctx, owner, &secret)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -416,10 +419,8 @@ func getDependabotOrganizationPublicKeyDetails(ctx context.Context, meta *Owner)
 	client := meta.v3client
 	owner := meta.name
 
-	publicKey, _, err := client.Dependabot.GetOrgPublicKey(ctx, owner)
-	if err != nil {
-		return "", "", err
-	}
-
-	return publicKey.GetKeyID(), publicKey.GetKey(), err
+	return meta.publicKeys.get(ctx, fmt.Sprintf("orgs/%s/dependabot/secrets/public-key", owner), func() (*github.PublicKey, error) {
+		publicKey, _, err := client.Dependabot.GetOrgPublicKey(ctx, owner)
+		return publicKey, err
+	})
 }
@@ -2,8 +2,8 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/google/go-github/v89/github"
@@ -63,21 +63,36 @@ func resourceGithubDependabotSecret() *schema.Resource {
 				Optional:      true,
 				Computed:      true,
 				RequiredWith:  []string{"value_encrypted"},
-				ConflictsWith: []string{"value", "plaintext_value"},
+				ConflictsWith: []string{"value", "value_wo", "plaintext_value"},
 				Description:   "ID of the public key used to encrypt the secret.",
 			},
 			"value": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				ExactlyOneOf: []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				Description:  "Plaintext value to be encrypted.",
 			},
+			"value_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
+				RequiredWith: []string{"value_wo_version"},
+				Description:  "Write-only plaintext value to be encrypted, which is never stored in the state. Requires Terraform 1.11 or later.",
+			},
+			"value_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"value_wo"},
+				Description:  "Version of `value_wo`, which must be changed to update the secret with a new `value_wo`.",
+			},
 			"value_encrypted": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Sensitive:        true,
-				ExactlyOneOf:     []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf:     []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 				Description:      "Value encrypted with the GitHub public key, defined by key_id, in Base64 format.",
 			},
@@ -85,7 +100,7 @@ func resourceGithubDependabotSecret() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Sensitive:        true,
-				ExactlyOneOf:     []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf:     []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsBase64),
 				Description:      "Encrypted value of the secret using the GitHub public key in Base64 format.",
 				Deprecated:       "Use value_encrypted and key_id.",
@@ -94,7 +109,7 @@ func resourceGithubDependabotSecret() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				ExactlyOneOf: []string{"value", "value_encrypted", "encrypted_value", "plaintext_value"},
+				ExactlyOneOf: []string{"value", "value_wo", "value_encrypted", "encrypted_value", "plaintext_value"},
 				Description:  "Plaintext value of the secret to be encrypted.",
 				Deprecated:   "Use value.",
 			},
@@ -113,6 +128,12 @@ func resourceGithubDependabotSecret() *schema.Resource {
 				Computed:    true,
 				Description: "Timestamp for when the secret was last updated.",
 			},
+			"drift_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(secretDriftPolicies, false)),
+				Description:      "How to plan the secret when it has been updated outside of Terraform. Must be one of `overwrite_in_place`, `recreate`, `ignore` or `error`; defaults to `overwrite_in_place`.",
+			},
 		},
 	}
 }
@@ -133,34 +154,23 @@ func resourceGithubDependabotSecretCreate(ctx context.Context, d *schema.Resourc
 	}
 	repoID := int(repo.GetID())
 
-	var publicKey string
-	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getDependabotPublicKeyDetails(ctx, meta, repoName)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		keyID = ki
-		publicKey = pk
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue, _ = resourceKeysGetOk[string](d, "value", "plaintext_value")
 	}
 
-	if len(encryptedValue) == 0 {
-		plaintextValue, _ := resourceKeysGetOk[string](d, "value", "plaintext_value")
-
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return diag.FromErr(err)
+	keyID, err = putSecret(ctx, meta, keyID, encryptedValue, plaintextValue, func() (string, string, error) {
+		return getDependabotPublicKeyDetails(ctx, meta, repoName)
+	}, func(keyID, encryptedValue string) error {
+		secret := github.DependabotEncryptedSecret{
+			Name:           secretName,
+			KeyID:          keyID,
+			EncryptedValue: encryptedValue,
 		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
-	}
 
-	secret := github.DependabotEncryptedSecret{
-		Name:           secretName,
-		KeyID:          keyID,
-		EncryptedValue: encryptedValue,
-	}
-
-	_, err = client.Dependabot.CreateOrUpdateRepoSecret(ctx, owner, repoName, &secret)
+		_, err := client.Dependabot.CreateOrUpdateRepoSecret(ctx, owner, repoName, &secret)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -241,6 +251,10 @@ func resourceGithubDependabotSecretRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceGithubDependabotSecretUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	if onlySecretDriftPolicyChanged(d) {
+		return nil
+	}
+
 	meta, _ := m.(*Owner)
 	client := meta.v3client
 	owner := meta.name
@@ -250,34 +264,23 @@ func resourceGithubDependabotSecretUpdate(ctx context.Context, d *schema.Resourc
 	keyID := d.Get("key_id").(string)
 	encryptedValue, _ := resourceKeysGetOk[string](d, "value_encrypted", "encrypted_value")
 
-	var publicKey string
-	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getDependabotPublicKeyDetails(ctx, meta, repoName)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		keyID = ki
-		publicKey = pk
+	plaintextValue, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		plaintextValue, _ = resourceKeysGetOk[string](d, "value", "plaintext_value")
 	}
 
-	if len(encryptedValue) == 0 {
-		plaintextValue, _ := resourceKeysGetOk[string](d, "value", "plaintext_value")
-
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return diag.FromErr(err)
+	keyID, err := putSecret(ctx, meta, keyID, encryptedValue, plaintextValue, func() (string, string, error) {
+		return getDependabotPublicKeyDetails(ctx, meta, repoName)
+	}, func(keyID, encryptedValue string) error {
+		secret := github.DependabotEncryptedSecret{
+			Name:           secretName,
+			KeyID:          keyID,
+			EncryptedValue: encryptedValue,
 		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
-	}
-
-	secret := github.DependabotEncryptedSecret{
-		Name:           secretName,
-		KeyID:          keyID,
-		EncryptedValue: encryptedValue,
-	}
 
-	_, err := client.Dependabot.CreateOrUpdateRepoSecret(ctx, owner, repoName, &secret)
+		_, err := client.Dependabot.CreateOrUpdateRepoSecret(ctx, owner, repoName, &secret)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -375,10 +378,8 @@ func getDependabotPublicKeyDetails(ctx context.Context, meta *Owner, repository
 	client := meta.v3client
 	owner := meta.name
 
-	publicKey, _, err := client.Dependabot.GetRepoPublicKey(ctx, owner, repository)
-	if err != nil {
-		return "", "", err
-	}
-
-	return publicKey.GetKeyID(), publicKey.GetKey(), err
+	return meta.publicKeys.get(ctx, fmt.Sprintf("repos/%s/%s/dependabot/secrets/public-key", owner, repository), func() (*github.PublicKey, error) {
+		publicKey, _, err := client.Dependabot.GetRepoPublicKey(ctx, owner, repository)
+		return publicKey, err
+	})
 }
@@ -0,0 +1,527 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceGithubEnterpriseRuleset() *schema.Resource {
+	// The rules and bypass actors of enterprise rulesets are the same as the ones of organization rulesets.
+	orgRuleset := resourceGithubOrganizationRuleset()
+
+	organizationConditions := []string{"conditions.0.organization_name", "conditions.0.organization_id", "conditions.0.organization_property"}
+	repositoryConditions := []string{"conditions.0.repository_name", "conditions.0.repository_property"}
+
+	return &schema.Resource{
+		Description:   "Creates and manages a GitHub enterprise ruleset, which applies to the repositories of the targeted organizations of the enterprise.",
+		CreateContext: resourceGithubEnterpriseRulesetCreate,
+		ReadContext:   resourceGithubEnterpriseRulesetRead,
+		UpdateContext: resourceGithubEnterpriseRulesetUpdate,
+		DeleteContext: resourceGithubEnterpriseRulesetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGithubEnterpriseRulesetImport,
+		},
+
+		CustomizeDiff: resourceGithubEnterpriseRulesetDiff,
+
+		Schema: map[string]*schema.Schema{
+			"enterprise_slug": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The slug of the enterprise.",
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(1, 100)),
+				Description:      "The name of the ruleset.",
+			},
+			"target": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(supportedOrgRulesetTargetTypes, false)),
+				Description:      "The target of the ruleset. Possible values are " + strings.Join(supportedOrgRulesetTargetTypes[:len(supportedOrgRulesetTargetTypes)-1], ", ") + " and " + supportedOrgRulesetTargetTypes[len(supportedOrgRulesetTargetTypes)-1] + ".",
+			},
+			"enforcement": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"disabled", "active", "evaluate"}, false)),
+				Description:      "The enforcement level of the ruleset. `evaluate` allows admins to test rules before enforcing them. Possible values are `disabled`, `active`, and `evaluate`.",
+			},
+			"bypass_actors": orgRuleset.Schema["bypass_actors"],
+			"node_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "GraphQL global node id for use with v4 API.",
+			},
+			"ruleset_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "GitHub ID for the ruleset.",
+			},
+			"conditions": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "Parameters for an enterprise ruleset condition. The conditions object should contain one of organization_name, organization_id or organization_property, and one of repository_name or repository_property. The branch and tag rulesets conditions object should also contain the ref_name property.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ref_name": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Targets refs that match the specified patterns. Required for `branch` and `tag` targets.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"include": {
+										Type:        schema.TypeList,
+										Required:    true,
+										Description: "Array of ref names or patterns to include. One of these patterns must match for the condition to pass. Also accepts `~DEFAULT_BRANCH` to include the default branch or `~ALL` to include all branches.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"exclude": {
+										Type:        schema.TypeList,
+										Required:    true,
+										Description: "Array of ref names or patterns to exclude. The condition will not pass if any of these patterns match.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+						"organization_name": {
+							Type:         schema.TypeList,
+							Optional:     true,
+							MaxItems:     1,
+							ExactlyOneOf: organizationConditions,
+							Description:  "Targets organizations that match the specified name patterns.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"include": {
+										Type:        schema.TypeList,
+										Required:    true,
+										Description: "Array of organization names or patterns to include. One of these patterns must match for the condition to pass. Also accepts `~ALL` to include all organizations.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"exclude": {
+										Type:        schema.TypeList,
+										Required:    true,
+										Description: "Array of organization names or patterns to exclude. The condition will not pass if any of these patterns match.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+						"organization_id": {
+							Type:         schema.TypeList,
+							Optional:     true,
+							ExactlyOneOf: organizationConditions,
+							Description:  "The organization IDs that the ruleset applies to. One of these IDs must match for the ruleset to apply.",
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+						},
+						"organization_property": enterpriseRulesetPropertyConditionSchema("organization", organizationConditions),
+						"repository_name": {
+							Type:         schema.TypeList,
+							Optional:     true,
+							MaxItems:     1,
+							ExactlyOneOf: repositoryConditions,
+							Description:  "Targets repositories that match the specified name patterns.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"include": {
+										Type:        schema.TypeList,
+										Required:    true,
+										Description: "Array of repository names or patterns to include. One of these patterns must match for the condition to pass. Also accepts `~ALL` to include all repositories.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"exclude": {
+										Type:        schema.TypeList,
+										Required:    true,
+										Description: "Array of repository names or patterns to exclude. The condition will not pass if any of these patterns match.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"protected": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Default:     false,
+										Description: "Whether renaming of target repositories is prevented.",
+									},
+								},
+							},
+						},
+						"repository_property": enterpriseRulesetPropertyConditionSchema("repository", repositoryConditions),
+					},
+				},
+			},
+			"rules": orgRuleset.Schema["rules"],
+			"etag": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "An etag representing the ruleset for caching purposes.",
+			},
+		},
+	}
+}
+
+// enterpriseRulesetPropertyConditionSchema returns the schema of a condition targeting organizations or repositories by their properties.
+func enterpriseRulesetPropertyConditionSchema(kind string, exactlyOneOf []string) *schema.Schema {
+	property := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: fmt.Sprintf("The name of the %s property to target.", kind),
+			},
+			"property_values": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: fmt.Sprintf("The values to match for the %s property.", kind),
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"source": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      fmt.Sprintf("The source of the %s property. Defaults to 'custom' if not specified. Can be one of: custom, system", kind),
+				Default:          "custom",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"custom", "system"}, false)),
+			},
+		},
+	}
+
+	return &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		ExactlyOneOf: exactlyOneOf,
+		Description:  fmt.Sprintf("Conditions to target %ss by custom or system properties.", kind),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"include": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					ConfigMode:  schema.SchemaConfigModeAttr,
+					Description: fmt.Sprintf("The %s properties and values to include. All of these properties must match for the condition to pass.", kind),
+					Elem:        property,
+				},
+				"exclude": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					ConfigMode:  schema.SchemaConfigModeAttr,
+					Description: fmt.Sprintf("The %s properties and values to exclude. The ruleset will not apply if any of these properties match.", kind),
+					Elem:        property,
+				},
+			},
+		},
+	}
+}
+
+func resourceGithubEnterpriseRulesetObject(d *schema.ResourceData) github.RepositoryRuleset {
+	target := github.RulesetTarget(d.Get("target").(string))
+	sourceType := github.RulesetSourceTypeEnterprise
+
+	return github.RepositoryRuleset{
+		Name:         d.Get("name").(string),
+		Target:       &target,
+		Source:       d.Get("enterprise_slug").(string),
+		SourceType:   &sourceType,
+		Enforcement:  github.RulesetEnforcement(d.Get("enforcement").(string)),
+		BypassActors: expandBypassActors(d.Get("bypass_actors").([]any)),
+		Conditions:   expandConditions(d.Get("conditions").([]any), true),
+		Rules:        expandRules(d.Get("rules").([]any), true),
+	}
+}
+
+func resourceGithubEnterpriseRulesetCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	enterpriseSlug := d.Get("enterprise_slug").(string)
+	name := d.Get("name").(string)
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating enterprise ruleset: %s/%s", enterpriseSlug, name), map[string]any{
+		"enterprise_slug": enterpriseSlug,
+		"name":            name,
+	})
+
+	ruleset, resp, err := client.Enterprise.CreateRepositoryRuleset(ctx, enterpriseSlug, resourceGithubEnterpriseRulesetObject(d))
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Failed to create enterprise ruleset: %s/%s", enterpriseSlug, name), map[string]any{
+			"enterprise_slug": enterpriseSlug,
+			"name":            name,
+			"error":           err.Error(),
+		})
+		return diag.FromErr(err)
+	}
+
+	id, err := buildID(enterpriseSlug, strconv.FormatInt(ruleset.GetID(), 10))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := d.Set("ruleset_id", ruleset.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("node_id", ruleset.GetNodeID()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("etag", resp.Header.Get("ETag")); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("rules", flattenRules(ctx, ruleset.Rules, true)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Created enterprise ruleset: %s/%s (ID: %d)", enterpriseSlug, name, ruleset.GetID()), map[string]any{
+		"enterprise_slug": enterpriseSlug,
+		"name":            name,
+		"ruleset_id":      ruleset.GetID(),
+	})
+
+	return nil
+}
+
+func resourceGithubEnterpriseRulesetRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+
+	enterpriseSlug, rulesetID, err := parseEnterpriseRulesetID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Reading enterprise ruleset: %s/%d", enterpriseSlug, rulesetID), map[string]any{
+		"enterprise_slug": enterpriseSlug,
+		"ruleset_id":      rulesetID,
+	})
+
+	if !d.IsNewResource() {
+		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
+	}
+
+	ruleset, resp, err := client.Enterprise.GetRepositoryRuleset(ctx, enterpriseSlug, rulesetID)
+	if err != nil {
+		if ghErr, ok := errors.AsType[*github.ErrorResponse](err); ok {
+			if ghErr.Response.StatusCode == http.StatusNotModified {
+				tflog.Debug(ctx, "API responded with StatusNotModified, not refreshing state", map[string]any{
+					"enterprise_slug": enterpriseSlug,
+					"ruleset_id":      rulesetID,
+				})
+				return nil
+			}
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				tflog.Info(ctx, fmt.Sprintf("Removing ruleset %s/%d from state because it no longer exists in GitHub", enterpriseSlug, rulesetID), map[string]any{
+					"enterprise_slug": enterpriseSlug,
+					"ruleset_id":      rulesetID,
+				})
+				d.SetId("")
+				return nil
+			}
+		}
+		tflog.Error(ctx, fmt.Sprintf("Failed to read enterprise ruleset: %s/%d", enterpriseSlug, rulesetID), map[string]any{
+			"enterprise_slug": enterpriseSlug,
+			"ruleset_id":      rulesetID,
+			"error":           err.Error(),
+		})
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("enterprise_slug", enterpriseSlug); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ruleset_id", ruleset.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", ruleset.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("target", ruleset.GetTarget()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("enforcement", ruleset.Enforcement); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("bypass_actors", flattenBypassActors(ctx, ruleset.BypassActors)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("conditions", flattenConditions(ctx, ruleset.GetConditions(), true)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("rules", flattenRules(ctx, ruleset.Rules, true)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("node_id", ruleset.GetNodeID()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("etag", resp.Header.Get("ETag")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully read enterprise ruleset: %s/%d", enterpriseSlug, rulesetID), map[string]any{
+		"enterprise_slug": enterpriseSlug,
+		"ruleset_id":      rulesetID,
+		"name":            ruleset.Name,
+	})
+
+	return nil
+}
+
+func resourceGithubEnterpriseRulesetUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	name := d.Get("name").(string)
+
+	enterpriseSlug, rulesetID, err := parseEnterpriseRulesetID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating enterprise ruleset: %s/%d", enterpriseSlug, rulesetID), map[string]any{
+		"enterprise_slug": enterpriseSlug,
+		"ruleset_id":      rulesetID,
+		"name":            name,
+	})
+
+	ruleset, resp, err := client.Enterprise.UpdateRepositoryRuleset(ctx, enterpriseSlug, rulesetID, resourceGithubEnterpriseRulesetObject(d))
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Failed to update enterprise ruleset: %s/%d", enterpriseSlug, rulesetID), map[string]any{
+			"enterprise_slug": enterpriseSlug,
+			"ruleset_id":      rulesetID,
+			"error":           err.Error(),
+		})
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ruleset_id", ruleset.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("node_id", ruleset.GetNodeID()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("etag", resp.Header.Get("ETag")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updated enterprise ruleset: %s/%d", enterpriseSlug, rulesetID), map[string]any{
+		"enterprise_slug": enterpriseSlug,
+		"ruleset_id":      rulesetID,
+		"name":            name,
+	})
+
+	return nil
+}
+
+func resourceGithubEnterpriseRulesetDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+
+	enterpriseSlug, rulesetID, err := parseEnterpriseRulesetID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Deleting enterprise ruleset: %s/%d", enterpriseSlug, rulesetID), map[string]any{
+		"enterprise_slug": enterpriseSlug,
+		"ruleset_id":      rulesetID,
+	})
+
+	_, err = client.Enterprise.DeleteRepositoryRuleset(ctx, enterpriseSlug, rulesetID)
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Failed to delete enterprise ruleset: %s/%d", enterpriseSlug, rulesetID), map[string]any{
+			"enterprise_slug": enterpriseSlug,
+			"ruleset_id":      rulesetID,
+			"error":           err.Error(),
+		})
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleted enterprise ruleset: %s/%d", enterpriseSlug, rulesetID), map[string]any{
+		"enterprise_slug": enterpriseSlug,
+		"ruleset_id":      rulesetID,
+	})
+
+	return nil
+}
+
+func resourceGithubEnterpriseRulesetImport(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	client := meta.(*Owner).v3client
+
+	enterpriseSlug, rulesetID, err := parseEnterpriseRulesetID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if rulesetID == 0 {
+		return nil, fmt.Errorf("`ruleset_id` must be present")
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Importing enterprise ruleset: %s/%d", enterpriseSlug, rulesetID), map[string]any{
+		"enterprise_slug": enterpriseSlug,
+		"ruleset_id":      rulesetID,
+	})
+
+	ruleset, _, err := client.Enterprise.GetRepositoryRuleset(ctx, enterpriseSlug, rulesetID)
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Failed to import enterprise ruleset: %s/%d", enterpriseSlug, rulesetID), map[string]any{
+			"enterprise_slug": enterpriseSlug,
+			"ruleset_id":      rulesetID,
+			"error":           err.Error(),
+		})
+		return nil, err
+	}
+
+	if err := d.Set("enterprise_slug", enterpriseSlug); err != nil {
+		return nil, err
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Imported enterprise ruleset: %s/%d (name: %s)", enterpriseSlug, rulesetID, ruleset.Name), map[string]any{
+		"enterprise_slug": enterpriseSlug,
+		"ruleset_id":      rulesetID,
+		"name":            ruleset.Name,
+	})
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceGithubEnterpriseRulesetDiff(ctx context.Context, d *schema.ResourceDiff, _ any) error {
+	if err := validateRulesetConditions(ctx, d, true); err != nil {
+		return err
+	}
+
+	return validateRulesetRules(ctx, d)
+}
+
+// parseEnterpriseRulesetID parses an enterprise ruleset ID of the form <enterprise-slug>:<ruleset-id>.
+func parseEnterpriseRulesetID(id string) (string, int64, error) {
+	enterpriseSlug, rulesetIDString, err := parseID2(id)
+	if err != nil {
+		return "", 0, err
+	}
+
+	rulesetID, err := strconv.ParseInt(rulesetIDString, 10, 64)
+	if err != nil {
+		return "", 0, unconvertibleIdErr(rulesetIDString, err)
+	}
+
+	return enterpriseSlug, rulesetID, nil
+}
@@ -0,0 +1,203 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGithubEnterpriseRuleset(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create_branch_ruleset", func(t *testing.T) {
+		t.Parallel()
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		rulesetName := fmt.Sprintf("%s-enterprise-ruleset-%s", testResourcePrefix, randomID)
+
+		config := `
+	resource "github_enterprise_ruleset" "test" {
+		enterprise_slug = "%s"
+		name            = "%s"
+		target          = "branch"
+		enforcement     = "%s"
+
+		bypass_actors {
+			actor_type  = "EnterpriseOwner"
+			bypass_mode = "always"
+		}
+
+		conditions {
+			organization_name {
+				include = ["~ALL"]
+				exclude = []
+			}
+
+			repository_name {
+				include = ["~ALL"]
+				exclude = []
+			}
+
+			ref_name {
+				include = ["~DEFAULT_BRANCH"]
+				exclude = []
+			}
+		}
+
+		rules {
+			creation         = true
+			deletion         = true
+			non_fast_forward = true
+
+			pull_request {
+				required_approving_review_count = 1
+			}
+
+			merge_queue {
+				merge_method = "SQUASH"
+			}
+		}
+	}
+`
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnlessEnterprise(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, testAccConf.enterpriseSlug, rulesetName, "evaluate"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("github_enterprise_ruleset.test", "name", rulesetName),
+						resource.TestCheckResourceAttr("github_enterprise_ruleset.test", "enforcement", "evaluate"),
+						resource.TestCheckResourceAttr("github_enterprise_ruleset.test", "bypass_actors.0.actor_type", "EnterpriseOwner"),
+						resource.TestCheckResourceAttr("github_enterprise_ruleset.test", "conditions.0.organization_name.0.include.0", "~ALL"),
+						resource.TestCheckResourceAttr("github_enterprise_ruleset.test", "rules.0.pull_request.0.required_approving_review_count", "1"),
+						resource.TestCheckResourceAttr("github_enterprise_ruleset.test", "rules.0.merge_queue.0.merge_method", "SQUASH"),
+						resource.TestCheckResourceAttrSet("github_enterprise_ruleset.test", "ruleset_id"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(config, testAccConf.enterpriseSlug, rulesetName, "active"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("github_enterprise_ruleset.test", "enforcement", "active"),
+					),
+				},
+				{
+					ResourceName:            "github_enterprise_ruleset.test",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"etag"},
+				},
+			},
+		})
+	})
+
+	t.Run("create_ruleset_with_organization_property", func(t *testing.T) {
+		t.Parallel()
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		rulesetName := fmt.Sprintf("%s-enterprise-ruleset-%s", testResourcePrefix, randomID)
+
+		config := fmt.Sprintf(`
+	resource "github_enterprise_ruleset" "test" {
+		enterprise_slug = "%s"
+		name            = "%s"
+		target          = "branch"
+		enforcement     = "evaluate"
+
+		conditions {
+			organization_property {
+				include = [{
+					name            = "environment"
+					property_values = ["production"]
+				}]
+				exclude = []
+			}
+
+			repository_property {
+				include = [{
+					name            = "visibility"
+					source          = "system"
+					property_values = ["public"]
+				}]
+				exclude = []
+			}
+
+			ref_name {
+				include = ["~ALL"]
+				exclude = []
+			}
+		}
+
+		rules {
+			required_signatures = true
+		}
+	}
+`, testAccConf.enterpriseSlug, rulesetName)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnlessEnterprise(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("github_enterprise_ruleset.test", "conditions.0.organization_property.0.include.0.name", "environment"),
+						resource.TestCheckResourceAttr("github_enterprise_ruleset.test", "conditions.0.organization_property.0.include.0.property_values.0", "production"),
+						resource.TestCheckResourceAttr("github_enterprise_ruleset.test", "conditions.0.repository_property.0.include.0.source", "system"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("validates_conditions_require_exactly_one_organization_targeting", func(t *testing.T) {
+		t.Parallel()
+
+		config := fmt.Sprintf(`
+	resource "github_enterprise_ruleset" "test" {
+		enterprise_slug = "%s"
+		name            = "test-validation"
+		target          = "branch"
+		enforcement     = "active"
+
+		conditions {
+			organization_name {
+				include = ["~ALL"]
+				exclude = []
+			}
+
+			organization_id = [1]
+
+			repository_name {
+				include = ["~ALL"]
+				exclude = []
+			}
+
+			ref_name {
+				include = ["~ALL"]
+				exclude = []
+			}
+		}
+
+		rules {
+			creation = true
+		}
+	}
+`, testAccConf.enterpriseSlug)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnlessEnterprise(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      config,
+					ExpectError: regexp.MustCompile(`(?s)only one of.*conditions\.0\.organization_id.*conditions\.0\.organization_name.*conditions\.0\.organization_property.*can be specified`),
+				},
+			},
+		})
+	})
+}
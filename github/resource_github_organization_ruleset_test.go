@@ -110,6 +110,16 @@ resource "github_organization_ruleset" "test" {
 			require_last_push_approval        = true
 		}
 
+		merge_queue {
+			check_response_timeout_minutes    = 10
+			grouping_strategy                 = "HEADGREEN"
+			max_entries_to_build              = 10
+			max_entries_to_merge              = 4
+			merge_method                      = "SQUASH"
+			min_entries_to_merge              = 2
+			min_entries_to_merge_wait_minutes = 30
+		}
+
 		copilot_code_review {
 			review_on_push             = true
 			review_draft_pull_requests = false
@@ -180,6 +190,13 @@ resource "github_organization_ruleset" "test" {
 						resource.TestCheckResourceAttr("github_organization_ruleset.test", "rules.0.required_code_scanning.0.required_code_scanning_tool.0.tool", "CodeQL"),
 						resource.TestCheckResourceAttr("github_organization_ruleset.test", "rules.0.copilot_code_review.0.review_on_push", "true"),
 						resource.TestCheckResourceAttr("github_organization_ruleset.test", "rules.0.copilot_code_review.0.review_draft_pull_requests", "false"),
+						resource.TestCheckResourceAttr("github_organization_ruleset.test", "rules.0.merge_queue.0.check_response_timeout_minutes", "10"),
+						resource.TestCheckResourceAttr("github_organization_ruleset.test", "rules.0.merge_queue.0.grouping_strategy", "HEADGREEN"),
+						resource.TestCheckResourceAttr("github_organization_ruleset.test", "rules.0.merge_queue.0.max_entries_to_build", "10"),
+						resource.TestCheckResourceAttr("github_organization_ruleset.test", "rules.0.merge_queue.0.max_entries_to_merge", "4"),
+						resource.TestCheckResourceAttr("github_organization_ruleset.test", "rules.0.merge_queue.0.merge_method", "SQUASH"),
+						resource.TestCheckResourceAttr("github_organization_ruleset.test", "rules.0.merge_queue.0.min_entries_to_merge", "2"),
+						resource.TestCheckResourceAttr("github_organization_ruleset.test", "rules.0.merge_queue.0.min_entries_to_merge_wait_minutes", "30"),
 					),
 				},
 			},
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/google/go-github/v89/github"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -130,6 +131,8 @@ func resourceGithubRepositoryFile() *schema.Resource {
 				DiffSuppressFunc: autoBranchDiffSuppressFunc,
 				Deprecated:       "Use `github_branch` resource instead",
 			},
+			"pull_request":  resourceGithubRepositoryFilePullRequestSchema(),
+			"managed_block": managedBlockSchema("Manage only the block of the file between a begin and an end marker line, setting `content` to the content of the block and leaving the rest of the file alone."),
 		},
 		CustomizeDiff: diffRepository,
 	}
@@ -224,7 +227,18 @@ func resourceGithubRepositoryFileCreate(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
-	if fileContent != nil {
+	if block := expandManagedBlock(d); block != nil {
+		existing := ""
+		if fileContent != nil {
+			if existing, err = fileContent.GetContent(); err != nil {
+				return diag.FromErr(err)
+			}
+			opts.SHA = fileContent.SHA
+		}
+
+		tflog.Debug(ctx, "Setting the managed block of a repository file")
+		opts.Content = []byte(block.replace(existing, d.Get("content").(string)))
+	} else if fileContent != nil {
 		if d.Get("overwrite_on_create").(bool) {
 			// Overwrite existing file if requested by configuring the options for
 			// `client.Repositories.CreateFile` to match the existing file's SHA
@@ -235,11 +249,21 @@ func resourceGithubRepositoryFileCreate(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
-	tflog.Debug(ctx, "Creating or overwriting a repository file")
-	// Create a new or overwritten file
-	create, _, err := client.Repositories.CreateFile(ctx, owner, repo, file, opts)
-	if err != nil {
-		return diag.FromErr(err)
+	var commitSHA string
+	if resourceGithubRepositoryFilePullRequest(d) != nil {
+		tflog.Debug(ctx, "Delivering a repository file through a pull request")
+		commitSHA, err = deliverRepositoryFilePullRequest(ctx, d, meta, branch, opts, new(string(opts.Content)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		tflog.Debug(ctx, "Creating or overwriting a repository file")
+		// Create a new or overwritten file
+		create, _, err := client.Repositories.CreateFile(ctx, owner, repo, file, opts)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		commitSHA = create.GetSHA()
 	}
 
 	newResourceID, err := buildID(repo, escapeIDPart(file), branch)
@@ -252,7 +276,7 @@ func resourceGithubRepositoryFileCreate(ctx context.Context, d *schema.ResourceD
 	d.SetId(newResourceID)
 
 	// Set computed values after the resource is created and in state
-	if err = d.Set("commit_sha", create.GetSHA()); err != nil {
+	if err = d.Set("commit_sha", commitSHA); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("branch", branch); err != nil {
@@ -278,9 +302,14 @@ func resourceGithubRepositoryFileRead(ctx context.Context, d *schema.ResourceDat
 	ctx = tflog.SetField(ctx, "owner", owner)
 	ctx = tflog.SetField(ctx, "owner", owner)
 
+	ref, err := resourceGithubRepositoryFilePullRequestRef(ctx, d, meta, branch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	opts := &github.RepositoryContentGetOptions{}
 
-	opts.Ref = branch
+	opts.Ref = ref
 
 	fc, _, _, err := client.Repositories.GetContents(ctx, owner, repoName, file, opts)
 	if err != nil {
@@ -297,6 +326,18 @@ func resourceGithubRepositoryFileRead(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
+	if block := expandManagedBlock(d); block != nil {
+		blockContent, ok := block.extract(content)
+		if !ok {
+			tflog.Info(ctx, "Managed block not found in repository file")
+		}
+
+		if configured := d.Get("content").(string); ok && block.equal(blockContent, configured) {
+			blockContent = configured
+		}
+		content = blockContent
+	}
+
 	if err = d.Set("content", content); err != nil {
 		return diag.FromErr(err)
 	}
@@ -315,7 +356,7 @@ func resourceGithubRepositoryFileRead(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	ref := parsedQuery["ref"][0]
+	ref = parsedQuery["ref"][0]
 	if err = d.Set("ref", ref); err != nil {
 		return diag.FromErr(err)
 	}
@@ -382,12 +423,39 @@ func resourceGithubRepositoryFileUpdate(ctx context.Context, d *schema.ResourceD
 		opts.Message = new(fmt.Sprintf("Update %s", file))
 	}
 
-	update, _, err := client.Repositories.UpdateFile(ctx, owner, repo, file, opts)
-	if err != nil {
-		return diag.FromErr(err)
+	if block := expandManagedBlock(d); block != nil {
+		ref, err := resourceGithubRepositoryFilePullRequestRef(ctx, d, meta, branch)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		content, sha, err := getRepositoryFileManagedBlockContent(ctx, client, owner, repo, file, ref, block, new(d.Get("content").(string)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		tflog.Debug(ctx, "Setting the managed block of a repository file")
+		opts.Content = []byte(content)
+		opts.SHA = sha
 	}
 
-	if err = d.Set("commit_sha", update.GetSHA()); err != nil {
+	var commitSHA string
+	if resourceGithubRepositoryFilePullRequest(d) != nil {
+		tflog.Debug(ctx, "Delivering a repository file update through a pull request")
+		sha, err := deliverRepositoryFilePullRequest(ctx, d, meta, branch, opts, new(string(opts.Content)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		commitSHA = sha
+	} else {
+		update, _, err := client.Repositories.UpdateFile(ctx, owner, repo, file, opts)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		commitSHA = update.GetSHA()
+	}
+
+	if err := d.Set("commit_sha", commitSHA); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -418,10 +486,72 @@ func resourceGithubRepositoryFileDelete(ctx context.Context, d *schema.ResourceD
 	branch := d.Get("branch").(string)
 	opts.Branch = new(branch)
 
+	pullRequest := resourceGithubRepositoryFilePullRequest(d) != nil
+	if pullRequest {
+		if err := closeRepositoryFilePullRequest(ctx, d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// With a managed block only the block is removed, unless nothing else is left in the file.
+	var remaining *string
+	if block := expandManagedBlock(d); block != nil {
+		content, sha, err := getRepositoryFileManagedBlockContent(ctx, client, owner, repo, file, branch, block, nil)
+		if err != nil {
+			return diag.FromErr(handleArchivedRepoDelete(err, "repository file", file, owner, repo))
+		}
+		if sha == nil {
+			tflog.Info(ctx, "Repository file no longer exists, nothing to remove the managed block from")
+			return nil
+		}
+
+		opts.SHA = sha
+		if strings.TrimSpace(content) != "" {
+			remaining = new(content)
+			opts.Content = []byte(content)
+		}
+	}
+
+	if pullRequest {
+		tflog.Debug(ctx, "Delivering a repository file deletion through a pull request")
+		_, err := deliverRepositoryFilePullRequest(ctx, d, meta, branch, opts, remaining)
+		return diag.FromErr(handleArchivedRepoDelete(err, "repository file", file, owner, repo))
+	}
+
+	if remaining != nil {
+		tflog.Debug(ctx, "Removing the managed block from a repository file")
+		_, _, err := client.Repositories.UpdateFile(ctx, owner, repo, file, opts)
+		return diag.FromErr(handleArchivedRepoDelete(err, "repository file", file, owner, repo))
+	}
+
 	_, _, err := client.Repositories.DeleteFile(ctx, owner, repo, file, opts)
 	return diag.FromErr(handleArchivedRepoDelete(err, "repository file", file, owner, repo))
 }
 
+// getRepositoryFileManagedBlockContent returns the content of the file on the ref with the managed block set to the content, or removed if content is nil, along with the blob SHA of the file, which is nil if the file doesn't exist.
+func getRepositoryFileManagedBlockContent(ctx context.Context, client *github.Client, owner, repo, file, ref string, block *managedBlock, content *string) (string, *string, error) {
+	var existing string
+	var sha *string
+
+	fc, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, file, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return "", nil, err
+		}
+	} else if fc != nil {
+		if existing, err = fc.GetContent(); err != nil {
+			return "", nil, err
+		}
+		sha = fc.SHA
+	}
+
+	if content == nil {
+		return block.remove(existing), sha, nil
+	}
+
+	return block.replace(existing, *content), sha, nil
+}
+
 func autoBranchDiffSuppressFunc(k, _, _ string, d *schema.ResourceData) bool {
 	if !d.Get("autocreate_branch").(bool) {
 		switch k {
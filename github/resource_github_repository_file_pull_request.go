@@ -0,0 +1,399 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/shurcooL/githubv4"
+)
+
+// repositoryFilePullRequestBranchPrefix prefixes the branches generated for delivering repository file changes through pull requests.
+const repositoryFilePullRequestBranchPrefix = "terraform/"
+
+// repositoryFilePullRequestBranchInvalidChars matches the characters replaced when generating a branch name from a file path.
+var repositoryFilePullRequestBranchInvalidChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// repositoryFilePullRequestMergeMethods maps the supported merge methods to their GraphQL enum values.
+var repositoryFilePullRequestMergeMethods = map[string]githubv4.PullRequestMergeMethod{
+	"merge":  githubv4.PullRequestMergeMethodMerge,
+	"squash": githubv4.PullRequestMergeMethodSquash,
+	"rebase": githubv4.PullRequestMergeMethodRebase,
+}
+
+func resourceGithubRepositoryFilePullRequestSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Deliver changes to the file through a pull request into the branch instead of committing them to the branch directly, e.g. when branch protection or rulesets block direct pushes.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"branch": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					Description: "The branch to commit the changes to and open the pull request from, defaults to a branch generated from the file path. The branch is created from the target branch if it doesn't exist, and otherwise fast-forwarded to it before opening a new pull request.",
+				},
+				"title": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The title of the pull request, defaults to the commit message.",
+				},
+				"body": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The body of the pull request.",
+				},
+				"labels": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "The labels to add to the pull request.",
+				},
+				"reviewers": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "The logins of the users to request reviews from.",
+				},
+				"team_reviewers": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "The slugs of the teams to request reviews from.",
+				},
+				"auto_merge": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Enable auto-merge for the pull request so that it's merged once its requirements are met; if it has no unmet requirements it's merged immediately.",
+				},
+				"merge_method": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          "merge",
+					Description:      "The merge method to use when `auto_merge` is enabled. Must be one of `merge`, `squash` or `rebase`.",
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"merge", "squash", "rebase"}, false)),
+				},
+				"number": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of the pull request delivering the last change.",
+				},
+				"url": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The URL of the pull request delivering the last change.",
+				},
+				"landed": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the last change has landed on the branch, i.e. its pull request has been merged.",
+				},
+			},
+		},
+	}
+}
+
+// repositoryFilePullRequestBranch returns the name of the branch generated for delivering changes to the file through pull requests.
+func repositoryFilePullRequestBranch(file string) string {
+	return repositoryFilePullRequestBranchPrefix + strings.Trim(repositoryFilePullRequestBranchInvalidChars.ReplaceAllString(file, "-"), "-.")
+}
+
+// resourceGithubRepositoryFilePullRequest returns the pull request delivery configuration of the resource, or nil if changes are committed to the branch directly.
+func resourceGithubRepositoryFilePullRequest(d *schema.ResourceData) map[string]any {
+	prs, _ := d.Get("pull_request").([]any)
+	if len(prs) == 0 || prs[0] == nil {
+		return nil
+	}
+
+	pr, _ := prs[0].(map[string]any)
+	return pr
+}
+
+// resourceGithubRepositoryFilePullRequestRef returns the ref to read the file from: the pull request branch while the pull request delivering the last change is open, otherwise the branch. It records whether the change has landed.
+func resourceGithubRepositoryFilePullRequestRef(ctx context.Context, d *schema.ResourceData, meta any, branch string) (string, error) {
+	pr := resourceGithubRepositoryFilePullRequest(d)
+	if pr == nil {
+		return branch, nil
+	}
+
+	number, _ := pr["number"].(int)
+	if number == 0 {
+		return branch, nil
+	}
+
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repo := d.Get("repository").(string)
+
+	pullRequest, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return "", err
+	}
+
+	pr["landed"] = pullRequest.GetMerged()
+	if err := d.Set("pull_request", []any{pr}); err != nil {
+		return "", err
+	}
+
+	if pullRequest.GetState() == "open" {
+		return pullRequest.GetHead().GetRef(), nil
+	}
+
+	return branch, nil
+}
+
+// deliverRepositoryFilePullRequest commits the file content, or its deletion if content is nil, to the pull request branch and opens or updates the pull request into the branch. It returns the SHA of the commit with the change; if the branch already has the content no pull request is opened and the change is recorded as landed.
+func deliverRepositoryFilePullRequest(ctx context.Context, d *schema.ResourceData, meta any, branch string, opts *github.RepositoryContentFileOptions, content *string) (string, error) {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repo := d.Get("repository").(string)
+	file := d.Get("file").(string)
+
+	pr := resourceGithubRepositoryFilePullRequest(d)
+	headBranch, _ := pr["branch"].(string)
+	if headBranch == "" {
+		headBranch = repositoryFilePullRequestBranch(file)
+	}
+	pr["branch"] = headBranch
+	// Only the generated branch belongs to the resource; a branch set in the configuration may carry other work, so it's never rewound or deleted.
+	generated := headBranch == repositoryFilePullRequestBranch(file)
+
+	ctx = tflog.SetField(ctx, "pull_request_branch", headBranch)
+
+	existing, _, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  owner + ":" + headBranch,
+		Base:  branch,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var pullRequest *github.PullRequest
+	if len(existing) > 0 {
+		pullRequest = existing[0]
+	}
+
+	base, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return "", err
+	}
+
+	// Start a new pull request from the tip of the branch so that it only carries this change; an open pull request is updated with a new commit instead.
+	if pullRequest == nil {
+		if generated {
+			err = resetRepositoryFilePullRequestBranch(ctx, client, owner, repo, headBranch, base.GetObject().GetSHA())
+		} else {
+			err = fastForwardRepositoryFilePullRequestBranch(ctx, client, owner, repo, headBranch, branch, base.GetObject().GetSHA())
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	commit, err := commitRepositoryFiles(ctx, client, owner, repo, headBranch, &repositoryFilesCommit{
+		message: opts.GetMessage(),
+		author:  opts.Author,
+		files:   map[string]*string{file: content},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if pullRequest == nil && commit.GetSHA() == base.GetObject().GetSHA() {
+		tflog.Info(ctx, "Branch already has the file content, skipping pull request")
+
+		if generated {
+			if _, err := client.Git.DeleteRef(ctx, owner, repo, "refs/heads/"+headBranch); err != nil {
+				return "", err
+			}
+		}
+
+		pr["number"] = 0
+		pr["url"] = ""
+		pr["landed"] = true
+		return commit.GetSHA(), d.Set("pull_request", []any{pr})
+	}
+
+	title, _ := pr["title"].(string)
+	if title == "" {
+		title = opts.GetMessage()
+	}
+	body, _ := pr["body"].(string)
+
+	if pullRequest == nil {
+		tflog.Debug(ctx, "Opening pull request for repository file")
+
+		pullRequest, _, err = client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+			Title: new(title),
+			Head:  new(headBranch),
+			Base:  new(branch),
+			Body:  new(body),
+		})
+	} else {
+		tflog.Debug(ctx, "Updating pull request for repository file", map[string]any{"number": pullRequest.GetNumber()})
+
+		pullRequest, _, err = client.PullRequests.Edit(ctx, owner, repo, pullRequest.GetNumber(), &github.PullRequest{
+			Title: new(title),
+			Body:  new(body),
+		})
+	}
+	if err != nil {
+		return "", err
+	}
+
+	number := pullRequest.GetNumber()
+
+	if labels := expandStringList(pr["labels"].(*schema.Set).List()); len(labels) > 0 {
+		if _, _, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, number, labels); err != nil {
+			return "", err
+		}
+	}
+
+	reviewers := github.ReviewersRequest{
+		Reviewers:     expandStringList(pr["reviewers"].(*schema.Set).List()),
+		TeamReviewers: expandStringList(pr["team_reviewers"].(*schema.Set).List()),
+	}
+	if len(reviewers.Reviewers) > 0 || len(reviewers.TeamReviewers) > 0 {
+		if _, _, err := client.PullRequests.RequestReviewers(ctx, owner, repo, number, reviewers); err != nil {
+			return "", err
+		}
+	}
+
+	landed := false
+	if autoMerge, _ := pr["auto_merge"].(bool); autoMerge {
+		mergeMethod, _ := pr["merge_method"].(string)
+		if landed, err = enableRepositoryFilePullRequestAutoMerge(ctx, meta, owner, repo, pullRequest, mergeMethod); err != nil {
+			return "", err
+		}
+	}
+
+	pr["number"] = number
+	pr["url"] = pullRequest.GetHTMLURL()
+	pr["landed"] = landed
+	return commit.GetSHA(), d.Set("pull_request", []any{pr})
+}
+
+// closeRepositoryFilePullRequest closes the open pull request delivering the last change to the file, if any, as the change is no longer wanted.
+func closeRepositoryFilePullRequest(ctx context.Context, d *schema.ResourceData, meta any) error {
+	pr := resourceGithubRepositoryFilePullRequest(d)
+	number, _ := pr["number"].(int)
+	if landed, _ := pr["landed"].(bool); number == 0 || landed {
+		return nil
+	}
+
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repo := d.Get("repository").(string)
+
+	pullRequest, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		if ghErr, ok := errors.AsType[*github.ErrorResponse](err); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if pullRequest.GetState() != "open" {
+		return nil
+	}
+
+	tflog.Info(ctx, "Closing pull request for repository file", map[string]any{"number": number})
+
+	_, _, err = client.PullRequests.Edit(ctx, owner, repo, number, &github.PullRequest{State: new("closed")})
+	return err
+}
+
+// resetRepositoryFilePullRequestBranch points the generated pull request branch at the commit, creating the branch if it doesn't exist.
+func resetRepositoryFilePullRequestBranch(ctx context.Context, client *github.Client, owner, repo, branch, sha string) error {
+	ref := "refs/heads/" + branch
+
+	_, _, err := client.Git.UpdateRef(ctx, owner, repo, ref, github.UpdateRef{SHA: sha, Force: new(true)})
+	if err == nil {
+		return nil
+	}
+
+	if ghErr, ok := errors.AsType[*github.ErrorResponse](err); !ok || ghErr.Response.StatusCode != http.StatusUnprocessableEntity && ghErr.Response.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("error resetting GitHub branch reference %s/%s (%s): %w", owner, repo, ref, err)
+	}
+
+	if _, _, err := client.Git.CreateRef(ctx, owner, repo, github.CreateRef{Ref: ref, SHA: sha}); err != nil {
+		return fmt.Errorf("error creating GitHub branch reference %s/%s (%s): %w", owner, repo, ref, err)
+	}
+
+	return nil
+}
+
+// fastForwardRepositoryFilePullRequestBranch fast-forwards the pull request branch to the commit at the tip of the base branch, creating the branch if it doesn't exist. It fails if the branch has commits which aren't on the base branch.
+func fastForwardRepositoryFilePullRequestBranch(ctx context.Context, client *github.Client, owner, repo, branch, baseBranch, sha string) error {
+	ref := "refs/heads/" + branch
+
+	existing, _, err := client.Git.GetRef(ctx, owner, repo, ref)
+	if err != nil {
+		if ghErr, ok := errors.AsType[*github.ErrorResponse](err); !ok || ghErr.Response.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("error reading GitHub branch reference %s/%s (%s): %w", owner, repo, ref, err)
+		}
+
+		if _, _, err := client.Git.CreateRef(ctx, owner, repo, github.CreateRef{Ref: ref, SHA: sha}); err != nil {
+			return fmt.Errorf("error creating GitHub branch reference %s/%s (%s): %w", owner, repo, ref, err)
+		}
+		return nil
+	}
+
+	if existing.GetObject().GetSHA() == sha {
+		return nil
+	}
+
+	if _, _, err := client.Git.UpdateRef(ctx, owner, repo, ref, github.UpdateRef{SHA: sha, Force: new(false)}); err != nil {
+		if ghErr, ok := errors.AsType[*github.ErrorResponse](err); ok && ghErr.Response.StatusCode == http.StatusUnprocessableEntity {
+			return fmt.Errorf("the pull request branch %s of %s/%s has commits which aren't on %s and can't be fast-forwarded; merge or delete the branch, or unset pull_request.branch to use a generated branch", branch, owner, repo, baseBranch)
+		}
+		return fmt.Errorf("error fast-forwarding GitHub branch reference %s/%s (%s): %w", owner, repo, ref, err)
+	}
+
+	return nil
+}
+
+// enableRepositoryFilePullRequestAutoMerge enables auto-merge for the pull request; GitHub refuses to enable auto-merge for pull requests without unmet merge requirements, so those are merged immediately. It returns whether the pull request was merged.
+func enableRepositoryFilePullRequestAutoMerge(ctx context.Context, meta any, owner, repo string, pullRequest *github.PullRequest, mergeMethod string) (bool, error) {
+	var mutate struct {
+		EnablePullRequestAutoMerge struct {
+			ClientMutationId githubv4.ID
+		} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+	}
+	input := githubv4.EnablePullRequestAutoMergeInput{
+		PullRequestID: githubv4.ID(pullRequest.GetNodeID()),
+		MergeMethod:   new(repositoryFilePullRequestMergeMethods[mergeMethod]),
+	}
+
+	err := meta.(*Owner).v4client.Mutate(ctx, &mutate, input, nil)
+	if err == nil {
+		return false, nil
+	}
+
+	if !strings.Contains(strings.ToLower(err.Error()), "clean status") {
+		return false, fmt.Errorf("error enabling auto-merge for pull request %s/%s#%d: %w", owner, repo, pullRequest.GetNumber(), err)
+	}
+
+	tflog.Info(ctx, "Pull request has no unmet merge requirements, merging it", map[string]any{"number": pullRequest.GetNumber()})
+
+	result, _, err := meta.(*Owner).v3client.PullRequests.Merge(ctx, owner, repo, pullRequest.GetNumber(), "", &github.PullRequestOptions{
+		SHA:         pullRequest.GetHead().GetSHA(),
+		MergeMethod: mergeMethod,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error merging pull request %s/%s#%d: %w", owner, repo, pullRequest.GetNumber(), err)
+	}
+
+	return result.GetMerged(), nil
+}
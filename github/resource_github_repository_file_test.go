@@ -1,15 +1,21 @@
 package github
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/google/go-github/v89/github"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
@@ -523,4 +529,238 @@ func TestAccGithubRepositoryFile(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("delivers_changes_through_pull_request", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+
+		config := `
+resource "github_repository_file" "test" {
+  repository     = "%s"
+  file           = "dir/test.txt"
+  content        = "%s"
+  commit_message = "Managed by Terraform"
+
+  pull_request {
+    title = "Update test file"
+    body  = "Managed by Terraform"
+  }
+}
+`
+
+		expectContentOnBranch := func(branch, want string) resource.TestCheckFunc {
+			return func(*terraform.State) error {
+				fc, _, _, err := testAccConf.meta.v3client.Repositories.GetContents(t.Context(), testAccConf.meta.name, repo.GetName(), "dir/test.txt", &github.RepositoryContentGetOptions{Ref: branch})
+				if err != nil {
+					return err
+				}
+
+				content, err := fc.GetContent()
+				if err != nil {
+					return err
+				}
+				if content != want {
+					return fmt.Errorf("expected content of branch %s to be %q, got %q", branch, want, content)
+				}
+				return nil
+			}
+		}
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, repo.GetName(), "foo"),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_repository_file.test", tfjsonpath.New("ref"), knownvalue.StringExact("terraform/dir-test.txt")),
+						statecheck.ExpectKnownValue("github_repository_file.test", tfjsonpath.New("pull_request").AtSliceIndex(0).AtMapKey("branch"), knownvalue.StringExact("terraform/dir-test.txt")),
+						statecheck.ExpectKnownValue("github_repository_file.test", tfjsonpath.New("pull_request").AtSliceIndex(0).AtMapKey("number"), knownvalue.NotNull()),
+						statecheck.ExpectKnownValue("github_repository_file.test", tfjsonpath.New("pull_request").AtSliceIndex(0).AtMapKey("landed"), knownvalue.Bool(false)),
+					},
+					Check: expectContentOnBranch("terraform/dir-test.txt", "foo"),
+				},
+				{
+					Config: fmt.Sprintf(config, repo.GetName(), "bar"),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_repository_file.test", plancheck.ResourceActionUpdate),
+						},
+					},
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_repository_file.test", tfjsonpath.New("content"), knownvalue.StringExact("bar")),
+						statecheck.ExpectKnownValue("github_repository_file.test", tfjsonpath.New("pull_request").AtSliceIndex(0).AtMapKey("landed"), knownvalue.Bool(false)),
+					},
+					Check: expectContentOnBranch("terraform/dir-test.txt", "bar"),
+				},
+			},
+		})
+	})
+
+	t.Run("manages_only_the_managed_block", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+
+		config := fmt.Sprintf(`
+resource "github_repository_file" "test" {
+  repository     = "%s"
+  file           = "README.md"
+  content        = "managed"
+  commit_message = "Managed by Terraform"
+
+  managed_block {}
+}
+`, repo.GetName())
+
+		readme := func() (*github.RepositoryContent, string, error) {
+			fc, _, _, err := testAccConf.meta.v3client.Repositories.GetContents(t.Context(), testAccConf.meta.name, repo.GetName(), "README.md", nil)
+			if err != nil {
+				return nil, "", err
+			}
+
+			content, err := fc.GetContent()
+			return fc, content, err
+		}
+
+		expectReadme := func(wantUnmanaged string) resource.TestCheckFunc {
+			return func(*terraform.State) error {
+				_, content, err := readme()
+				if err != nil {
+					return err
+				}
+
+				if !strings.Contains(content, wantUnmanaged) {
+					return fmt.Errorf("expected README.md to keep %q, got %q", wantUnmanaged, content)
+				}
+				if block := fmt.Sprintf("%s\nmanaged\n%s\n", managedBlockDefaultBeginMarker, managedBlockDefaultEndMarker); !strings.Contains(content, block) {
+					return fmt.Errorf("expected README.md to contain %q, got %q", block, content)
+				}
+				return nil
+			}
+		}
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check:  expectReadme(repo.GetName()),
+				},
+				{
+					PreConfig: func() {
+						fc, content, err := readme()
+						if err != nil {
+							t.Fatal(err)
+						}
+
+						_, _, err = testAccConf.meta.v3client.Repositories.UpdateFile(t.Context(), testAccConf.meta.name, repo.GetName(), "README.md", &github.RepositoryContentFileOptions{
+							Message: new("Edit outside the managed block"),
+							Content: []byte("edited by hand\n" + content),
+							SHA:     fc.SHA,
+						})
+						if err != nil {
+							t.Fatal(err)
+						}
+					},
+					Config: config,
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectEmptyPlan(),
+						},
+					},
+					Check: expectReadme("edited by hand"),
+				},
+			},
+		})
+	})
+}
+
+func Test_repositoryFilePullRequestBranch(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		file string
+		want string
+	}{
+		{file: "README.md", want: "terraform/README.md"},
+		{file: ".github/workflows/ci.yml", want: "terraform/github-workflows-ci.yml"},
+		{file: "dir/with space/file:name.txt", want: "terraform/dir-with-space-file-name.txt"},
+	} {
+		if got := repositoryFilePullRequestBranch(tt.file); got != tt.want {
+			t.Errorf("expected branch for %q to be %q, got %q", tt.file, tt.want, got)
+		}
+	}
+}
+
+func Test_fastForwardRepositoryFilePullRequestBranch(t *testing.T) {
+	t.Parallel()
+
+	const base = "1111111111111111111111111111111111111111"
+
+	for _, tt := range []struct {
+		name        string
+		existingSHA string
+		updateCode  int
+		wantCreate  bool
+		wantUpdate  bool
+		wantErr     string
+	}{
+		{name: "creates_missing_branch", wantCreate: true},
+		{name: "keeps_branch_at_base", existingSHA: base},
+		{name: "fast_forwards_branch", existingSHA: "2222222222222222222222222222222222222222", updateCode: http.StatusOK, wantUpdate: true},
+		{name: "fails_for_diverged_branch", existingSHA: "3333333333333333333333333333333333333333", updateCode: http.StatusUnprocessableEntity, wantUpdate: true, wantErr: "can't be fast-forwarded"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var created, updated bool
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/repos/test-org/test-repo/git/ref/heads/feature":
+					if tt.existingSHA == "" {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					_, _ = fmt.Fprintf(w, `{"ref": "refs/heads/feature", "object": {"sha": %q}}`, tt.existingSHA)
+				case r.Method == http.MethodPost && r.URL.Path == "/repos/test-org/test-repo/git/refs":
+					created = true
+					w.WriteHeader(http.StatusCreated)
+					_, _ = fmt.Fprintf(w, `{"ref": "refs/heads/feature", "object": {"sha": %q}}`, base)
+				case r.Method == http.MethodPatch && r.URL.Path == "/repos/test-org/test-repo/git/refs/heads/feature":
+					updated = true
+					var req github.UpdateRef
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GetForce() {
+						t.Errorf("expected a non-forced update, got %+v (%v)", req, err)
+					}
+					w.WriteHeader(tt.updateCode)
+					_, _ = fmt.Fprintf(w, `{"ref": "refs/heads/feature", "object": {"sha": %q}}`, base)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			t.Cleanup(ts.Close)
+
+			client, err := github.NewClient(github.WithURLs(new(ts.URL+"/"), nil))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err = fastForwardRepositoryFilePullRequestBranch(t.Context(), client, "test-org", "test-repo", "feature", "main", base)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if created != tt.wantCreate {
+				t.Errorf("expected branch created to be %t, got %t", tt.wantCreate, created)
+			}
+			if updated != tt.wantUpdate {
+				t.Errorf("expected branch updated to be %t, got %t", tt.wantUpdate, updated)
+			}
+		})
+	}
 }
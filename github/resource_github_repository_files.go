@@ -0,0 +1,561 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceGithubRepositoryFiles() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubRepositoryFilesCreate,
+		ReadContext:   resourceGithubRepositoryFilesRead,
+		UpdateContext: resourceGithubRepositoryFilesUpdate,
+		DeleteContext: resourceGithubRepositoryFilesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGithubRepositoryFilesImport,
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			diffRepository,
+			resourceGithubRepositoryFilesDiff,
+		),
+
+		Description: "Manages a set of files on a branch of a GitHub repository, writing every change to the files as a single commit.",
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The repository name.",
+			},
+			"repository_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The repository ID.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Description: "The branch name, defaults to the repository's default branch.",
+			},
+			"file": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The files to manage.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "The file path to manage.",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+						},
+						"content": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The file's content.",
+						},
+					},
+				},
+			},
+			"commit_message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The commit message when creating, updating or deleting the files.",
+			},
+			"commit_author": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The commit author name, defaults to the authenticated user's name. GitHub app users may omit author and email information so GitHub can verify commits as the GitHub App.",
+				RequiredWith: []string{"commit_email"},
+			},
+			"commit_email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The commit author email address, defaults to the authenticated user's email address. GitHub app users may omit author and email information so GitHub can verify commits as the GitHub App.",
+				RequiredWith: []string{"commit_author"},
+			},
+			"commit_sha": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA of the last commit that modified the files.",
+			},
+			"file_shas": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The blob SHAs of the files, keyed by path.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"overwrite_on_create": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable overwriting existing files when they're added to the resource, defaults to \"false\".",
+			},
+			"autocreate_branch": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				Description:      "Automatically create the branch if it could not be found.",
+				DiffSuppressFunc: autoBranchDiffSuppressFunc,
+			},
+			"autocreate_branch_source_branch": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "main",
+				Description:      "The branch name to start from, if 'autocreate_branch' is set. Defaults to 'main'.",
+				RequiredWith:     []string{"autocreate_branch"},
+				DiffSuppressFunc: autoBranchDiffSuppressFunc,
+			},
+			"autocreate_branch_source_sha": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The commit hash to start from, if 'autocreate_branch' is set. Defaults to the tip of 'autocreate_branch_source_branch'. If provided, 'autocreate_branch_source_branch' is ignored.",
+				RequiredWith:     []string{"autocreate_branch"},
+				DiffSuppressFunc: autoBranchDiffSuppressFunc,
+			},
+			"managed_block": managedBlockSchema("Manage only the block of each file between a begin and an end marker line, setting the `content` of the files to the content of their block and leaving the rest of the files alone."),
+		},
+	}
+}
+
+func resourceGithubRepositoryFilesDiff(ctx context.Context, d *schema.ResourceDiff, _ any) error {
+	if !d.HasChange("file") || !d.NewValueKnown("file") {
+		return nil
+	}
+
+	tflog.Debug(ctx, "Diffing repository files.")
+
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath("file"))
+	if diags.HasError() {
+		return fmt.Errorf("error reading file config: %v", diags)
+	}
+
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	it := v.ElementIterator()
+	for it.Next() {
+		_, elem := it.Element()
+		val := elem.GetAttr("path")
+		if val.IsNull() || !val.IsKnown() {
+			continue
+		}
+
+		path := val.AsString()
+		if strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") {
+			return fmt.Errorf("file path %s must not start or end with a slash", path)
+		}
+		if _, ok := seen[path]; ok {
+			return fmt.Errorf("duplicate file path %s found in files", path)
+		}
+		seen[path] = struct{}{}
+	}
+
+	return nil
+}
+
+func resourceGithubRepositoryFilesCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta, _ := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+
+	repoName, _ := d.Get("repository").(string)
+
+	ctx = tflog.SetField(ctx, "repository", repoName)
+	ctx = tflog.SetField(ctx, "owner", owner)
+
+	tflog.Debug(ctx, "Creating repository files.")
+
+	repo, _, err := client.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	branch := repo.GetDefaultBranch()
+	if v, ok := d.GetOk("branch"); ok {
+		branch, _ = v.(string)
+		if err := checkRepositoryBranchExists(ctx, client, owner, repoName, branch); err != nil {
+			if autocreate, _ := d.Get("autocreate_branch").(bool); !autocreate {
+				return diag.FromErr(err)
+			}
+
+			if err := resourceGithubRepositoryFileCreateBranch(ctx, d, m); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	ctx = tflog.SetField(ctx, "branch", branch)
+
+	files := expandRepositoryFiles(d.Get("file"))
+	paths := slices.Sorted(maps.Keys(files))
+
+	c := &repositoryFilesCommit{
+		message: repositoryFilesCommitMessage(d, "Add", paths),
+		author:  repositoryFilesCommitAuthor(d),
+		files:   make(map[string]*string, len(files)),
+		block:   expandManagedBlock(d),
+	}
+	for path, content := range files {
+		c.files[path] = new(content)
+	}
+	if overwrite, _ := d.Get("overwrite_on_create").(bool); !overwrite && c.block == nil {
+		c.create = paths
+	}
+
+	commit, err := commitRepositoryFiles(ctx, client, owner, repoName, branch, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := buildID(repoName, branch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := d.Set("branch", branch); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("repository_id", int(repo.GetID())); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("commit_sha", commit.GetSHA()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGithubRepositoryFilesRead(ctx, d, m)
+}
+
+func resourceGithubRepositoryFilesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta, _ := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+
+	repoName, _ := d.Get("repository").(string)
+	branch, _ := d.Get("branch").(string)
+
+	ctx = tflog.SetField(ctx, "repository", repoName)
+	ctx = tflog.SetField(ctx, "owner", owner)
+	ctx = tflog.SetField(ctx, "branch", branch)
+
+	tflog.Debug(ctx, "Reading repository files.")
+
+	head, _, err := client.Git.GetRef(ctx, owner, repoName, "refs/heads/"+branch)
+	if err != nil {
+		return diag.FromErr(deleteResourceOn404AndSwallow304OtherwiseReturnError(err, d, "repository files %s/%s:%s", owner, repoName, branch))
+	}
+
+	commit, _, err := client.Git.GetCommit(ctx, owner, repoName, head.GetObject().GetSHA())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	files := expandRepositoryFiles(d.Get("file"))
+	paths := slices.Sorted(maps.Keys(files))
+
+	entries, err := getRepositoryFileEntries(ctx, client, owner, repoName, commit, paths)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	block := expandManagedBlock(d)
+	knownShas, _ := d.Get("file_shas").(map[string]any)
+
+	flatFiles := make([]any, 0, len(entries))
+	shas := make(map[string]any, len(entries))
+	for _, path := range paths {
+		entry, ok := entries[path]
+		if !ok {
+			tflog.Info(ctx, "Repository file no longer exists on the branch", map[string]any{"file": path})
+			continue
+		}
+
+		content := files[path]
+		if block != nil {
+			if knownShas[path] != entry.GetSHA() {
+				tflog.Debug(ctx, "Repository file changed, reading managed block", map[string]any{"file": path})
+
+				raw, _, err := client.Git.GetBlobRaw(ctx, owner, repoName, entry.GetSHA())
+				if err != nil {
+					return diag.FromErr(err)
+				}
+
+				blockContent, ok := block.extract(string(raw))
+				if !ok {
+					tflog.Info(ctx, "Managed block not found in repository file", map[string]any{"file": path})
+				}
+				if !ok || !block.equal(blockContent, content) {
+					content = blockContent
+				}
+			}
+		} else if entry.GetSHA() != gitBlobSHA(content) {
+			tflog.Debug(ctx, "Repository file changed outside of Terraform", map[string]any{"file": path})
+
+			raw, _, err := client.Git.GetBlobRaw(ctx, owner, repoName, entry.GetSHA())
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			content = string(raw)
+		}
+
+		flatFiles = append(flatFiles, map[string]any{
+			"path":    path,
+			"content": content,
+		})
+		shas[path] = entry.GetSHA()
+	}
+
+	if err := d.Set("file", flatFiles); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("file_shas", shas); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if sha, _ := d.Get("commit_sha").(string); sha == "" && len(flatFiles) > 0 {
+		tflog.Debug(ctx, "Commit SHA unknown for files, looking for commit")
+
+		var last *github.RepositoryCommit
+		for path := range shas {
+			c, err := getFileCommit(ctx, client, owner, repoName, path, branch)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			if last == nil || c.GetCommit().GetCommitter().GetDate().After(last.GetCommit().GetCommitter().GetDate().Time) {
+				last = c
+			}
+		}
+
+		if err := d.Set("commit_sha", last.GetSHA()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceGithubRepositoryFilesUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta, _ := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+
+	repoName, _ := d.Get("repository").(string)
+	branch, _ := d.Get("branch").(string)
+
+	ctx = tflog.SetField(ctx, "repository", repoName)
+	ctx = tflog.SetField(ctx, "owner", owner)
+	ctx = tflog.SetField(ctx, "branch", branch)
+
+	tflog.Debug(ctx, "Updating repository files.")
+
+	if d.HasChange("file") {
+		oldFiles, newFiles := d.GetChange("file")
+
+		c := repositoryFilesChanges(expandRepositoryFiles(oldFiles), expandRepositoryFiles(newFiles))
+		c.block = expandManagedBlock(d)
+		if overwrite, _ := d.Get("overwrite_on_create").(bool); overwrite || c.block != nil {
+			c.create = nil
+		}
+		c.message = repositoryFilesCommitMessage(d, "Update", slices.Sorted(maps.Keys(c.files)))
+		c.author = repositoryFilesCommitAuthor(d)
+
+		commit, err := commitRepositoryFiles(ctx, client, owner, repoName, branch, c)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("commit_sha", commit.GetSHA()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("repository") {
+		id, err := buildID(repoName, branch)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(id)
+	}
+
+	return resourceGithubRepositoryFilesRead(ctx, d, m)
+}
+
+func resourceGithubRepositoryFilesDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta, _ := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+
+	repoName, _ := d.Get("repository").(string)
+	branch, _ := d.Get("branch").(string)
+
+	ctx = tflog.SetField(ctx, "repository", repoName)
+	ctx = tflog.SetField(ctx, "owner", owner)
+	ctx = tflog.SetField(ctx, "branch", branch)
+
+	tflog.Debug(ctx, "Deleting repository files.")
+
+	paths := slices.Sorted(maps.Keys(expandRepositoryFiles(d.Get("file"))))
+
+	c := &repositoryFilesCommit{
+		message: repositoryFilesCommitMessage(d, "Delete", paths),
+		author:  repositoryFilesCommitAuthor(d),
+		files:   make(map[string]*string, len(paths)),
+		block:   expandManagedBlock(d),
+	}
+	for _, path := range paths {
+		c.files[path] = nil
+	}
+
+	_, err := commitRepositoryFiles(ctx, client, owner, repoName, branch, c)
+	if ghErr, ok := errors.AsType[*github.ErrorResponse](err); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+		tflog.Info(ctx, "Branch no longer exists, removing repository files from state.")
+		return nil
+	}
+
+	return diag.FromErr(handleArchivedRepoDelete(err, "repository files", strings.Join(paths, ", "), owner, repoName))
+}
+
+func resourceGithubRepositoryFilesImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	repoName, branch, pathsPart, err := parseID3(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID specified. Supplied ID must be written as <repository>:<branch>:<file path>,<file path> (branch may be empty for the default branch). %w", err)
+	}
+
+	meta, _ := m.(*Owner)
+	client := meta.v3client
+	owner := meta.name
+
+	tflog.Debug(ctx, "Importing repository files.", map[string]any{"repository": repoName, "owner": owner})
+
+	repo, _, err := client.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	if branch == "" {
+		branch = repo.GetDefaultBranch()
+	}
+
+	files := make([]any, 0)
+	for path := range strings.SplitSeq(pathsPart, ",") {
+		if path = unescapeIDPart(strings.TrimSpace(path)); path != "" {
+			files = append(files, map[string]any{"path": path, "content": ""})
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no file paths specified in ID %s", d.Id())
+	}
+
+	id, err := buildID(repoName, branch)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(id)
+
+	if err := d.Set("repository", repoName); err != nil {
+		return nil, err
+	}
+	if err := d.Set("repository_id", int(repo.GetID())); err != nil {
+		return nil, err
+	}
+	if err := d.Set("branch", branch); err != nil {
+		return nil, err
+	}
+	if err := d.Set("file", files); err != nil {
+		return nil, err
+	}
+	if err := d.Set("overwrite_on_create", false); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandRepositoryFiles returns the content of the files of the file set, keyed by path.
+func expandRepositoryFiles(v any) map[string]string {
+	files := make(map[string]string)
+
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return files
+	}
+
+	for _, f := range set.List() {
+		file, _ := f.(map[string]any)
+		path, _ := file["path"].(string)
+		content, _ := file["content"].(string)
+		files[path] = content
+	}
+
+	return files
+}
+
+// repositoryFilesChanges returns the commit changing the files from the old to the new content; files which are only in the new files are expected not to exist yet, and files which are only in the old files are deleted.
+func repositoryFilesChanges(oldFiles, newFiles map[string]string) *repositoryFilesCommit {
+	c := &repositoryFilesCommit{files: make(map[string]*string)}
+
+	for _, path := range slices.Sorted(maps.Keys(newFiles)) {
+		content := newFiles[path]
+		oldContent, ok := oldFiles[path]
+		if !ok {
+			c.create = append(c.create, path)
+		}
+		if !ok || oldContent != content {
+			c.files[path] = new(content)
+		}
+	}
+
+	for path := range oldFiles {
+		if _, ok := newFiles[path]; !ok {
+			c.files[path] = nil
+		}
+	}
+
+	return c
+}
+
+// repositoryFilesCommitMessage returns the configured commit message, or a message describing the change to the paths.
+func repositoryFilesCommitMessage(d *schema.ResourceData, verb string, paths []string) string {
+	if message, ok := d.GetOk("commit_message"); ok {
+		return message.(string)
+	}
+
+	if len(paths) == 1 {
+		return fmt.Sprintf("%s %s", verb, paths[0])
+	}
+
+	return fmt.Sprintf("%s %d files", verb, len(paths))
+}
+
+// repositoryFilesCommitAuthor returns the configured commit author, or nil to commit as the authenticated user.
+func repositoryFilesCommitAuthor(d *schema.ResourceData) *github.CommitAuthor {
+	commitAuthor, hasCommitAuthor := d.GetOk("commit_author")
+	commitEmail, hasCommitEmail := d.GetOk("commit_email")
+	if !hasCommitAuthor || !hasCommitEmail {
+		return nil
+	}
+
+	return &github.CommitAuthor{Name: new(commitAuthor.(string)), Email: new(commitEmail.(string))}
+}
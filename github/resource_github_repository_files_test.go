@@ -0,0 +1,219 @@
+package github
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccGithubRepositoryFiles(t *testing.T) {
+	t.Parallel()
+
+	// expectBranchHead checks that the branch head is the commit recorded by the resource, i.e. that the files were written as a single commit.
+	expectBranchHead := func(repoName, branch string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			rs, ok := s.RootModule().Resources["github_repository_files.test"]
+			if !ok {
+				return fmt.Errorf("resource github_repository_files.test not found in state")
+			}
+
+			ref, _, err := testAccConf.meta.v3client.Git.GetRef(t.Context(), testAccConf.meta.name, repoName, "refs/heads/"+branch)
+			if err != nil {
+				return err
+			}
+
+			if got, want := ref.GetObject().GetSHA(), rs.Primary.Attributes["commit_sha"]; got != want {
+				return fmt.Errorf("expected branch %s head to be %s, got %s", branch, want, got)
+			}
+
+			return nil
+		}
+	}
+
+	t.Run("manages_files_in_single_commits", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+
+		config := `
+resource "github_repository_files" "test" {
+  repository     = "%s"
+  commit_message = "Managed by Terraform"
+  commit_author  = "Terraform User"
+  commit_email   = "terraform@example.com"
+
+  file {
+    path    = "a.txt"
+    content = "%s"
+  }
+
+  file {
+    path    = "%s"
+    content = "bar"
+  }
+}
+`
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, repo.GetName(), "foo", "dir/b.txt"),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_repository_files.test", tfjsonpath.New("repository_id"), knownvalue.Int64Exact(repo.GetID())),
+						statecheck.ExpectKnownValue("github_repository_files.test", tfjsonpath.New("branch"), knownvalue.StringExact(repo.GetDefaultBranch())),
+						statecheck.ExpectKnownValue("github_repository_files.test", tfjsonpath.New("file_shas"), knownvalue.MapExact(map[string]knownvalue.Check{
+							"a.txt":     knownvalue.StringExact(gitBlobSHA("foo")),
+							"dir/b.txt": knownvalue.StringExact(gitBlobSHA("bar")),
+						})),
+					},
+					Check: expectBranchHead(repo.GetName(), repo.GetDefaultBranch()),
+				},
+				{
+					Config: fmt.Sprintf(config, repo.GetName(), "foo2", "dir/c.txt"),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_repository_files.test", plancheck.ResourceActionUpdate),
+						},
+					},
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_repository_files.test", tfjsonpath.New("file_shas"), knownvalue.MapExact(map[string]knownvalue.Check{
+							"a.txt":     knownvalue.StringExact(gitBlobSHA("foo2")),
+							"dir/c.txt": knownvalue.StringExact(gitBlobSHA("bar")),
+						})),
+					},
+					Check: expectBranchHead(repo.GetName(), repo.GetDefaultBranch()),
+				},
+				{
+					ResourceName:            "github_repository_files.test",
+					ImportState:             true,
+					ImportStateId:           fmt.Sprintf("%s::a.txt,dir/c.txt", repo.GetName()),
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"commit_message", "commit_author", "commit_email"},
+				},
+			},
+		})
+	})
+
+	t.Run("refuses_to_overwrite_existing_files", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+
+		config := `
+resource "github_repository_files" "test" {
+  repository          = "%s"
+  overwrite_on_create = %t
+
+  file {
+    path    = "README.md"
+    content = "overwritten"
+  }
+}
+`
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      fmt.Sprintf(config, repo.GetName(), false),
+					ExpectError: regexp.MustCompile("refusing to overwrite existing files README.md"),
+				},
+				{
+					Config: fmt.Sprintf(config, repo.GetName(), true),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_repository_files.test", tfjsonpath.New("file_shas").AtMapKey("README.md"), knownvalue.StringExact(gitBlobSHA("overwritten"))),
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("autocreates_branch", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+
+		config := fmt.Sprintf(`
+resource "github_repository_files" "test" {
+  repository        = "%s"
+  branch            = "files"
+  autocreate_branch = true
+
+  autocreate_branch_source_branch = "%s"
+
+  file {
+    path    = "a.txt"
+    content = "foo"
+  }
+}
+`, repo.GetName(), repo.GetDefaultBranch())
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_repository_files.test", tfjsonpath.New("branch"), knownvalue.StringExact("files")),
+						statecheck.ExpectKnownValue("github_repository_files.test", tfjsonpath.New("autocreate_branch_source_sha"), knownvalue.NotNull()),
+					},
+					Check: expectBranchHead(repo.GetName(), "files"),
+				},
+			},
+		})
+	})
+}
+
+func Test_gitBlobSHA(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		content string
+		want    string
+	}{
+		{content: "", want: "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"},
+		{content: "bar", want: "ba0e162e1c47469e3fe4b393a8bf8c569f302116"},
+		{content: "hello world\n", want: "3b18e512dba79e4c8300dd08aeb37f8e728b8dad"},
+	} {
+		if got := gitBlobSHA(tt.content); got != tt.want {
+			t.Errorf("expected blob SHA of %q to be %s, got %s", tt.content, tt.want, got)
+		}
+	}
+}
+
+func Test_repositoryFilesChanges(t *testing.T) {
+	t.Parallel()
+
+	c := repositoryFilesChanges(
+		map[string]string{"same.txt": "a", "changed.txt": "b", "removed.txt": "c"},
+		map[string]string{"same.txt": "a", "changed.txt": "b2", "added.txt": "d"},
+	)
+
+	if want := []string{"added.txt"}; !slices.Equal(c.create, want) {
+		t.Errorf("expected created files to be %v, got %v", want, c.create)
+	}
+
+	if want, got := []string{"added.txt", "changed.txt", "removed.txt"}, slices.Sorted(maps.Keys(c.files)); !slices.Equal(got, want) {
+		t.Fatalf("expected changed files to be %v, got %v", want, got)
+	}
+
+	for path, want := range map[string]*string{"added.txt": new("d"), "changed.txt": new("b2"), "removed.txt": nil} {
+		got := c.files[path]
+		if (got == nil) != (want == nil) || got != nil && *got != *want {
+			t.Errorf("expected content of %s to be %v, got %v", path, want, got)
+		}
+	}
+}
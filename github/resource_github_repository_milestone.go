@@ -95,11 +95,15 @@ const (
 )
 
 func resourceGithubRepositoryMilestoneCreate(d *schema.ResourceData, meta any) error {
-	conn := meta.(*Owner).v3client
 	ctx := context.Background()
 	owner := d.Get("owner").(string)
 	repoName := d.Get("repository").(string)
 
+	conn, err := meta.(*Owner).restClient(ctx, owner)
+	if err != nil {
+		return err
+	}
+
 	milestone := &github.Milestone{
 		Title: new(d.Get("title").(string)),
 	}
@@ -121,7 +125,7 @@ func resourceGithubRepositoryMilestoneCreate(d *schema.ResourceData, meta any) e
 		milestone.State = new(v.(string))
 	}
 
-	milestone, _, err := conn.Issues.CreateMilestone(ctx, owner, repoName, milestone)
+	milestone, _, err = conn.Issues.CreateMilestone(ctx, owner, repoName, milestone)
 	if err != nil {
 		return err
 	}
@@ -132,11 +136,16 @@ func resourceGithubRepositoryMilestoneCreate(d *schema.ResourceData, meta any) e
 }
 
 func resourceGithubRepositoryMilestoneRead(d *schema.ResourceData, meta any) error {
-	conn := meta.(*Owner).v3client
 	ctx := context.WithValue(context.Background(), ctxId, d.Id())
 
 	owner := d.Get("owner").(string)
 	repoName := d.Get("repository").(string)
+
+	conn, err := meta.(*Owner).restClient(ctx, owner)
+	if err != nil {
+		return err
+	}
+
 	number, err := parseMilestoneNumber(d.Id())
 	if err != nil {
 		return err
@@ -181,10 +190,15 @@ func resourceGithubRepositoryMilestoneRead(d *schema.ResourceData, meta any) err
 }
 
 func resourceGithubRepositoryMilestoneUpdate(d *schema.ResourceData, meta any) error {
-	conn := meta.(*Owner).v3client
 	ctx := context.WithValue(context.Background(), ctxId, d.Id())
 	owner := d.Get("owner").(string)
 	repoName := d.Get("repository").(string)
+
+	conn, err := meta.(*Owner).restClient(ctx, owner)
+	if err != nil {
+		return err
+	}
+
 	number, err := parseMilestoneNumber(d.Id())
 	if err != nil {
 		return err
@@ -227,10 +241,15 @@ func resourceGithubRepositoryMilestoneUpdate(d *schema.ResourceData, meta any) e
 }
 
 func resourceGithubRepositoryMilestoneDelete(d *schema.ResourceData, meta any) error {
-	conn := meta.(*Owner).v3client
 	ctx := context.WithValue(context.Background(), ctxId, d.Id())
 	owner := d.Get("owner").(string)
 	repoName := d.Get("repository").(string)
+
+	conn, err := meta.(*Owner).restClient(ctx, owner)
+	if err != nil {
+		return err
+	}
+
 	number, err := parseMilestoneNumber(d.Id())
 	if err != nil {
 		return err
@@ -0,0 +1,643 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	secretSetTypeActions    = "actions"
+	secretSetTypeCodespaces = "codespaces"
+	secretSetTypeDependabot = "dependabot"
+)
+
+// secretSetTypes are the secret types supported by github_secret_set, in the order the secrets of each target are managed.
+var secretSetTypes = []string{secretSetTypeActions, secretSetTypeCodespaces, secretSetTypeDependabot}
+
+func resourceGithubSecretSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubSecretSetCreate,
+		ReadContext:   resourceGithubSecretSetRead,
+		UpdateContext: resourceGithubSecretSetUpdate,
+		DeleteContext: resourceGithubSecretSetDelete,
+
+		CustomizeDiff: diffSecretSet,
+
+		Description: "Resource to manage a secret with the same value across multiple GitHub Actions, Codespaces and Dependabot repositories, environments and organizations.",
+
+		Schema: map[string]*schema.Schema{
+			"secret_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateSecretNameFunc,
+				Description:      "Name of the secret.",
+			},
+			"value": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"value", "value_wo"},
+				Description:  "Plaintext value of the secret, which is encrypted with the public key of each target.",
+			},
+			"value_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"value", "value_wo"},
+				RequiredWith: []string{"value_wo_version"},
+				Description:  "Write-only plaintext value of the secret, which is encrypted with the public key of each target and is never stored in the state. Requires Terraform 1.11 or later.",
+			},
+			"value_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"value_wo"},
+				Description:  "Version of `value_wo`, which must be changed to update the secrets with a new `value_wo`.",
+			},
+			"secret_types": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(secretSetTypes, false)),
+				},
+				Description: "The types of secret to create for each target. Must be one or more of `actions`, `codespaces` or `dependabot`.",
+			},
+			"target": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The repositories, environments and organization to create the secrets in.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"repository": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of the repository to create the secrets in; if not set the secrets are created in the organization.",
+						},
+						"environment": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of the repository environment to create the secret in. Environments only support the `actions` secret type, so only an Actions secret is created for this target.",
+						},
+						"visibility": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"all", "private", "selected"}, false)),
+							Description:      "Configures the access that repositories have to the organization secrets. Must be one of `all`, `private` or `selected`; required for organization targets.",
+						},
+						"selected_repository_ids": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Set:         schema.HashInt,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "An array of repository IDs that can access the organization secrets, when `visibility` is `selected`.",
+						},
+					},
+				},
+			},
+			"drift_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(secretDriftPolicies, false)),
+				Description:      "How to plan the secrets when any of them has been updated outside of Terraform. Must be one of `overwrite_in_place`, `recreate`, `ignore` or `error`; defaults to `overwrite_in_place`.",
+			},
+			"secrets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The secrets managed for each target and secret type.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the secret.",
+						},
+						"repository": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the repository of the secret, or empty for an organization secret.",
+						},
+						"environment": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the environment of the secret, or empty for a repository or organization secret.",
+						},
+						"key_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the public key used to encrypt the secret.",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Timestamp of when the secret was created.",
+						},
+						"updated_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Timestamp of when the secret was last updated by the provider.",
+						},
+						"remote_updated_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Timestamp of when the secret was last updated, or empty if the secret no longer exists.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// secretSetSecret is a secret managed by github_secret_set, as stored in the secrets attribute.
+type secretSetSecret struct {
+	secretType      string
+	repository      string
+	environment     string
+	visibility      string
+	repositoryIDs   []int64
+	keyID           string
+	createdAt       string
+	updatedAt       string
+	remoteUpdatedAt string
+}
+
+// key returns a key identifying the secret within the set.
+func (s *secretSetSecret) key() string {
+	return s.secretType + "/" + s.repository + "/" + s.environment
+}
+
+// scope returns a description of where the secret is for logs and errors.
+func (s *secretSetSecret) scope() string {
+	switch {
+	case s.environment != "":
+		return fmt.Sprintf("%s secret in environment %s of repository %s", s.secretType, s.environment, s.repository)
+	case s.repository != "":
+		return fmt.Sprintf("%s secret in repository %s", s.secretType, s.repository)
+	default:
+		return fmt.Sprintf("%s organization secret", s.secretType)
+	}
+}
+
+// expandSecretSetSecrets returns the secrets to manage for the targets and secret types; environment targets only have an Actions secret.
+func expandSecretSetSecrets(targets []any, secretTypes []string) []*secretSetSecret {
+	var secrets []*secretSetSecret
+	for _, t := range targets {
+		target, _ := t.(map[string]any)
+		if target == nil {
+			continue
+		}
+
+		repository, _ := target["repository"].(string)
+		environment, _ := target["environment"].(string)
+		visibility, _ := target["visibility"].(string)
+
+		var repositoryIDs []int64
+		if ids, ok := target["selected_repository_ids"].(*schema.Set); ok {
+			for _, id := range ids.List() {
+				repositoryIDs = append(repositoryIDs, int64(id.(int)))
+			}
+		}
+
+		for _, secretType := range secretSetTypes {
+			if !slices.Contains(secretTypes, secretType) || (environment != "" && secretType != secretSetTypeActions) {
+				continue
+			}
+
+			secrets = append(secrets, &secretSetSecret{
+				secretType:    secretType,
+				repository:    repository,
+				environment:   environment,
+				visibility:    visibility,
+				repositoryIDs: repositoryIDs,
+			})
+		}
+	}
+
+	return secrets
+}
+
+// flattenSecretSetSecrets returns the value of the secrets attribute.
+func flattenSecretSetSecrets(secrets []*secretSetSecret) []any {
+	result := make([]any, 0, len(secrets))
+	for _, s := range secrets {
+		result = append(result, map[string]any{
+			"type":              s.secretType,
+			"repository":        s.repository,
+			"environment":       s.environment,
+			"key_id":            s.keyID,
+			"created_at":        s.createdAt,
+			"updated_at":        s.updatedAt,
+			"remote_updated_at": s.remoteUpdatedAt,
+		})
+	}
+
+	return result
+}
+
+// expandSecretSetState returns the secrets from the value of the secrets attribute.
+func expandSecretSetState(v any) []*secretSetSecret {
+	l, _ := v.([]any)
+
+	secrets := make([]*secretSetSecret, 0, len(l))
+	for _, raw := range l {
+		m, _ := raw.(map[string]any)
+		if m == nil {
+			continue
+		}
+
+		s := &secretSetSecret{}
+		s.secretType, _ = m["type"].(string)
+		s.repository, _ = m["repository"].(string)
+		s.environment, _ = m["environment"].(string)
+		s.keyID, _ = m["key_id"].(string)
+		s.createdAt, _ = m["created_at"].(string)
+		s.updatedAt, _ = m["updated_at"].(string)
+		s.remoteUpdatedAt, _ = m["remote_updated_at"].(string)
+		secrets = append(secrets, s)
+	}
+
+	return secrets
+}
+
+// getSecretSetPublicKey returns the ID and value of the public key to encrypt the secret with.
+func getSecretSetPublicKey(ctx context.Context, meta *Owner, s *secretSetSecret) (string, string, error) {
+	switch {
+	case s.environment != "":
+		return getEnvironmentPublicKeyDetails(ctx, meta, meta.name, s.repository, url.PathEscape(s.environment))
+	case s.secretType == secretSetTypeActions && s.repository != "":
+		return getPublicKeyDetails(ctx, meta, s.repository)
+	case s.secretType == secretSetTypeActions:
+		return getOrganizationPublicKeyDetails(ctx, meta)
+	case s.secretType == secretSetTypeDependabot && s.repository != "":
+		return getDependabotPublicKeyDetails(ctx, meta, s.repository)
+	case s.secretType == secretSetTypeDependabot:
+		return getDependabotOrganizationPublicKeyDetails(ctx, meta)
+	case s.repository != "":
+		return getCodespacesPublicKeyDetails(meta.name, s.repository, meta)
+	default:
+		return getCodespacesOrganizationPublicKeyDetails(meta.name, meta)
+	}
+}
+
+// putSecretSetSecret creates or updates the secret with the value encrypted with the key.
+func putSecretSetSecret(ctx context.Context, meta *Owner, name string, s *secretSetSecret, keyID, encryptedValue string) error {
+	client := meta.v3client
+	owner := meta.name
+
+	var err error
+	switch {
+	case s.environment != "":
+		_, err = client.Actions.CreateOrUpdateEnvSecret(ctx, owner, s.repository, url.PathEscape(s.environment), name, github.SecretRequest{KeyID: keyID, EncryptedValue: encryptedValue})
+	case s.secretType == secretSetTypeActions && s.repository != "":
+		_, err = client.Actions.CreateOrUpdateRepoSecret(ctx, owner, s.repository, name, github.SecretRequest{KeyID: keyID, EncryptedValue: encryptedValue})
+	case s.secretType == secretSetTypeActions:
+		_, err = client.Actions.CreateOrUpdateOrgSecret(ctx, owner, name, github.OrgSecretRequest{KeyID: keyID, EncryptedValue: encryptedValue, Visibility: s.visibility, SelectedRepositoryIDs: s.repositoryIDs})
+	case s.secretType == secretSetTypeDependabot && s.repository != "":
+		_, err = client.Dependabot.CreateOrUpdateRepoSecret(ctx, owner, s.repository, &github.DependabotEncryptedSecret{Name: name, KeyID: keyID, EncryptedValue: encryptedValue})
+	case s.secretType == secretSetTypeDependabot:
+		_, err = client.Dependabot.CreateOrUpdateOrgSecret(ctx, owner, &github.DependabotEncryptedSecret{Name: name, KeyID: keyID, EncryptedValue: encryptedValue, Visibility: s.visibility, SelectedRepositoryIDs: s.repositoryIDs})
+	case s.repository != "":
+		_, err = client.Codespaces.CreateOrUpdateRepoSecret(ctx, owner, s.repository, &github.EncryptedSecret{Name: name, KeyID: keyID, EncryptedValue: encryptedValue})
+	default:
+		_, err = client.Codespaces.CreateOrUpdateOrgSecret(ctx, owner, &github.EncryptedSecret{Name: name, KeyID: keyID, EncryptedValue: encryptedValue, Visibility: s.visibility, SelectedRepositoryIDs: s.repositoryIDs})
+	}
+
+	return err
+}
+
+// getSecretSetSecret returns the secret from GitHub.
+func getSecretSetSecret(ctx context.Context, meta *Owner, name string, s *secretSetSecret) (*github.Secret, error) {
+	client := meta.v3client
+	owner := meta.name
+
+	var secret *github.Secret
+	var err error
+	switch {
+	case s.environment != "":
+		secret, _, err = client.Actions.GetEnvSecret(ctx, owner, s.repository, url.PathEscape(s.environment), name)
+	case s.secretType == secretSetTypeActions && s.repository != "":
+		secret, _, err = client.Actions.GetRepoSecret(ctx, owner, s.repository, name)
+	case s.secretType == secretSetTypeActions:
+		secret, _, err = client.Actions.GetOrgSecret(ctx, owner, name)
+	case s.secretType == secretSetTypeDependabot && s.repository != "":
+		secret, _, err = client.Dependabot.GetRepoSecret(ctx, owner, s.repository, name)
+	case s.secretType == secretSetTypeDependabot:
+		secret, _, err = client.Dependabot.GetOrgSecret(ctx, owner, name)
+	case s.repository != "":
+		secret, _, err = client.Codespaces.GetRepoSecret(ctx, owner, s.repository, name)
+	default:
+		secret, _, err = client.Codespaces.GetOrgSecret(ctx, owner, name)
+	}
+
+	return secret, err
+}
+
+// deleteSecretSetSecret deletes the secret, ignoring secrets that no longer exist.
+func deleteSecretSetSecret(ctx context.Context, meta *Owner, name string, s *secretSetSecret) error {
+	client := meta.v3client
+	owner := meta.name
+
+	var err error
+	switch {
+	case s.environment != "":
+		_, err = client.Actions.DeleteEnvSecret(ctx, owner, s.repository, url.PathEscape(s.environment), name)
+	case s.secretType == secretSetTypeActions && s.repository != "":
+		_, err = client.Actions.DeleteRepoSecret(ctx, owner, s.repository, name)
+	case s.secretType == secretSetTypeActions:
+		_, err = client.Actions.DeleteOrgSecret(ctx, owner, name)
+	case s.secretType == secretSetTypeDependabot && s.repository != "":
+		_, err = client.Dependabot.DeleteRepoSecret(ctx, owner, s.repository, name)
+	case s.secretType == secretSetTypeDependabot:
+		_, err = client.Dependabot.DeleteOrgSecret(ctx, owner, name)
+	case s.repository != "":
+		_, err = client.Codespaces.DeleteRepoSecret(ctx, owner, s.repository, name)
+	default:
+		_, err = client.Codespaces.DeleteOrgSecret(ctx, owner, name)
+	}
+
+	if ghErr, ok := errors.AsType[*github.ErrorResponse](err); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// writeSecretSetSecrets encrypts the value with the public key of each secret and creates or updates it; it returns the secrets that were written along with any error.
+func writeSecretSetSecrets(ctx context.Context, meta *Owner, name, value string, secrets []*secretSetSecret) ([]*secretSetSecret, error) {
+	written := make([]*secretSetSecret, 0, len(secrets))
+	for _, s := range secrets {
+		tflog.Debug(ctx, "Writing secret", map[string]any{"secret_name": name, "scope": s.scope()})
+		keyID, err := putSecret(ctx, meta, "", "", value, func() (string, string, error) {
+			return getSecretSetPublicKey(ctx, meta, s)
+		}, func(keyID, encryptedValue string) error {
+			return putSecretSetSecret(ctx, meta, name, s, keyID, encryptedValue)
+		})
+		if err != nil {
+			return written, fmt.Errorf("error writing the %s: %w", s.scope(), err)
+		}
+		s.keyID = keyID
+
+		// GitHub API does not return on create or update so we have to lookup the secret to get timestamps.
+		if secret, err := retryUntilResourceFound(ctx, func() (*github.Secret, error) {
+			return getSecretSetSecret(ctx, meta, name, s)
+		}, nil); err == nil {
+			s.createdAt = secret.CreatedAt.String()
+			s.updatedAt = secret.UpdatedAt.String()
+			s.remoteUpdatedAt = secret.UpdatedAt.String()
+		}
+
+		written = append(written, s)
+	}
+
+	return written, nil
+}
+
+// getSecretSetValue returns the plaintext value of the secrets from the configuration.
+func getSecretSetValue(d *schema.ResourceData) string {
+	value, ok := getWriteOnlyString(d, "value_wo")
+	if !ok {
+		value, _ = d.Get("value").(string)
+	}
+
+	return value
+}
+
+func resourceGithubSecretSetCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta, _ := m.(*Owner)
+
+	secretName, _ := d.Get("secret_name").(string)
+	secretTypes := expandStringList(d.Get("secret_types").(*schema.Set).List())
+	secrets := expandSecretSetSecrets(d.Get("target").([]any), secretTypes)
+
+	d.SetId(secretName)
+
+	written, err := writeSecretSetSecrets(ctx, meta, secretName, getSecretSetValue(d), secrets)
+	if setErr := d.Set("secrets", flattenSecretSetSecrets(written)); setErr != nil {
+		return diag.FromErr(setErr)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubSecretSetRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta, _ := m.(*Owner)
+
+	secretName, _ := d.Get("secret_name").(string)
+	secrets := expandSecretSetState(d.Get("secrets"))
+
+	found := false
+	for _, s := range secrets {
+		secret, err := getSecretSetSecret(ctx, meta, secretName, s)
+		if err != nil {
+			if ghErr, ok := errors.AsType[*github.ErrorResponse](err); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+				tflog.Info(ctx, "Secret of secret set no longer exists in GitHub", map[string]any{"secret_name": secretName, "scope": s.scope()})
+				s.remoteUpdatedAt = ""
+				continue
+			}
+			return diag.FromErr(err)
+		}
+
+		found = true
+
+		// Due to the eventually consistent behavior of this API we may not get created_at/updated_at
+		// values on the first read after creation, so we only set them here if they are not already set.
+		if len(s.createdAt) == 0 {
+			s.createdAt = secret.CreatedAt.String()
+		}
+		if len(s.updatedAt) == 0 {
+			s.updatedAt = secret.UpdatedAt.String()
+		}
+		s.remoteUpdatedAt = secret.UpdatedAt.String()
+	}
+
+	if !found && len(secrets) > 0 {
+		tflog.Info(ctx, "Removing secret set from state because none of its secrets exist in GitHub", map[string]any{"secret_name": secretName})
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("secrets", flattenSecretSetSecrets(secrets)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubSecretSetUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	if onlySecretDriftPolicyChanged(d) {
+		return nil
+	}
+
+	meta, _ := m.(*Owner)
+
+	secretName, _ := d.Get("secret_name").(string)
+	secretTypes := expandStringList(d.Get("secret_types").(*schema.Set).List())
+	secrets := expandSecretSetSecrets(d.Get("target").([]any), secretTypes)
+
+	oldSecrets, _ := d.GetChange("secrets")
+	current := expandSecretSetState(oldSecrets)
+
+	// Delete the secrets of targets and types that have been removed from the configuration.
+	remaining := make([]*secretSetSecret, 0, len(current))
+	for _, s := range current {
+		if slices.ContainsFunc(secrets, func(o *secretSetSecret) bool { return o.key() == s.key() }) {
+			remaining = append(remaining, s)
+			continue
+		}
+
+		tflog.Info(ctx, "Deleting secret removed from secret set", map[string]any{"secret_name": secretName, "scope": s.scope()})
+		if err := deleteSecretSetSecret(ctx, meta, secretName, s); err != nil {
+			if setErr := d.Set("secrets", flattenSecretSetSecrets(remaining)); setErr != nil {
+				return diag.FromErr(setErr)
+			}
+			return diag.Errorf("error deleting the %s: %v", s.scope(), err)
+		}
+	}
+
+	written, err := writeSecretSetSecrets(ctx, meta, secretName, getSecretSetValue(d), secrets)
+	if err != nil {
+		// Keep the secrets which were written or existed before in the state, so that they're deleted with the resource.
+		for _, s := range remaining {
+			if !slices.ContainsFunc(written, func(o *secretSetSecret) bool { return o.key() == s.key() }) {
+				written = append(written, s)
+			}
+		}
+	}
+	if setErr := d.Set("secrets", flattenSecretSetSecrets(written)); setErr != nil {
+		return diag.FromErr(setErr)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubSecretSetDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	meta, _ := m.(*Owner)
+
+	secretName, _ := d.Get("secret_name").(string)
+
+	for _, s := range expandSecretSetState(d.Get("secrets")) {
+		tflog.Info(ctx, "Deleting secret of secret set", map[string]any{"secret_name": secretName, "scope": s.scope()})
+		if err := deleteSecretSetSecret(ctx, meta, secretName, s); err != nil {
+			return diag.Errorf("error deleting the %s: %v", s.scope(), err)
+		}
+	}
+
+	return nil
+}
+
+// diffSecretSet validates the targets of a github_secret_set and plans an update of every secret when the configuration changes, or when any secret has drifted as set by the drift_policy field.
+func diffSecretSet(ctx context.Context, diff *schema.ResourceDiff, _ any) error {
+	secretTypes := expandStringList(diff.Get("secret_types").(*schema.Set).List())
+
+	targets, _ := diff.Get("target").([]any)
+	seen := map[string]int{}
+	for i, t := range targets {
+		target, _ := t.(map[string]any)
+		if target == nil {
+			continue
+		}
+
+		known := func(key string) bool {
+			return diff.NewValueKnown(fmt.Sprintf("target.%d.%s", i, key))
+		}
+		if !known("repository") || !known("environment") || !known("visibility") {
+			continue
+		}
+
+		repository, _ := target["repository"].(string)
+		environment, _ := target["environment"].(string)
+		visibility, _ := target["visibility"].(string)
+		repositoryIDs, _ := target["selected_repository_ids"].(*schema.Set)
+
+		if j, ok := seen[repository+"/"+environment]; ok {
+			return fmt.Errorf("target %d: duplicates target %d", i, j)
+		}
+		seen[repository+"/"+environment] = i
+
+		switch {
+		case environment != "" && repository == "":
+			return fmt.Errorf("target %d: environment can only be set with repository", i)
+		case environment != "" && !slices.Contains(secretTypes, secretSetTypeActions):
+			return fmt.Errorf("target %d: environment targets require the %s secret type", i, secretSetTypeActions)
+		case repository != "" && visibility != "":
+			return fmt.Errorf("target %d: visibility can only be set for organization targets", i)
+		case repository == "" && visibility == "":
+			return fmt.Errorf("target %d: visibility must be set for organization targets", i)
+		case visibility != "selected" && repositoryIDs != nil && repositoryIDs.Len() > 0:
+			return fmt.Errorf("target %d: cannot use selected_repository_ids without visibility being set to selected", i)
+		}
+	}
+
+	if len(diff.Id()) == 0 {
+		return nil
+	}
+
+	var drifted []string
+	for _, s := range expandSecretSetState(diff.Get("secrets")) {
+		if s.updatedAt != s.remoteUpdatedAt {
+			drifted = append(drifted, s.scope())
+		}
+	}
+
+	policy, _ := diff.Get("drift_policy").(string)
+	if len(drifted) > 0 {
+		tflog.Info(ctx, "Secret drift detected from timestamp fields.", map[string]any{"secret_name": diff.Id(), "drift_policy": policy, "scopes": drifted})
+
+		switch policy {
+		case secretDriftPolicyError:
+			return fmt.Errorf("secret %s has been updated outside of Terraform, so its value has been rotated externally, for the %s; set drift_policy to overwrite_in_place or recreate to replace it with the configured value, or to ignore to keep it", diff.Id(), strings.Join(drifted, ", "))
+		case secretDriftPolicyRecreate:
+			if err := diff.SetNewComputed("secrets"); err != nil {
+				return err
+			}
+			return diff.ForceNew("secrets")
+		case secretDriftPolicyIgnore:
+			drifted = nil
+		}
+	}
+
+	if len(drifted) > 0 || diff.HasChanges("value", "value_wo_version", "secret_types") || hasSecretSetTargetChanges(diff) {
+		return diff.SetNewComputed("secrets")
+	}
+
+	return nil
+}
+
+// hasSecretSetTargetChanges returns true if any target has changed. The targets are compared field by field, as a list holding sets always differs when it's compared as a whole.
+func hasSecretSetTargetChanges(diff *schema.ResourceDiff) bool {
+	if diff.HasChange("target.#") {
+		return true
+	}
+
+	targets, _ := diff.Get("target").([]any)
+	for i := range targets {
+		for _, key := range []string{"repository", "environment", "visibility", "selected_repository_ids"} {
+			if diff.HasChange(fmt.Sprintf("target.%d.%s", i, key)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
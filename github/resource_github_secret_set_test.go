@@ -0,0 +1,227 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpandSecretSetSecrets(t *testing.T) {
+	t.Parallel()
+
+	targets := []any{
+		map[string]any{"repository": "repo", "environment": "", "visibility": "", "selected_repository_ids": schema.NewSet(schema.HashInt, nil)},
+		map[string]any{"repository": "repo", "environment": "production", "visibility": "", "selected_repository_ids": schema.NewSet(schema.HashInt, nil)},
+		map[string]any{"repository": "", "environment": "", "visibility": "selected", "selected_repository_ids": schema.NewSet(schema.HashInt, []any{1, 2})},
+	}
+
+	secrets := expandSecretSetSecrets(targets, []string{"dependabot", "actions"})
+
+	want := []string{"actions/repo/", "dependabot/repo/", "actions/repo/production", "actions//", "dependabot//"}
+	if len(secrets) != len(want) {
+		t.Fatalf("expected %d secrets, got %d", len(want), len(secrets))
+	}
+	for i, s := range secrets {
+		if s.key() != want[i] {
+			t.Errorf("expected secret %d to be %q, got %q", i, want[i], s.key())
+		}
+	}
+
+	org := secrets[3]
+	if org.visibility != "selected" || len(org.repositoryIDs) != 2 {
+		t.Errorf("expected the organization secret to be visible to the selected repositories, got %q %v", org.visibility, org.repositoryIDs)
+	}
+}
+
+func TestGithubSecretSet(t *testing.T) {
+	t.Parallel()
+
+	skipUnlessTerraform(t)
+
+	t.Run("manages_secrets_and_detects_drift", func(t *testing.T) {
+		t.Parallel()
+
+		fake, providerConfig := newFakeGitHub(t, "test-org")
+		client := fake.Client(t)
+
+		for _, name := range []string{"test-repo-1", "test-repo-2"} {
+			if _, _, err := client.Repositories.Create(t.Context(), "test-org", &github.Repository{Name: new(name)}); err != nil {
+				t.Fatalf("failed to create repository: %v", err)
+			}
+		}
+
+		config := providerConfig + `
+resource "github_secret_set" "test" {
+  secret_name  = "TEST"
+  value        = "%s"
+  secret_types = ["actions"]
+
+  target {
+    repository = "test-repo-1"
+  }
+
+  target {
+    visibility = "private"
+  }
+%s}
+`
+		target := `
+  target {
+    repository = "test-repo-2"
+  }
+`
+
+		expectValues := func(want string, repos ...string) resource.TestCheckFunc {
+			return func(*terraform.State) error {
+				for _, repo := range repos {
+					if value, ok := fake.SecretValue("test-org", repo, "TEST"); !ok || value != want {
+						return fmt.Errorf("expected secret value %q in %q, got %q (exists: %t)", want, repo, value, ok)
+					}
+				}
+				return nil
+			}
+		}
+
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, "my-value", target),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_secret_set.test", tfjsonpath.New("secrets"), knownvalue.ListSizeExact(3)),
+						statecheck.ExpectKnownValue("github_secret_set.test", tfjsonpath.New("secrets").AtSliceIndex(1).AtMapKey("repository"), knownvalue.StringExact("")),
+					},
+					Check: expectValues("my-value", "test-repo-1", "", "test-repo-2"),
+				},
+				{
+					Config: fmt.Sprintf(config, "my-value-2", target),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_secret_set.test", plancheck.ResourceActionUpdate),
+						},
+					},
+					Check: expectValues("my-value-2", "test-repo-1", "", "test-repo-2"),
+				},
+				{
+					PreConfig: func() {
+						if _, err := client.Actions.DeleteRepoSecret(t.Context(), "test-org", "test-repo-2", "TEST"); err != nil {
+							t.Fatalf("failed to delete secret: %v", err)
+						}
+					},
+					Config: fmt.Sprintf(config, "my-value-2", target),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_secret_set.test", plancheck.ResourceActionUpdate),
+						},
+					},
+					Check: expectValues("my-value-2", "test-repo-1", "", "test-repo-2"),
+				},
+				{
+					Config: fmt.Sprintf(config, "my-value-2", ""),
+					Check: resource.ComposeTestCheckFunc(
+						expectValues("my-value-2", "test-repo-1", ""),
+						func(*terraform.State) error {
+							if _, ok := fake.SecretValue("test-org", "test-repo-2", "TEST"); ok {
+								return fmt.Errorf("expected the secret of the removed target to be deleted")
+							}
+							return nil
+						},
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestAccGithubSecretSet(t *testing.T) {
+	t.Parallel()
+
+	skipUnauthenticated(t)
+
+	t.Run("with_repository_targets", func(t *testing.T) {
+		t.Parallel()
+
+		repo1 := mustCreateTestRepository(t)
+		repo2 := mustCreateTestRepository(t)
+
+		config := fmt.Sprintf(`
+resource "github_secret_set" "test" {
+  secret_name  = "TEST"
+  value        = "%%s"
+  secret_types = ["actions", "codespaces", "dependabot"]
+
+  target {
+    repository = "%s"
+  }
+
+  target {
+    repository = "%s"
+  }
+}
+`, repo1.GetName(), repo2.GetName())
+
+		resource.Test(t, resource.TestCase{
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, "super_secret_value"),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_secret_set.test", tfjsonpath.New("secrets"), knownvalue.ListSizeExact(6)),
+						statecheck.ExpectKnownValue("github_secret_set.test", tfjsonpath.New("secrets").AtSliceIndex(0).AtMapKey("key_id"), knownvalue.NotNull()),
+						statecheck.ExpectKnownValue("github_secret_set.test", tfjsonpath.New("secrets").AtSliceIndex(0).AtMapKey("updated_at"), knownvalue.NotNull()),
+					},
+				},
+				{
+					Config: fmt.Sprintf(config, "super_secret_value_2"),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("github_secret_set.test", plancheck.ResourceActionUpdate),
+						},
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("with_environment_target", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mustCreateTestRepository(t)
+		env := mustCreateTestRepositoryEnvironment(t, repo)
+
+		config := fmt.Sprintf(`
+resource "github_secret_set" "test" {
+  secret_name      = "TEST"
+  value_wo         = "super_secret_value"
+  value_wo_version = 1
+  secret_types     = ["actions", "dependabot"]
+
+  target {
+    repository  = "%s"
+    environment = "%s"
+  }
+}
+`, repo.GetName(), env.GetName())
+
+		resource.Test(t, resource.TestCase{
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("github_secret_set.test", tfjsonpath.New("secrets"), knownvalue.ListSizeExact(1)),
+						statecheck.ExpectKnownValue("github_secret_set.test", tfjsonpath.New("secrets").AtSliceIndex(0).AtMapKey("environment"), knownvalue.StringExact(env.GetName())),
+					},
+				},
+			},
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
+	"github.com/shurcooL/githubv4"
+)
+
+// dataSourceTelemetryScopePrefix prefixes the telemetry scope of data sources, so that they're distinguished from resources of the same type.
+const dataSourceTelemetryScopePrefix = "data."
+
+// providerTelemetries account the GitHub API requests made by the provider process per `telemetry_file`, so that provider configurations in the process (e.g. aliases) with different files each get a summary of their own requests; configurations with the same file, or without one, share a summary.
+var (
+	providerTelemetriesMu sync.Mutex
+	providerTelemetries   = map[string]*ghclient.Telemetry{}
+)
+
+// providerTelemetry returns the telemetry of the provider configurations with the `telemetry_file`, creating it on first use.
+func providerTelemetry(path string) *ghclient.Telemetry {
+	providerTelemetriesMu.Lock()
+	defer providerTelemetriesMu.Unlock()
+
+	t, ok := providerTelemetries[path]
+	if !ok {
+		t = ghclient.NewTelemetry()
+		t.SetOutputPath(path)
+		providerTelemetries[path] = t
+	}
+
+	return t
+}
+
+// FlushTelemetry logs the summaries of the GitHub API requests made by the provider process and appends each to its `telemetry_file`, if set; it's called when the provider shuts down.
+func FlushTelemetry() {
+	ctx := tfsdklog.NewRootProviderLogger(context.Background())
+
+	providerTelemetriesMu.Lock()
+	telemetries := maps.Clone(providerTelemetries)
+	providerTelemetriesMu.Unlock()
+
+	for _, path := range slices.Sorted(maps.Keys(telemetries)) {
+		if err := telemetries[path].Flush(tflog.SetField(ctx, "telemetry_file", path)); err != nil {
+			tflog.Warn(ctx, "Failed to flush GitHub API telemetry.", map[string]any{"telemetry_file": path, "error": err.Error()})
+		}
+	}
+}
+
+// addTelemetryScopes wraps the functions of the resources and data sources of the provider so that the GitHub API requests they make are accounted to their type. It must be called before [addOwnerArguments], so that the meta of the selected owner is scoped.
+func addTelemetryScopes(p *schema.Provider) {
+	for name, r := range p.ResourcesMap {
+		withTelemetryScope(r, name)
+	}
+
+	for name, r := range p.DataSourcesMap {
+		withTelemetryScope(r, dataSourceTelemetryScopePrefix+name)
+	}
+}
+
+// withTelemetryScope wraps the functions of the resource so that they're called with the telemetry scope set in their context and with a provider meta whose clients account requests made without a scope in their context to the scope, which covers legacy functions and requests made with a background context.
+func withTelemetryScope(r *schema.Resource, scope string) {
+	r.CreateContext = wrapTelemetryContextFunc(r.CreateContext, scope)
+	r.ReadContext = wrapTelemetryContextFunc(r.ReadContext, scope)
+	r.UpdateContext = wrapTelemetryContextFunc(r.UpdateContext, scope)
+	r.DeleteContext = wrapTelemetryContextFunc(r.DeleteContext, scope)
+	r.CreateWithoutTimeout = wrapTelemetryContextFunc(r.CreateWithoutTimeout, scope)
+	r.ReadWithoutTimeout = wrapTelemetryContextFunc(r.ReadWithoutTimeout, scope)
+	r.UpdateWithoutTimeout = wrapTelemetryContextFunc(r.UpdateWithoutTimeout, scope)
+	r.DeleteWithoutTimeout = wrapTelemetryContextFunc(r.DeleteWithoutTimeout, scope)
+	r.Create = wrapTelemetryFunc(r.Create, scope)
+	r.Read = wrapTelemetryFunc(r.Read, scope)
+	r.Update = wrapTelemetryFunc(r.Update, scope)
+	r.Delete = wrapTelemetryFunc(r.Delete, scope)
+
+	if r.CustomizeDiff != nil {
+		fn := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+			return fn(ghclient.WithTelemetryScope(ctx, scope), d, telemetryScopedMeta(m, scope))
+		}
+	}
+
+	if r.Importer != nil {
+		importer := *r.Importer
+		if fn := importer.StateContext; fn != nil {
+			importer.StateContext = func(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+				return fn(ghclient.WithTelemetryScope(ctx, scope), d, telemetryScopedMeta(m, scope))
+			}
+		}
+		if fn := importer.State; fn != nil {
+			importer.State = func(d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+				return fn(d, telemetryScopedMeta(m, scope))
+			}
+		}
+		r.Importer = &importer
+	}
+
+	for i, u := range r.StateUpgraders {
+		fn := u.Upgrade
+		r.StateUpgraders[i].Upgrade = func(ctx context.Context, rawState map[string]any, m any) (map[string]any, error) {
+			return fn(ghclient.WithTelemetryScope(ctx, scope), rawState, telemetryScopedMeta(m, scope))
+		}
+	}
+}
+
+// wrapTelemetryContextFunc wraps a resource context function so that it's called with the telemetry scope set in its context and with the scoped provider meta.
+func wrapTelemetryContextFunc[F ~func(context.Context, *schema.ResourceData, any) diag.Diagnostics](fn F, scope string) F {
+	if fn == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		return fn(ghclient.WithTelemetryScope(ctx, scope), d, telemetryScopedMeta(m, scope))
+	}
+}
+
+// wrapTelemetryFunc wraps a legacy resource function so that it's called with the scoped provider meta.
+func wrapTelemetryFunc[F ~func(*schema.ResourceData, any) error](fn F, scope string) F {
+	if fn == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, m any) error {
+		return fn(d, telemetryScopedMeta(m, scope))
+	}
+}
+
+// telemetryScopedMeta returns the provider meta scoped to the telemetry scope, see [Owner.withTelemetryScope].
+func telemetryScopedMeta(m any, scope string) any {
+	if meta, ok := m.(*Owner); ok {
+		return meta.withTelemetryScope(scope)
+	}
+
+	return m
+}
+
+// withTelemetryScope returns a copy of the owner whose clients account the requests made without a telemetry scope in their context to the scope; each client of the copy wraps the HTTP client of the owner's client. The copy is created on first use of the scope and reused; an owner without clients is returned as is.
+func (o *Owner) withTelemetryScope(scope string) *Owner {
+	if o.scopedOwners == nil || o.v3client == nil || o.v4httpClient == nil {
+		return o
+	}
+
+	if scoped, ok := o.scopedOwners.Load(scope); ok {
+		return scoped.(*Owner)
+	}
+
+	v3httpClient := o.v3client.Client()
+	v3httpClient.Transport = ghclient.TelemetryScopeTransport(v3httpClient.Transport, scope)
+
+	v3client, err := o.v3client.Clone(github.WithHTTPClient(v3httpClient))
+	if err != nil {
+		return o
+	}
+
+	v4httpClient := *o.v4httpClient
+	v4httpClient.Transport = ghclient.TelemetryScopeTransport(v4httpClient.Transport, scope)
+
+	scoped := *o
+	scoped.v3client = v3client
+	scoped.v4client = githubv4.NewEnterpriseClient(o.graphQLURL, &v4httpClient)
+	scoped.v4httpClient = &v4httpClient
+	scoped.scopedOwners = nil
+
+	actual, _ := o.scopedOwners.LoadOrStore(scope, &scoped)
+	return actual.(*Owner)
+}
@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
+	"github.com/shurcooL/githubv4"
+)
+
+func Test_addTelemetryScopes(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	telemetry := ghclient.NewTelemetry()
+	client := &http.Client{Transport: telemetry.Transport(telemetry.AttemptTransport(http.DefaultTransport))}
+
+	get := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+		if err != nil {
+			return err
+		}
+
+		res, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return res.Body.Close()
+	}
+
+	newResource := func() *schema.Resource {
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {Type: schema.TypeString, Optional: true},
+			},
+			ReadContext: func(ctx context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+				return diag.FromErr(get(ctx))
+			},
+			Importer: &schema.ResourceImporter{
+				StateContext: func(ctx context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+					return []*schema.ResourceData{d}, get(ctx)
+				},
+			},
+		}
+	}
+
+	p := &schema.Provider{
+		ResourcesMap:   map[string]*schema.Resource{"github_test": newResource()},
+		DataSourcesMap: map[string]*schema.Resource{"github_test": newResource()},
+	}
+	addTelemetryScopes(p)
+
+	r := p.ResourcesMap["github_test"]
+	if diags := r.ReadContext(t.Context(), r.TestResourceData(), nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if _, err := r.Importer.StateContext(t.Context(), r.TestResourceData(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ds := p.DataSourcesMap["github_test"]
+	if diags := ds.ReadContext(t.Context(), ds.TestResourceData(), nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if err := get(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	summary := telemetry.Summary()
+	for scope, want := range map[string]int64{
+		"github_test":                       2,
+		"data.github_test":                  1,
+		ghclient.TelemetryScopeUnattributed: 1,
+	} {
+		if got := summary.Scopes[scope].Requests; got != want {
+			t.Errorf("expected %d requests for scope %q, got %d", want, scope, got)
+		}
+	}
+}
+
+func Test_withTelemetryScope(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/test-org":
+			_, _ = w.Write([]byte(`{"id": 123456, "login": "test-org", "type": "Organization"}`))
+		case "/graphql":
+			_, _ = w.Write([]byte(`{"data": {"viewer": {"login": "test-user"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	telemetry := ghclient.NewTelemetry()
+	meta, err := configureProviderMeta(t.Context(), "test", &Config{
+		BaseURL:   mustNewURL(t, ts.URL),
+		Owner:     "test-org",
+		Token:     "test-token",
+		Telemetry: telemetry,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Optional: true},
+		},
+		Read: func(_ *schema.ResourceData, m any) error {
+			owner := m.(*Owner)
+			if _, _, err := owner.v3client.Users.Get(context.Background(), "test-org"); err != nil {
+				return err
+			}
+
+			var query struct {
+				Viewer struct {
+					Login githubv4.String
+				}
+			}
+			return owner.v4client.Query(context.Background(), &query, nil)
+		},
+	}
+	withTelemetryScope(r, "github_test")
+
+	before := telemetry.Summary().Scopes[ghclient.TelemetryScopeUnattributed].Requests
+
+	for range 2 {
+		if err := r.Read(r.TestResourceData(), meta); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	summary := telemetry.Summary()
+	if got := summary.Scopes["github_test"].Requests; got != 4 {
+		t.Errorf("expected %d requests for scope %q, got %d", 4, "github_test", got)
+	}
+	if got := summary.Scopes[ghclient.TelemetryScopeUnattributed].Requests; got != before {
+		t.Errorf("expected no unattributed requests, got %d", got-before)
+	}
+
+	if meta.withTelemetryScope("github_test") != meta.withTelemetryScope("github_test") {
+		t.Error("expected the scoped owner to be reused")
+	}
+}
+
+func Test_providerTelemetry(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.jsonl")
+	second := filepath.Join(dir, "second.jsonl")
+
+	if providerTelemetry(first) != providerTelemetry(first) {
+		t.Fatal("expected provider configurations with the same telemetry file to share the telemetry")
+	}
+	if providerTelemetry(first) == providerTelemetry(second) {
+		t.Fatal("expected provider configurations with different telemetry files to have their own telemetry")
+	}
+
+	for path, requests := range map[string]int{first: 1, second: 2} {
+		telemetry := providerTelemetry(path)
+		client := &http.Client{Transport: telemetry.Transport(telemetry.AttemptTransport(http.DefaultTransport))}
+		for range requests {
+			res, err := client.Get(ts.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			res.Body.Close()
+		}
+	}
+
+	FlushTelemetry()
+
+	for path, want := range map[string]int64{first: 1, second: 2} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read telemetry file: %v", err)
+		}
+
+		var summary ghclient.TelemetrySummary
+		if err := json.Unmarshal(data, &summary); err != nil {
+			t.Fatalf("failed to unmarshal telemetry summary: %v", err)
+		}
+
+		if summary.Total.Requests != want {
+			t.Errorf("expected %d requests in %s, got %d", want, filepath.Base(path), summary.Total.Requests)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/go-github/v89/github"
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
 )
 
 const (
@@ -283,6 +284,10 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			return resp, err
 		}
 
+		if _, ok := errors.AsType[*ghclient.RateLimitBudgetError](err); ok {
+			return nil, err
+		}
+
 		time.Sleep(t.retryDelay)
 	}
 
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/google/go-github/v89/github"
+	"github.com/integrations/terraform-provider-github/v6/internal/ghclient"
 )
 
 func TestEtagTransport(t *testing.T) {
@@ -185,6 +186,46 @@ func TestRateLimitTransport_abuseLimit_get_cancelled(t *testing.T) {
 	}
 }
 
+func TestRateLimitTransport_rateLimitBudget(t *testing.T) {
+	// IMPORTANT: This test is not parallelized because it uses global state.
+
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri: "/repos/test/blah",
+			ResponseBody: `{
+  "message": "API rate limit exceeded for user ID 1.",
+  "documentation_url": "https://docs.github.com/rest/overview/resources-in-the-rest-api#rate-limiting"
+}`,
+			StatusCode: 403,
+			ResponseHeaders: map[string]string{
+				"X-RateLimit-Resource":  "core",
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     fmt.Sprint(time.Now().Add(time.Hour).Unix()),
+			},
+		},
+	})
+	defer ts.Close()
+
+	budget := ghclient.NewRateLimitBudget(0, time.Minute, 0)
+	client := mustCreateTestGitHubClient(t, ts.URL, github.WithTransport(NewRetryTransport(NewRateLimitTransport(budget.Transport(http.DefaultTransport)), WithMaxRetries(3))))
+
+	ctx := context.WithValue(t.Context(), ctxId, t.Name())
+
+	start := time.Now()
+	_, _, err := client.Repositories.Get(ctx, "test", "blah")
+	budgetErr, ok := errors.AsType[*ghclient.RateLimitBudgetError](err)
+	if !ok {
+		t.Fatalf("Expected rate limit budget error, got: %v", err)
+	}
+	if budgetErr.Category != "core" {
+		t.Fatalf("Expected core category, got: %s", budgetErr.Category)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("Waited for longer than expected: %s", time.Since(start))
+	}
+}
+
 func TestRateLimitTransport_abuseLimit_post(t *testing.T) {
 	// IMPORTANT: This test is not parallelized because it uses global state.
 
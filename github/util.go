@@ -277,3 +277,13 @@ func resourceKeysGetOk[T any](d *schema.ResourceData, keys ...string) (T, bool)
 	}
 	return empty, false
 }
+
+// getWriteOnlyString returns the value of a write-only string attribute from the configuration, as write-only attributes are never in the state or the plan.
+func getWriteOnlyString(d *schema.ResourceData, key string) (string, bool) {
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() || !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+		return "", false
+	}
+
+	return v.AsString(), v.AsString() != ""
+}
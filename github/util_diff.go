@@ -78,7 +78,8 @@ func diffRepository(ctx context.Context, diff *schema.ResourceDiff, m any) error
 	return nil
 }
 
-// diffSecret compares the remote_updated_at and updated_at fields to determine if the secret has changed remotely.
+// diffSecret compares the remote_updated_at and updated_at fields to determine if the secret has changed remotely, and plans the drift as set by the drift_policy field.
+// The drift shows up in the plan as a change of updated_at, which forces a new resource with the recreate policy.
 func diffSecret(ctx context.Context, diff *schema.ResourceDiff, _ any) error {
 	if len(diff.Id()) == 0 {
 		return nil
@@ -94,13 +95,30 @@ func diffSecret(ctx context.Context, diff *schema.ResourceDiff, _ any) error {
 		return nil
 	}
 
-	tflog.Debug(ctx, "Secret drift detected from timestamp fields.", map[string]any{"updated_at": updatedAt, "remote_updated_at": remoteUpdatedAt})
-
 	if updatedAt == "" {
 		return diff.SetNew("updated_at", remoteUpdatedAt)
 	}
 
-	return diff.SetNewComputed("updated_at")
+	policy, _ := diff.Get("drift_policy").(string)
+
+	tflog.Info(ctx, "Secret drift detected from timestamp fields.", map[string]any{"id": diff.Id(), "drift_policy": policy, "updated_at": updatedAt, "remote_updated_at": remoteUpdatedAt})
+
+	switch policy {
+	case secretDriftPolicyIgnore:
+		return nil
+	case secretDriftPolicyError:
+		return fmt.Errorf("secret %s was updated outside of Terraform at %s, after it was last updated by Terraform at %s, so its value has been rotated externally; set drift_policy to overwrite_in_place or recreate to replace it with the configured value, or to ignore to keep it", diff.Id(), remoteUpdatedAt, updatedAt)
+	}
+
+	if err := diff.SetNewComputed("updated_at"); err != nil {
+		return err
+	}
+
+	if policy == secretDriftPolicyRecreate {
+		return diff.ForceNew("updated_at")
+	}
+
+	return nil
 }
 
 // diffSecretVariableVisibility ensures that selected_repository_ids is only set when visibility is set to selected.
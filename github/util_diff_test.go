@@ -0,0 +1,167 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDiffSecret(t *testing.T) {
+	t.Parallel()
+
+	state := func(remoteUpdatedAt string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "test-repo:TEST",
+			Attributes: map[string]string{
+				"id":                "test-repo:TEST",
+				"repository":        "test-repo",
+				"repository_id":     "1",
+				"secret_name":       "TEST",
+				"value":             "super_secret_value",
+				"key_id":            "1",
+				"created_at":        "2026-10-01 00:00:00 +0000 UTC",
+				"updated_at":        "2026-10-01 00:00:00 +0000 UTC",
+				"remote_updated_at": remoteUpdatedAt,
+			},
+		}
+	}
+
+	config := func(driftPolicy string) *terraform.ResourceConfig {
+		raw := map[string]any{
+			"repository":  "test-repo",
+			"secret_name": "TEST",
+			"value":       "super_secret_value",
+		}
+		if driftPolicy != "" {
+			raw["drift_policy"] = driftPolicy
+		}
+		return terraform.NewResourceConfigRaw(raw)
+	}
+
+	for _, d := range []struct {
+		testName        string
+		driftPolicy     string
+		remoteUpdatedAt string
+		expectUpdate    bool
+		expectNew       bool
+		expectErr       bool
+	}{
+		{testName: "no_drift", driftPolicy: secretDriftPolicyError, remoteUpdatedAt: "2026-10-01 00:00:00 +0000 UTC"},
+		{testName: "overwrites_in_place_by_default", remoteUpdatedAt: "2026-10-02 00:00:00 +0000 UTC", expectUpdate: true},
+		{testName: "overwrites_in_place", driftPolicy: secretDriftPolicyOverwriteInPlace, remoteUpdatedAt: "2026-10-02 00:00:00 +0000 UTC", expectUpdate: true},
+		{testName: "recreates", driftPolicy: secretDriftPolicyRecreate, remoteUpdatedAt: "2026-10-02 00:00:00 +0000 UTC", expectUpdate: true, expectNew: true},
+		{testName: "ignores", driftPolicy: secretDriftPolicyIgnore, remoteUpdatedAt: "2026-10-02 00:00:00 +0000 UTC"},
+		{testName: "errors", driftPolicy: secretDriftPolicyError, remoteUpdatedAt: "2026-10-02 00:00:00 +0000 UTC", expectErr: true},
+	} {
+		t.Run(d.testName, func(t *testing.T) {
+			t.Parallel()
+
+			r := resourceGithubActionsSecret()
+			s := state(d.remoteUpdatedAt)
+			if d.driftPolicy != "" {
+				s.Attributes["drift_policy"] = d.driftPolicy
+			}
+
+			diff, err := r.Diff(t.Context(), s, config(d.driftPolicy), &Owner{})
+			if d.expectErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff == nil {
+				diff = terraform.NewInstanceDiff()
+			}
+
+			attr := diff.Attributes["updated_at"]
+			if updated := attr != nil && attr.NewComputed; updated != d.expectUpdate {
+				t.Errorf("expected updated_at to be planned: %t, got %t", d.expectUpdate, updated)
+			}
+			if requiresNew := diff.RequiresNew(); requiresNew != d.expectNew {
+				t.Errorf("expected a new resource: %t, got %t", d.expectNew, requiresNew)
+			}
+		})
+	}
+}
+
+func TestDiffSecretSet(t *testing.T) {
+	t.Parallel()
+
+	for _, d := range []struct {
+		testName        string
+		driftPolicy     string
+		remoteUpdatedAt string
+		expectUpdate    bool
+		expectNew       bool
+		expectErr       bool
+	}{
+		{testName: "no_drift", remoteUpdatedAt: "2026-10-01 00:00:00 +0000 UTC"},
+		{testName: "overwrites_in_place", driftPolicy: secretDriftPolicyOverwriteInPlace, remoteUpdatedAt: "2026-10-02 00:00:00 +0000 UTC", expectUpdate: true},
+		{testName: "recreates", driftPolicy: secretDriftPolicyRecreate, remoteUpdatedAt: "2026-10-02 00:00:00 +0000 UTC", expectUpdate: true, expectNew: true},
+		{testName: "ignores", driftPolicy: secretDriftPolicyIgnore, remoteUpdatedAt: "2026-10-02 00:00:00 +0000 UTC"},
+		{testName: "errors", driftPolicy: secretDriftPolicyError, remoteUpdatedAt: "2026-10-02 00:00:00 +0000 UTC", expectErr: true},
+	} {
+		t.Run(d.testName, func(t *testing.T) {
+			t.Parallel()
+
+			state := &terraform.InstanceState{
+				ID: "TEST",
+				Attributes: map[string]string{
+					"id":                                 "TEST",
+					"secret_name":                        "TEST",
+					"value":                              "super_secret_value",
+					"drift_policy":                       d.driftPolicy,
+					"secret_types.#":                     "1",
+					"secret_types.0":                     "actions",
+					"target.#":                           "1",
+					"target.0.repository":                "test-repo",
+					"target.0.environment":               "",
+					"target.0.visibility":                "",
+					"target.0.selected_repository_ids.#": "0",
+					"secrets.#":                          "1",
+					"secrets.0.type":                     "actions",
+					"secrets.0.repository":               "test-repo",
+					"secrets.0.environment":              "",
+					"secrets.0.key_id":                   "1",
+					"secrets.0.created_at":               "2026-10-01 00:00:00 +0000 UTC",
+					"secrets.0.updated_at":               "2026-10-01 00:00:00 +0000 UTC",
+					"secrets.0.remote_updated_at":        d.remoteUpdatedAt,
+				},
+			}
+			config := terraform.NewResourceConfigRaw(map[string]any{
+				"secret_name":  "TEST",
+				"value":        "super_secret_value",
+				"drift_policy": d.driftPolicy,
+				"secret_types": []any{"actions"},
+				"target":       []any{map[string]any{"repository": "test-repo"}},
+			})
+
+			diff, err := resourceGithubSecretSet().Diff(t.Context(), state, config, &Owner{})
+			if d.expectErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff == nil {
+				diff = terraform.NewInstanceDiff()
+			}
+
+			attr := diff.Attributes["secrets.#"]
+			if updated := attr != nil && attr.NewComputed; updated != d.expectUpdate {
+				t.Errorf("expected the secrets to be planned: %t, got %t", d.expectUpdate, updated)
+			}
+			if requiresNew := diff.RequiresNew(); requiresNew != d.expectNew {
+				t.Errorf("expected a new resource: %t, got %t", d.expectNew, requiresNew)
+			}
+		})
+	}
+}
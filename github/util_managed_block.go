@@ -0,0 +1,131 @@
+package github
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// managedBlockDefaultBeginMarker is the default line marking the beginning of a managed block.
+	managedBlockDefaultBeginMarker = "# BEGIN TERRAFORM MANAGED BLOCK"
+
+	// managedBlockDefaultEndMarker is the default line marking the end of a managed block.
+	managedBlockDefaultEndMarker = "# END TERRAFORM MANAGED BLOCK"
+)
+
+// managedBlock is a section of a file delimited by a begin and an end marker line; Terraform owns the text between the markers and leaves the rest of the file alone.
+type managedBlock struct {
+	begin string
+	end   string
+}
+
+func managedBlockSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		ForceNew:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"begin_marker": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          managedBlockDefaultBeginMarker,
+					Description:      "The line marking the beginning of the managed block.",
+					ValidateDiagFunc: validation.ToDiagFunc(validation.All(validation.StringIsNotWhiteSpace, validation.StringDoesNotContainAny("\n"))),
+				},
+				"end_marker": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          managedBlockDefaultEndMarker,
+					Description:      "The line marking the end of the managed block.",
+					ValidateDiagFunc: validation.ToDiagFunc(validation.All(validation.StringIsNotWhiteSpace, validation.StringDoesNotContainAny("\n"))),
+				},
+			},
+		},
+	}
+}
+
+// expandManagedBlock returns the managed block of the resource, or nil if the resource manages whole files.
+func expandManagedBlock(d *schema.ResourceData) *managedBlock {
+	blocks, _ := d.Get("managed_block").([]any)
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+
+	block, _ := blocks[0].(map[string]any)
+	begin, _ := block["begin_marker"].(string)
+	end, _ := block["end_marker"].(string)
+
+	return &managedBlock{begin: begin, end: end}
+}
+
+// find returns the offsets of the start of the begin marker line, the start of the block content, the start of the end marker line and the end of the end marker line including its newline; ok is false if the file doesn't contain the block.
+func (b *managedBlock) find(content string) (start, contentStart, contentEnd, end int, ok bool) {
+	offset := 0
+	begun := false
+	for line := range strings.Lines(content) {
+		next := offset + len(line)
+		switch trimmed := strings.TrimRight(line, "\r\n"); {
+		case !begun && trimmed == b.begin:
+			start, contentStart, begun = offset, next, true
+		case begun && trimmed == b.end:
+			return start, contentStart, offset, next, true
+		}
+		offset = next
+	}
+
+	return 0, 0, 0, 0, false
+}
+
+// extract returns the content of the block in the file, and whether the file contains the block.
+func (b *managedBlock) extract(content string) (string, bool) {
+	_, contentStart, contentEnd, _, ok := b.find(content)
+	if !ok {
+		return "", false
+	}
+
+	return content[contentStart:contentEnd], true
+}
+
+// replace returns the file with the content of the block replaced, appending the block to the file if it doesn't contain it yet.
+func (b *managedBlock) replace(content, block string) string {
+	rendered := b.begin + "\n" + withTrailingNewline(block) + b.end + "\n"
+
+	start, _, _, end, ok := b.find(content)
+	if !ok {
+		if content == "" {
+			return rendered
+		}
+		return withTrailingNewline(content) + rendered
+	}
+
+	return content[:start] + rendered + content[end:]
+}
+
+// remove returns the file without the block, including its markers.
+func (b *managedBlock) remove(content string) string {
+	start, _, _, end, ok := b.find(content)
+	if !ok {
+		return content
+	}
+
+	return content[:start] + content[end:]
+}
+
+// equal returns whether the block content read from a file is the configured content, ignoring the trailing newline added when writing the block.
+func (b *managedBlock) equal(read, configured string) bool {
+	return withTrailingNewline(read) == withTrailingNewline(configured)
+}
+
+// withTrailingNewline returns the text ending with a newline, unless it's empty.
+func withTrailingNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+
+	return s + "\n"
+}
@@ -0,0 +1,85 @@
+package github
+
+import "testing"
+
+func Test_managedBlock(t *testing.T) {
+	t.Parallel()
+
+	block := &managedBlock{begin: managedBlockDefaultBeginMarker, end: managedBlockDefaultEndMarker}
+
+	t.Run("extract", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tt := range []struct {
+			name    string
+			content string
+			want    string
+			wantOk  bool
+		}{
+			{name: "missing", content: "* @org/team\n", want: "", wantOk: false},
+			{name: "missing_end", content: "# BEGIN TERRAFORM MANAGED BLOCK\n* @org/team\n", want: "", wantOk: false},
+			{name: "empty", content: "# BEGIN TERRAFORM MANAGED BLOCK\n# END TERRAFORM MANAGED BLOCK\n", want: "", wantOk: true},
+			{name: "surrounded", content: "before\n# BEGIN TERRAFORM MANAGED BLOCK\n* @org/team\n/docs @org/docs\n# END TERRAFORM MANAGED BLOCK\nafter\n", want: "* @org/team\n/docs @org/docs\n", wantOk: true},
+			{name: "crlf", content: "# BEGIN TERRAFORM MANAGED BLOCK\r\n* @org/team\r\n# END TERRAFORM MANAGED BLOCK\r\n", want: "* @org/team\r\n", wantOk: true},
+			{name: "end_without_newline", content: "# BEGIN TERRAFORM MANAGED BLOCK\n* @org/team\n# END TERRAFORM MANAGED BLOCK", want: "* @org/team\n", wantOk: true},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, ok := block.extract(tt.content)
+				if ok != tt.wantOk || got != tt.want {
+					t.Errorf("expected (%q, %t), got (%q, %t)", tt.want, tt.wantOk, got, ok)
+				}
+			})
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tt := range []struct {
+			name    string
+			content string
+			block   string
+			want    string
+		}{
+			{name: "new_file", content: "", block: "* @org/team", want: "# BEGIN TERRAFORM MANAGED BLOCK\n* @org/team\n# END TERRAFORM MANAGED BLOCK\n"},
+			{name: "append", content: "before", block: "* @org/team\n", want: "before\n# BEGIN TERRAFORM MANAGED BLOCK\n* @org/team\n# END TERRAFORM MANAGED BLOCK\n"},
+			{name: "existing", content: "before\n# BEGIN TERRAFORM MANAGED BLOCK\nold\n# END TERRAFORM MANAGED BLOCK\nafter\n", block: "new", want: "before\n# BEGIN TERRAFORM MANAGED BLOCK\nnew\n# END TERRAFORM MANAGED BLOCK\nafter\n"},
+			{name: "empty_block", content: "# BEGIN TERRAFORM MANAGED BLOCK\nold\n# END TERRAFORM MANAGED BLOCK\n", block: "", want: "# BEGIN TERRAFORM MANAGED BLOCK\n# END TERRAFORM MANAGED BLOCK\n"},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				if got := block.replace(tt.content, tt.block); got != tt.want {
+					t.Errorf("expected %q, got %q", tt.want, got)
+				}
+			})
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		t.Parallel()
+
+		content := "before\n# BEGIN TERRAFORM MANAGED BLOCK\n* @org/team\n# END TERRAFORM MANAGED BLOCK\nafter\n"
+		if got, want := block.remove(content), "before\nafter\n"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+
+		if got, want := block.remove("unmanaged\n"), "unmanaged\n"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("equal", func(t *testing.T) {
+		t.Parallel()
+
+		if !block.equal("* @org/team\n", "* @org/team") {
+			t.Error("expected block content to equal configured content without trailing newline")
+		}
+
+		if block.equal("* @org/team\n", "* @org/other") {
+			t.Error("expected different block content not to be equal")
+		}
+	})
+}
@@ -2,10 +2,15 @@ package github
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/google/go-github/v89/github"
@@ -92,6 +97,205 @@ func getFileCommit(ctx context.Context, client *github.Client, owner, repo, file
 	return nil, fmt.Errorf("cannot find file %s in repo %s/%s", file, owner, repo)
 }
 
+// repositoryFilesCommitAttempts is the number of times a commit of repository files is rebuilt on top of the branch when the branch moves before it can be fast-forwarded.
+const repositoryFilesCommitAttempts = 3
+
+// repositoryFilesCommit is a single commit changing a set of files on a branch.
+type repositoryFilesCommit struct {
+	// message is the commit message.
+	message string
+	// author is the commit author and committer; GitHub uses the authenticated user if it's nil.
+	author *github.CommitAuthor
+	// files maps the paths to write to their content; a nil content deletes the path.
+	files map[string]*string
+	// create lists the paths that are expected not to exist on the branch yet.
+	create []string
+	// block is the managed block of the files; if set the content of the files is written to the block, leaving the rest of the files alone, and deleting a file removes the block from it.
+	block *managedBlock
+}
+
+// gitBlobSHA returns the SHA of the Git blob for the content, which is how GitHub identifies file content in trees.
+func gitBlobSHA(content string) string {
+	h := sha1.New()
+	_, _ = fmt.Fprintf(h, "blob %d\x00%s", len(content), content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// getRepositoryFileEntries returns the tree entries of the paths that exist as files in the commit, keyed by path. If the recursive tree is too large to be returned in full, the paths are looked up individually.
+func getRepositoryFileEntries(ctx context.Context, client *github.Client, owner, repo string, commit *github.Commit, paths []string) (map[string]*github.TreeEntry, error) {
+	entries := make(map[string]*github.TreeEntry, len(paths))
+
+	tree, _, err := client.Git.GetTree(ctx, owner, repo, commit.GetTree().GetSHA(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	if !tree.GetTruncated() {
+		for _, e := range tree.Entries {
+			if e.GetType() == "blob" && slices.Contains(paths, e.GetPath()) {
+				entries[e.GetPath()] = e
+			}
+		}
+
+		return entries, nil
+	}
+
+	tflog.Debug(ctx, "Repository tree truncated, looking up files individually", map[string]any{
+		"commit_sha": commit.GetSHA(),
+	})
+
+	for _, path := range paths {
+		fc, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: commit.GetSHA()})
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		if fc != nil {
+			entries[path] = &github.TreeEntry{Path: new(path), Mode: new("100644"), Type: new("blob"), SHA: fc.SHA}
+		}
+	}
+
+	return entries, nil
+}
+
+// commitRepositoryFiles writes the files of the commit to the branch as a single commit built with the Git Data API, and fast-forwards the branch to it. If the branch moves in the meantime the commit is rebuilt on top of it, so concurrent changes to other files, or to the rest of files with a managed block, are kept. Files which already have the desired content are skipped; if nothing changes no commit is made and the branch head is returned.
+func commitRepositoryFiles(ctx context.Context, client *github.Client, owner, repo, branch string, c *repositoryFilesCommit) (*github.Commit, error) {
+	ref := "refs/heads/" + branch
+	paths := slices.Sorted(maps.Keys(c.files))
+	blobs := make(map[string]struct{}, len(paths))
+
+	for attempt := 1; ; attempt++ {
+		head, _, err := client.Git.GetRef(ctx, owner, repo, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		parent, _, err := client.Git.GetCommit(ctx, owner, repo, head.GetObject().GetSHA())
+		if err != nil {
+			return nil, err
+		}
+
+		existing, err := getRepositoryFileEntries(ctx, client, owner, repo, parent, paths)
+		if err != nil {
+			return nil, err
+		}
+
+		var conflicts []string
+		for _, path := range c.create {
+			if _, ok := existing[path]; ok {
+				conflicts = append(conflicts, path)
+			}
+		}
+		if len(conflicts) > 0 {
+			return nil, fmt.Errorf("refusing to overwrite existing files %s: configure `overwrite_on_create` to `true` to override", strings.Join(conflicts, ", "))
+		}
+
+		entries := make([]*github.TreeEntry, 0, len(paths))
+		for _, path := range paths {
+			content := c.files[path]
+			entry, exists := existing[path]
+
+			if c.block != nil {
+				if content, err = managedBlockFileContent(ctx, client, owner, repo, c.block, entry, content); err != nil {
+					return nil, err
+				}
+			}
+
+			mode := "100644"
+			if exists {
+				mode = entry.GetMode()
+			}
+
+			if content == nil {
+				if exists {
+					entries = append(entries, &github.TreeEntry{Path: new(path), Mode: new(mode), Type: new("blob")})
+				}
+				continue
+			}
+
+			sha := gitBlobSHA(*content)
+			if exists && entry.GetSHA() == sha {
+				continue
+			}
+
+			if _, ok := blobs[sha]; !ok {
+				if _, _, err := client.Git.CreateBlob(ctx, owner, repo, github.Blob{
+					Content:  new(base64.StdEncoding.EncodeToString([]byte(*content))),
+					Encoding: new("base64"),
+				}); err != nil {
+					return nil, err
+				}
+				blobs[sha] = struct{}{}
+			}
+
+			entries = append(entries, &github.TreeEntry{Path: new(path), Mode: new(mode), Type: new("blob"), SHA: new(sha)})
+		}
+
+		if len(entries) == 0 {
+			tflog.Debug(ctx, "Repository files already up to date, skipping commit", map[string]any{
+				"branch":     branch,
+				"commit_sha": parent.GetSHA(),
+			})
+			return parent, nil
+		}
+
+		tree, _, err := client.Git.CreateTree(ctx, owner, repo, parent.GetTree().GetSHA(), entries)
+		if err != nil {
+			return nil, err
+		}
+
+		commit, _, err := client.Git.CreateCommit(ctx, owner, repo, github.Commit{
+			Message:   new(c.message),
+			Tree:      tree,
+			Parents:   []*github.Commit{{SHA: parent.SHA}},
+			Author:    c.author,
+			Committer: c.author,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		_, _, err = client.Git.UpdateRef(ctx, owner, repo, ref, github.UpdateRef{SHA: commit.GetSHA(), Force: new(false)})
+		if err == nil {
+			return commit, nil
+		}
+
+		if ghErr, ok := errors.AsType[*github.ErrorResponse](err); !ok || ghErr.Response.StatusCode != http.StatusUnprocessableEntity || attempt == repositoryFilesCommitAttempts {
+			return nil, fmt.Errorf("error updating branch %s of %s/%s to commit %s: %w", branch, owner, repo, commit.GetSHA(), err)
+		}
+
+		tflog.Info(ctx, "Branch moved while committing repository files, rebuilding the commit on the new branch head", map[string]any{
+			"branch":  branch,
+			"attempt": attempt,
+		})
+	}
+}
+
+// managedBlockFileContent returns the content of the file with the managed block set to the content, or removed if content is nil; nil is returned if nothing but the block would be left in the file, so that the file is deleted.
+func managedBlockFileContent(ctx context.Context, client *github.Client, owner, repo string, block *managedBlock, entry *github.TreeEntry, content *string) (*string, error) {
+	var existing string
+	if entry != nil {
+		raw, _, err := client.Git.GetBlobRaw(ctx, owner, repo, entry.GetSHA())
+		if err != nil {
+			return nil, err
+		}
+		existing = string(raw)
+	}
+
+	if content != nil {
+		return new(block.replace(existing, *content)), nil
+	}
+
+	if remaining := block.remove(existing); strings.TrimSpace(remaining) != "" {
+		return new(remaining), nil
+	}
+
+	return nil, nil
+}
+
 // getAutolinkByKeyPrefix returns a single autolink reference by key prefix that was configured for the given repository.
 func getAutolinkByKeyPrefix(ctx context.Context, client *github.Client, owner, repo, keyPrefix string) (*github.Autolink, error) {
 	autolinks, err := listAutolinks(ctx, client, owner, repo)
@@ -258,6 +258,42 @@ func expandConditions(input []any, org bool) *github.RepositoryRulesetConditions
 		} else if v, ok := inputConditions["repository_property"].([]any); ok && v != nil && len(v) != 0 {
 			rulesetConditions.RepositoryProperty = expandRepositoryPropertyConditions(v)
 		}
+
+		// organization_name, organization_id and organization_property are only set for enterprise rulesets
+		if v, ok := inputConditions["organization_name"].([]any); ok && v != nil && len(v) != 0 {
+			inputOrganizationName := v[0].(map[string]any)
+			include := make([]string, 0)
+			exclude := make([]string, 0)
+
+			for _, v := range inputOrganizationName["include"].([]any) {
+				if v != nil {
+					include = append(include, v.(string))
+				}
+			}
+
+			for _, v := range inputOrganizationName["exclude"].([]any) {
+				if v != nil {
+					exclude = append(exclude, v.(string))
+				}
+			}
+
+			rulesetConditions.OrganizationName = &github.RepositoryRulesetOrganizationNamesConditionParameters{
+				Include: include,
+				Exclude: exclude,
+			}
+		} else if v, ok := inputConditions["organization_id"].([]any); ok && v != nil && len(v) != 0 {
+			organizationIDs := make([]int64, 0)
+
+			for _, v := range v {
+				if v != nil {
+					organizationIDs = append(organizationIDs, toInt64(v))
+				}
+			}
+
+			rulesetConditions.OrganizationID = &github.RepositoryRulesetOrganizationIDsConditionParameters{OrganizationIDs: organizationIDs}
+		} else if v, ok := inputConditions["organization_property"].([]any); ok && v != nil && len(v) != 0 {
+			rulesetConditions.OrganizationProperty = (*github.RepositoryRulesetOrganizationPropertyConditionParameters)(expandRepositoryPropertyConditions(v))
+		}
 	}
 
 	return rulesetConditions
@@ -360,6 +396,24 @@ func flattenConditions(ctx context.Context, conditions *github.RepositoryRuleset
 			})
 			conditionsMap["repository_property"] = repositoryPropertySlice
 		}
+
+		if conditions.OrganizationName != nil {
+			conditionsMap["organization_name"] = []map[string]any{{
+				"include": conditions.OrganizationName.Include,
+				"exclude": conditions.OrganizationName.Exclude,
+			}}
+		}
+
+		if conditions.OrganizationID != nil {
+			conditionsMap["organization_id"] = conditions.OrganizationID.OrganizationIDs
+		}
+
+		if conditions.OrganizationProperty != nil {
+			conditionsMap["organization_property"] = []map[string]any{{
+				"include": flattenRulesetRepositoryPropertyTargetParameters(conditions.OrganizationProperty.Include),
+				"exclude": flattenRulesetRepositoryPropertyTargetParameters(conditions.OrganizationProperty.Exclude),
+			}}
+		}
 	}
 
 	return []any{conditionsMap}
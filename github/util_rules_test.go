@@ -496,6 +496,58 @@ func TestCopilotCodeReviewRoundTrip(t *testing.T) {
 	}
 }
 
+func TestMergeQueueOrgRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// Test that merge_queue rule survives expand -> flatten round trip for organization rulesets
+	mergeQueue := map[string]any{
+		"check_response_timeout_minutes":    10,
+		"grouping_strategy":                 "HEADGREEN",
+		"max_entries_to_build":              10,
+		"max_entries_to_merge":              4,
+		"merge_method":                      "SQUASH",
+		"min_entries_to_merge":              2,
+		"min_entries_to_merge_wait_minutes": 30,
+	}
+
+	input := []any{map[string]any{
+		"merge_queue": []any{mergeQueue},
+	}}
+
+	expandedRules := expandRules(input, true)
+
+	if expandedRules.MergeQueue == nil {
+		t.Fatal("Expected MergeQueue rule to be set")
+	}
+
+	if expandedRules.MergeQueue.GroupingStrategy != github.MergeGroupingStrategyHeadGreen {
+		t.Errorf("Expected GroupingStrategy to be HEADGREEN, got %v", expandedRules.MergeQueue.GroupingStrategy)
+	}
+
+	if expandedRules.MergeQueue.MergeMethod != github.MergeQueueMergeMethodSquash {
+		t.Errorf("Expected MergeMethod to be SQUASH, got %v", expandedRules.MergeQueue.MergeMethod)
+	}
+
+	flattenedResult := flattenRules(t.Context(), expandedRules, true)
+
+	if len(flattenedResult) != 1 {
+		t.Fatalf("Expected 1 flattened result, got %d", len(flattenedResult))
+	}
+
+	flattenedRulesMap := flattenedResult[0].(map[string]any)
+	mergeQueueRules := flattenedRulesMap["merge_queue"].([]map[string]any)
+
+	if len(mergeQueueRules) != 1 {
+		t.Fatalf("Expected 1 merge_queue rule after round trip, got %d", len(mergeQueueRules))
+	}
+
+	for k, want := range mergeQueue {
+		if got := mergeQueueRules[0][k]; got != want {
+			t.Errorf("Expected %s to be %v, got %v", k, want, got)
+		}
+	}
+}
+
 func TestFlattenConditions_PushRuleset_WithRepositoryNameOnly(t *testing.T) {
 	t.Parallel()
 
@@ -1247,6 +1299,83 @@ func TestRoundTripRepositoryPropertyConditions(t *testing.T) {
 	}
 }
 
+func TestRoundTripOrganizationConditions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("organization_name", func(t *testing.T) {
+		t.Parallel()
+
+		input := []any{map[string]any{
+			"organization_name": []any{map[string]any{
+				"include": []any{"acme-*"},
+				"exclude": []any{"acme-sandbox"},
+			}},
+		}}
+
+		expanded := expandConditions(input, true)
+		if expanded.OrganizationName == nil {
+			t.Fatal("Expected OrganizationName to be set")
+		}
+		if expanded.OrganizationID != nil || expanded.OrganizationProperty != nil {
+			t.Error("Expected only OrganizationName to be set")
+		}
+
+		flattened := flattenConditions(t.Context(), expanded, true)
+		organizationName := flattened[0].(map[string]any)["organization_name"].([]map[string]any)
+		if include := organizationName[0]["include"].([]string); len(include) != 1 || include[0] != "acme-*" {
+			t.Errorf("Expected include to be ['acme-*'], got %v", include)
+		}
+		if exclude := organizationName[0]["exclude"].([]string); len(exclude) != 1 || exclude[0] != "acme-sandbox" {
+			t.Errorf("Expected exclude to be ['acme-sandbox'], got %v", exclude)
+		}
+	})
+
+	t.Run("organization_id", func(t *testing.T) {
+		t.Parallel()
+
+		input := []any{map[string]any{
+			"organization_id": []any{1, 2},
+		}}
+
+		expanded := expandConditions(input, true)
+		if expanded.OrganizationID == nil || len(expanded.OrganizationID.OrganizationIDs) != 2 {
+			t.Fatalf("Expected 2 organization IDs, got %v", expanded.OrganizationID)
+		}
+
+		flattened := flattenConditions(t.Context(), expanded, true)
+		if ids := flattened[0].(map[string]any)["organization_id"].([]int64); len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+			t.Errorf("Expected organization_id to be [1, 2], got %v", ids)
+		}
+	})
+
+	t.Run("organization_property", func(t *testing.T) {
+		t.Parallel()
+
+		input := []any{map[string]any{
+			"organization_property": []any{map[string]any{
+				"include": []any{map[string]any{
+					"name":            "environment",
+					"source":          "custom",
+					"property_values": []any{"production"},
+				}},
+				"exclude": []any{},
+			}},
+		}}
+
+		expanded := expandConditions(input, true)
+		if expanded.OrganizationProperty == nil || len(expanded.OrganizationProperty.Include) != 1 {
+			t.Fatalf("Expected 1 included organization property, got %v", expanded.OrganizationProperty)
+		}
+
+		flattened := flattenConditions(t.Context(), expanded, true)
+		organizationProperty := flattened[0].(map[string]any)["organization_property"].([]map[string]any)
+		include := organizationProperty[0]["include"].([]map[string]any)
+		if len(include) != 1 || include[0]["name"] != "environment" {
+			t.Errorf("Expected include to contain the environment property, got %v", include)
+		}
+	})
+}
+
 func TestFlattenRulesetRepositoryPropertyTargetParameters_Empty(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,142 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	secretDriftPolicyOverwriteInPlace = "overwrite_in_place"
+	secretDriftPolicyRecreate         = "recreate"
+	secretDriftPolicyIgnore           = "ignore"
+	secretDriftPolicyError            = "error"
+)
+
+// secretDriftPolicies are the values of the drift_policy attribute of secrets, which sets how a secret updated outside of Terraform is planned.
+var secretDriftPolicies = []string{secretDriftPolicyOverwriteInPlace, secretDriftPolicyRecreate, secretDriftPolicyIgnore, secretDriftPolicyError}
+
+// onlySecretDriftPolicyChanged returns true if only the attributes setting how drift is planned have changed; these don't change the secrets, so an update has nothing to put.
+func onlySecretDriftPolicyChanged(d *schema.ResourceData) bool {
+	return !d.HasChangesExcept("drift_policy", "destroy_on_drift")
+}
+
+// publicKeyCache caches the public keys used to encrypt secrets for the lifetime of the provider, so that each key is fetched once rather than for every secret. Keys are cached by scope, the API path of the key, and are removed by key ID when GitHub rejects a value encrypted with a key that has since been rotated.
+type publicKeyCache struct {
+	mu      sync.Mutex
+	entries map[string]*publicKeyCacheEntry
+}
+
+// publicKeyCacheEntry holds the public key of a scope, which is locked while the key is fetched so that concurrent resources wait for a single request.
+type publicKeyCacheEntry struct {
+	mu  sync.Mutex
+	key *github.PublicKey
+}
+
+// newPublicKeyCache creates a new empty publicKeyCache.
+func newPublicKeyCache() *publicKeyCache {
+	return &publicKeyCache{entries: map[string]*publicKeyCacheEntry{}}
+}
+
+// get returns the ID and value of the public key for the scope, calling fetch to get the key if it isn't cached. A nil cache always calls fetch.
+func (c *publicKeyCache) get(ctx context.Context, scope string, fetch func() (*github.PublicKey, error)) (string, string, error) {
+	if c == nil {
+		key, err := fetch()
+		if err != nil {
+			return "", "", err
+		}
+		return key.GetKeyID(), key.GetKey(), nil
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[scope]
+	if !ok {
+		e = &publicKeyCacheEntry{}
+		c.entries[scope] = e
+	}
+	c.mu.Unlock()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.key == nil {
+		key, err := fetch()
+		if err != nil {
+			return "", "", err
+		}
+		tflog.Debug(ctx, "Caching secrets public key", map[string]any{"scope": scope, "key_id": key.GetKeyID()})
+		e.key = key
+	}
+
+	return e.key.GetKeyID(), e.key.GetKey(), nil
+}
+
+// invalidate removes the public key with the ID from the cache, so that it's fetched again for every scope it was cached for.
+func (c *publicKeyCache) invalidate(ctx context.Context, keyID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for scope, e := range c.entries {
+		e.mu.Lock()
+		if e.key != nil && e.key.GetKeyID() == keyID {
+			tflog.Debug(ctx, "Removing secrets public key from the cache", map[string]any{"scope": scope, "key_id": keyID})
+			e.key = nil
+		}
+		e.mu.Unlock()
+	}
+}
+
+// isPublicKeyMismatch returns true if the error is GitHub rejecting an encrypted secret value, which is the response when the value was encrypted with a public key that has since been rotated.
+func isPublicKeyMismatch(err error) bool {
+	ghErr, ok := errors.AsType[*github.ErrorResponse](err)
+	return ok && ghErr.Response.StatusCode == http.StatusUnprocessableEntity
+}
+
+// putSecret creates or updates a secret by calling put with the key ID and encrypted value, and returns the ID of the key used.
+// If encryptedValue is set it's put as is, with keyID or the ID of the key returned by getKey; otherwise the plaintext is encrypted with the key returned by getKey.
+// When GitHub rejects a value that was encrypted by the provider, the key is removed from the provider cache and the value is encrypted with the current key and put again once.
+func putSecret(ctx context.Context, meta *Owner, keyID, encryptedValue, plaintext string, getKey func() (string, string, error), put func(keyID, encryptedValue string) error) (string, error) {
+	if len(encryptedValue) != 0 {
+		if len(keyID) == 0 {
+			ki, _, err := getKey()
+			if err != nil {
+				return "", err
+			}
+			keyID = ki
+		}
+
+		return keyID, put(keyID, encryptedValue)
+	}
+
+	for attempt := 0; ; attempt++ {
+		keyID, publicKey, err := getKey()
+		if err != nil {
+			return "", err
+		}
+
+		encryptedBytes, err := encryptPlaintext(plaintext, publicKey)
+		if err != nil {
+			return "", err
+		}
+
+		err = put(keyID, base64.StdEncoding.EncodeToString(encryptedBytes))
+		if attempt == 0 && isPublicKeyMismatch(err) {
+			tflog.Info(ctx, "Secret rejected by GitHub, retrying with the current public key", map[string]any{"key_id": keyID})
+			meta.publicKeys.invalidate(ctx, keyID)
+			meta.userPublicKeys.invalidate(ctx, keyID)
+			continue
+		}
+
+		return keyID, err
+	}
+}
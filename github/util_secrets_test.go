@@ -0,0 +1,162 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func Test_publicKeyCache(t *testing.T) {
+	t.Parallel()
+
+	fetcher := func(count *int, keyID string) func() (*github.PublicKey, error) {
+		return func() (*github.PublicKey, error) {
+			*count++
+			return &github.PublicKey{KeyID: new(keyID), Key: new("key-" + keyID)}, nil
+		}
+	}
+
+	t.Run("fetches_each_scope_once", func(t *testing.T) {
+		t.Parallel()
+
+		c := newPublicKeyCache()
+		var repoCount, orgCount int
+
+		for range 3 {
+			keyID, key, err := c.get(t.Context(), "repos/owner/repo/actions/secrets/public-key", fetcher(&repoCount, "1"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if keyID != "1" || key != "key-1" {
+				t.Fatalf("expected key 1, got %q %q", keyID, key)
+			}
+		}
+		if _, _, err := c.get(t.Context(), "orgs/owner/actions/secrets/public-key", fetcher(&orgCount, "2")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if repoCount != 1 || orgCount != 1 {
+			t.Errorf("expected each key to be fetched once, got %d and %d", repoCount, orgCount)
+		}
+	})
+
+	t.Run("fetches_invalidated_keys_again", func(t *testing.T) {
+		t.Parallel()
+
+		c := newPublicKeyCache()
+		var repoCount, orgCount int
+
+		_, _, _ = c.get(t.Context(), "repos/owner/repo/actions/secrets/public-key", fetcher(&repoCount, "1"))
+		_, _, _ = c.get(t.Context(), "orgs/owner/actions/secrets/public-key", fetcher(&orgCount, "2"))
+
+		c.invalidate(t.Context(), "1")
+
+		keyID, _, err := c.get(t.Context(), "repos/owner/repo/actions/secrets/public-key", fetcher(&repoCount, "3"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, _, _ = c.get(t.Context(), "orgs/owner/actions/secrets/public-key", fetcher(&orgCount, "2"))
+
+		if keyID != "3" || repoCount != 2 {
+			t.Errorf("expected the invalidated key to be fetched again, got key %q after %d fetches", keyID, repoCount)
+		}
+		if orgCount != 1 {
+			t.Errorf("expected other keys to stay cached, got %d fetches", orgCount)
+		}
+	})
+
+	t.Run("fetches_every_time_without_a_cache", func(t *testing.T) {
+		t.Parallel()
+
+		var c *publicKeyCache
+		var count int
+
+		for range 2 {
+			if _, _, err := c.get(t.Context(), "user/codespaces/secrets/public-key", fetcher(&count, "1")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		c.invalidate(t.Context(), "1")
+
+		if count != 2 {
+			t.Errorf("expected the key to be fetched every time, got %d fetches", count)
+		}
+	})
+}
+
+func Test_putSecret(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retries_with_the_current_key_after_rotation", func(t *testing.T) {
+		t.Parallel()
+
+		fake, _ := newFakeGitHub(t, "test-org")
+		client := fake.Client(t)
+		ctx := t.Context()
+
+		if _, _, err := client.Repositories.Create(ctx, "test-org", &github.Repository{Name: new("test-repo")}); err != nil {
+			t.Fatalf("failed to create repository: %v", err)
+		}
+
+		meta := &Owner{name: "test-org", v3client: client, publicKeys: newPublicKeyCache()}
+		var keyFetches int
+		getKey := func() (string, string, error) {
+			keyFetches++
+			return getPublicKeyDetails(ctx, meta, "test-repo")
+		}
+		var puts int
+		put := func(keyID, encryptedValue string) error {
+			puts++
+			_, err := client.Actions.CreateOrUpdateRepoSecret(ctx, "test-org", "test-repo", "TEST", github.SecretRequest{KeyID: keyID, EncryptedValue: encryptedValue})
+			return err
+		}
+
+		firstKeyID, err := putSecret(ctx, meta, "", "", "value-1", getKey, put)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fake.RotatePublicKey(t)
+
+		secondKeyID, err := putSecret(ctx, meta, "", "", "value-2", getKey, put)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if secondKeyID == firstKeyID {
+			t.Errorf("expected the rotated key to be used, got %q", secondKeyID)
+		}
+		if puts != 3 {
+			t.Errorf("expected the rejected secret to be put again, got %d puts", puts)
+		}
+		if value, ok := fake.SecretValue("test-org", "test-repo", "TEST"); !ok || value != "value-2" {
+			t.Errorf("expected the secret value %q, got %q", "value-2", value)
+		}
+
+		if _, err := putSecret(ctx, meta, "", "", "value-3", getKey, put); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if keyFetches != 4 {
+			t.Errorf("expected the key getter to be called for each encryption, got %d calls", keyFetches)
+		}
+	})
+
+	t.Run("puts_encrypted_values_as_is", func(t *testing.T) {
+		t.Parallel()
+
+		var gotKeyID, gotValue string
+		keyID, err := putSecret(t.Context(), &Owner{}, "", "ZW5jcnlwdGVk", "", func() (string, string, error) {
+			return "1", "", nil
+		}, func(keyID, encryptedValue string) error {
+			gotKeyID, gotValue = keyID, encryptedValue
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if keyID != "1" || gotKeyID != "1" || gotValue != "ZW5jcnlwdGVk" {
+			t.Errorf("expected the encrypted value to be put with key 1, got %q %q %q", keyID, gotKeyID, gotValue)
+		}
+	})
+}
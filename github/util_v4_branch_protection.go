@@ -6,20 +6,23 @@ import (
 	"log"
 	"strings"
 
+	"github.com/google/go-github/v89/github"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/shurcooL/githubv4"
 )
 
 type Actor struct {
-	ID   githubv4.ID
-	Name githubv4.String
-	Slug githubv4.String
+	ID         githubv4.ID
+	DatabaseID githubv4.Int `graphql:"databaseId"`
+	Name       githubv4.String
+	Slug       githubv4.String
 }
 
 type ActorUser struct {
-	ID    githubv4.ID
-	Name  githubv4.String
-	Login githubv4.String
+	ID         githubv4.ID
+	DatabaseID githubv4.Int `graphql:"databaseId"`
+	Name       githubv4.String
+	Login      githubv4.String
 }
 
 type DismissalActorTypes struct {
@@ -54,6 +57,13 @@ type PushActorTypes struct {
 	}
 }
 
+type RequiredStatusCheckDescription struct {
+	App *struct {
+		DatabaseID githubv4.Int `graphql:"databaseId"`
+	}
+	Context githubv4.String
+}
+
 type BranchProtectionRule struct {
 	Repository struct {
 		ID   githubv4.String
@@ -79,12 +89,15 @@ type BranchProtectionRule struct {
 	IsAdminEnforced                githubv4.Boolean
 	Pattern                        githubv4.String
 	RequiredApprovingReviewCount   githubv4.Int
+	RequiredDeploymentEnvironments []githubv4.String
 	RequiredStatusCheckContexts    []githubv4.String
+	RequiredStatusChecks           []RequiredStatusCheckDescription
 	RequiresApprovingReviews       githubv4.Boolean
 	RequiresCodeOwnerReviews       githubv4.Boolean
 	RequiresCommitSignatures       githubv4.Boolean
 	RequiresLinearHistory          githubv4.Boolean
 	RequiresConversationResolution githubv4.Boolean
+	RequiresDeployments            githubv4.Boolean
 	RequiresStatusChecks           githubv4.Boolean
 	RequiresStrictStatusChecks     githubv4.Boolean
 	RestrictsPushes                githubv4.Boolean
@@ -93,6 +106,12 @@ type BranchProtectionRule struct {
 	LockBranch                     githubv4.Boolean
 }
 
+// BranchProtectionStatusCheck is a required status check, pinned to the GitHub App with the ID AppID; an AppID of -1 allows any app and 0 lets GitHub pick the app which most recently set the status check.
+type BranchProtectionStatusCheck struct {
+	Context string
+	AppID   int
+}
+
 type BranchProtectionResourceData struct {
 	AllowsDeletions                bool
 	AllowsForcePushes              bool
@@ -106,12 +125,15 @@ type BranchProtectionResourceData struct {
 	PushActorIDs                   []string
 	RepositoryID                   string
 	RequiredApprovingReviewCount   int
+	RequiredDeploymentEnvironments []string
 	RequiredStatusCheckContexts    []string
+	RequiredStatusChecks           []BranchProtectionStatusCheck
 	RequiresApprovingReviews       bool
 	RequiresCodeOwnerReviews       bool
 	RequiresCommitSignatures       bool
 	RequiresLinearHistory          bool
 	RequiresConversationResolution bool
+	RequiresDeployments            bool
 	RequiresStatusChecks           bool
 	RequiresStrictStatusChecks     bool
 	RestrictsPushes                bool
@@ -232,6 +254,29 @@ func branchProtectionResourceData(d *schema.ResourceData, meta any) (BranchProte
 
 			data.RequiredStatusCheckContexts = expandNestedSet(m, PROTECTION_REQUIRED_STATUS_CHECK_CONTEXTS)
 		}
+		data.RequiredStatusChecks = expandBranchProtectionStatusChecks(v)
+		if len(data.RequiredStatusChecks) > 0 && len(data.RequiredStatusCheckContexts) > 0 {
+			return BranchProtectionResourceData{},
+				fmt.Errorf("error %s and %s are mutually exclusive", PROTECTION_REQUIRED_STATUS_CHECK_CONTEXTS, PROTECTION_REQUIRED_STATUS_CHECKS)
+		}
+	}
+
+	if v, ok := d.GetOk(PROTECTION_REQUIRES_DEPLOYMENTS); ok {
+		vL := v.([]any)
+		if len(vL) > 1 {
+			return BranchProtectionResourceData{},
+				fmt.Errorf("error multiple %s declarations", PROTECTION_REQUIRES_DEPLOYMENTS)
+		}
+		for _, v := range vL {
+			if v == nil {
+				break
+			}
+
+			data.RequiresDeployments = true
+
+			m := v.(map[string]any)
+			data.RequiredDeploymentEnvironments = expandNestedSet(m, PROTECTION_REQUIRED_DEPLOYMENT_ENVIRONMENTS)
+		}
 	}
 
 	if v, ok := d.GetOk(PROTECTION_RESTRICTS_PUSHES); ok {
@@ -515,19 +560,181 @@ func setApprovingReviews(protection BranchProtectionRule, data BranchProtectionR
 	return approvalReviews
 }
 
-func setStatusChecks(protection BranchProtectionRule) any {
+// setStatusChecks returns the required status checks of the rule as 'checks' if the resource has 'checks', or when imported with checks pinned to an app, and as 'contexts' otherwise.
+func setStatusChecks(protection BranchProtectionRule, data BranchProtectionResourceData) any {
 	if !protection.RequiresStatusChecks {
 		return nil
 	}
 
-	statusChecks := []any{
+	statusChecks := map[string]any{
+		PROTECTION_REQUIRES_STRICT_STATUS_CHECKS: protection.RequiresStrictStatusChecks,
+	}
+
+	useChecks := len(data.RequiredStatusChecks) > 0
+	if !useChecks && len(data.RequiredStatusCheckContexts) == 0 {
+		for _, c := range protection.RequiredStatusChecks {
+			if c.App != nil {
+				useChecks = true
+				break
+			}
+		}
+	}
+
+	if !useChecks {
+		statusChecks[PROTECTION_REQUIRED_STATUS_CHECK_CONTEXTS] = protection.RequiredStatusCheckContexts
+		return []any{statusChecks}
+	}
+
+	// Checks without an app ID are pinned by GitHub to the app which most recently set them, keep them unpinned in state.
+	unpinned := make(map[string]bool)
+	for _, c := range data.RequiredStatusChecks {
+		if c.AppID == 0 {
+			unpinned[c.Context] = true
+		}
+	}
+
+	checks := make([]any, 0, len(protection.RequiredStatusChecks))
+	for _, c := range protection.RequiredStatusChecks {
+		appID := -1
+		if c.App != nil {
+			appID = int(c.App.DatabaseID)
+		}
+		if unpinned[string(c.Context)] {
+			appID = 0
+		}
+
+		checks = append(checks, map[string]any{
+			PROTECTION_REQUIRED_STATUS_CHECK_CONTEXT: string(c.Context),
+			PROTECTION_REQUIRED_STATUS_CHECK_APP_ID:  appID,
+		})
+	}
+	statusChecks[PROTECTION_REQUIRED_STATUS_CHECKS] = checks
+
+	return []any{statusChecks}
+}
+
+func setDeployments(protection BranchProtectionRule) any {
+	if !protection.RequiresDeployments {
+		return nil
+	}
+
+	deployments := []any{
 		map[string]any{
-			PROTECTION_REQUIRES_STRICT_STATUS_CHECKS:  protection.RequiresStrictStatusChecks,
-			PROTECTION_REQUIRED_STATUS_CHECK_CONTEXTS: protection.RequiredStatusCheckContexts,
+			PROTECTION_REQUIRED_DEPLOYMENT_ENVIRONMENTS: protection.RequiredDeploymentEnvironments,
 		},
 	}
 
-	return statusChecks
+	return deployments
+}
+
+// expandBranchProtectionStatusChecks returns the 'checks' of the 'required_status_checks' block.
+func expandBranchProtectionStatusChecks(v any) []BranchProtectionStatusCheck {
+	vL, _ := v.([]any)
+	if len(vL) == 0 || vL[0] == nil {
+		return nil
+	}
+
+	m := vL[0].(map[string]any)
+	set, ok := m[PROTECTION_REQUIRED_STATUS_CHECKS].(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	checks := make([]BranchProtectionStatusCheck, 0, set.Len())
+	for _, c := range set.List() {
+		check := c.(map[string]any)
+		checks = append(checks, BranchProtectionStatusCheck{
+			Context: check[PROTECTION_REQUIRED_STATUS_CHECK_CONTEXT].(string),
+			AppID:   check[PROTECTION_REQUIRED_STATUS_CHECK_APP_ID].(int),
+		})
+	}
+
+	return checks
+}
+
+// githubv4RequiredStatusCheckContexts returns the contexts input of the rule, which is omitted when the status checks are set with 'checks'.
+func githubv4RequiredStatusCheckContexts(data BranchProtectionResourceData) *[]githubv4.String {
+	if len(data.RequiredStatusChecks) > 0 {
+		return nil
+	}
+
+	return new(githubv4StringSliceEmpty(data.RequiredStatusCheckContexts))
+}
+
+// githubv4RequiredStatusChecks returns the status checks input of the rule, or nil if the status checks are set with 'contexts'.
+func githubv4RequiredStatusChecks(data BranchProtectionResourceData, meta any) (*[]githubv4.RequiredStatusCheckInput, error) {
+	if len(data.RequiredStatusChecks) == 0 {
+		return nil, nil
+	}
+
+	appIDs := map[int]*githubv4.ID{}
+	checks := make([]githubv4.RequiredStatusCheckInput, 0, len(data.RequiredStatusChecks))
+	for _, c := range data.RequiredStatusChecks {
+		appID, ok := appIDs[c.AppID]
+		if !ok {
+			var err error
+			appID, err = githubv4AppID(c.AppID, meta)
+			if err != nil {
+				return nil, err
+			}
+			appIDs[c.AppID] = appID
+		}
+
+		checks = append(checks, githubv4.RequiredStatusCheckInput{
+			Context: githubv4.String(c.Context),
+			AppID:   appID,
+		})
+	}
+
+	return &checks, nil
+}
+
+// githubv4AppID returns the node ID of the GitHub App with the given ID for a required status check: "any" for -1, and nil for 0 to let GitHub pick the app.
+// GraphQL doesn't look apps up by ID, so the app is found in the GitHub App installations of the organization and its node ID is read with the REST API.
+func githubv4AppID(appID int, meta any) (*githubv4.ID, error) {
+	switch {
+	case appID == 0:
+		return nil, nil
+	case appID < 0:
+		return new(githubv4.ID("any")), nil
+	}
+
+	owner := meta.(*Owner)
+	if !owner.IsOrganization {
+		return nil, fmt.Errorf("the GitHub App %d of a required status check can only be looked up for repositories owned by an organization; remove %s to pin the status check to the app which most recently set it", appID, PROTECTION_REQUIRED_STATUS_CHECK_APP_ID)
+	}
+
+	ctx := context.Background()
+	client := owner.v3client
+
+	opts := &github.ListOptions{PerPage: owner.maxPerPage}
+	for {
+		installations, resp, err := client.Organizations.ListInstallations(ctx, owner.name, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the GitHub App installations of %s: %w", owner.name, err)
+		}
+
+		for _, installation := range installations.Installations {
+			if installation.GetAppID() != int64(appID) {
+				continue
+			}
+
+			app, _, err := client.Apps.Get(ctx, installation.GetAppSlug())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub App %s: %w", installation.GetAppSlug(), err)
+			}
+			log.Printf("[DEBUG] Retrieved node ID for GitHub App : %d - node ID : %s", appID, app.GetNodeID())
+
+			return new(githubv4.ID(app.GetNodeID())), nil
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return nil, fmt.Errorf("the GitHub App %d of a required status check isn't installed in the organization %s", appID, owner.name)
 }
 
 func setPushes(protection BranchProtectionRule, data BranchProtectionResourceData, meta any) any {
@@ -0,0 +1,145 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/shurcooL/githubv4"
+)
+
+func TestSetStatusChecks(t *testing.T) {
+	t.Parallel()
+
+	app := func(id int) *struct {
+		DatabaseID githubv4.Int `graphql:"databaseId"`
+	} {
+		return &struct {
+			DatabaseID githubv4.Int `graphql:"databaseId"`
+		}{DatabaseID: githubv4.Int(id)}
+	}
+
+	protection := BranchProtectionRule{
+		RequiresStatusChecks:        true,
+		RequiresStrictStatusChecks:  true,
+		RequiredStatusCheckContexts: []githubv4.String{"ci/pinned", "ci/any"},
+		RequiredStatusChecks: []RequiredStatusCheckDescription{
+			{Context: "ci/pinned", App: app(15368)},
+			{Context: "ci/any"},
+		},
+	}
+
+	for _, d := range []struct {
+		testName string
+		data     BranchProtectionResourceData
+		want     any
+	}{
+		{
+			testName: "keeps_contexts",
+			data:     BranchProtectionResourceData{RequiredStatusCheckContexts: []string{"ci/pinned", "ci/any"}},
+			want: []any{map[string]any{
+				PROTECTION_REQUIRES_STRICT_STATUS_CHECKS:  githubv4.Boolean(true),
+				PROTECTION_REQUIRED_STATUS_CHECK_CONTEXTS: []githubv4.String{"ci/pinned", "ci/any"},
+			}},
+		},
+		{
+			testName: "sets_pinned_checks",
+			data: BranchProtectionResourceData{RequiredStatusChecks: []BranchProtectionStatusCheck{
+				{Context: "ci/pinned", AppID: 15368},
+				{Context: "ci/any", AppID: -1},
+			}},
+			want: []any{map[string]any{
+				PROTECTION_REQUIRES_STRICT_STATUS_CHECKS: githubv4.Boolean(true),
+				PROTECTION_REQUIRED_STATUS_CHECKS: []any{
+					map[string]any{PROTECTION_REQUIRED_STATUS_CHECK_CONTEXT: "ci/pinned", PROTECTION_REQUIRED_STATUS_CHECK_APP_ID: 15368},
+					map[string]any{PROTECTION_REQUIRED_STATUS_CHECK_CONTEXT: "ci/any", PROTECTION_REQUIRED_STATUS_CHECK_APP_ID: -1},
+				},
+			}},
+		},
+		{
+			testName: "keeps_unpinned_checks_unpinned",
+			data: BranchProtectionResourceData{RequiredStatusChecks: []BranchProtectionStatusCheck{
+				{Context: "ci/pinned"},
+				{Context: "ci/any", AppID: -1},
+			}},
+			want: []any{map[string]any{
+				PROTECTION_REQUIRES_STRICT_STATUS_CHECKS: githubv4.Boolean(true),
+				PROTECTION_REQUIRED_STATUS_CHECKS: []any{
+					map[string]any{PROTECTION_REQUIRED_STATUS_CHECK_CONTEXT: "ci/pinned", PROTECTION_REQUIRED_STATUS_CHECK_APP_ID: 0},
+					map[string]any{PROTECTION_REQUIRED_STATUS_CHECK_CONTEXT: "ci/any", PROTECTION_REQUIRED_STATUS_CHECK_APP_ID: -1},
+				},
+			}},
+		},
+		{
+			testName: "sets_checks_pinned_to_apps_on_import",
+			data:     BranchProtectionResourceData{},
+			want: []any{map[string]any{
+				PROTECTION_REQUIRES_STRICT_STATUS_CHECKS: githubv4.Boolean(true),
+				PROTECTION_REQUIRED_STATUS_CHECKS: []any{
+					map[string]any{PROTECTION_REQUIRED_STATUS_CHECK_CONTEXT: "ci/pinned", PROTECTION_REQUIRED_STATUS_CHECK_APP_ID: 15368},
+					map[string]any{PROTECTION_REQUIRED_STATUS_CHECK_CONTEXT: "ci/any", PROTECTION_REQUIRED_STATUS_CHECK_APP_ID: -1},
+				},
+			}},
+		},
+	} {
+		t.Run(d.testName, func(t *testing.T) {
+			t.Parallel()
+
+			if got := setStatusChecks(protection, d.data); !reflect.DeepEqual(got, d.want) {
+				t.Errorf("got %+v, want %+v", got, d.want)
+			}
+		})
+	}
+}
+
+func TestGithubv4AppID(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/test-org/installations":
+			_, _ = fmt.Fprint(w, `{"total_count": 1, "installations": [{"id": 1, "app_id": 15368, "app_slug": "github-actions"}]}`)
+		case "/apps/github-actions":
+			_, _ = fmt.Fprint(w, `{"id": 15368, "slug": "github-actions", "node_id": "MDM6QXBwMTUzNjg="}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	client, err := github.NewClient(github.WithURLs(new(ts.URL+"/"), nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	org := &Owner{name: "test-org", v3client: client, maxPerPage: 100, IsOrganization: true}
+	user := &Owner{name: "test-user", v3client: client, maxPerPage: 100}
+
+	for _, d := range []struct {
+		testName string
+		appID    int
+		meta     *Owner
+		want     *githubv4.ID
+		wantErr  bool
+	}{
+		{testName: "lets_github_pick_the_app", appID: 0, meta: user, want: nil},
+		{testName: "allows_any_app", appID: -1, meta: user, want: new(githubv4.ID("any"))},
+		{testName: "looks_up_the_installed_app", appID: 15368, meta: org, want: new(githubv4.ID("MDM6QXBwMTUzNjg="))},
+		{testName: "fails_for_an_app_not_installed", appID: 42, meta: org, wantErr: true},
+		{testName: "fails_for_a_user_owner", appID: 15368, meta: user, wantErr: true},
+	} {
+		t.Run(d.testName, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := githubv4AppID(d.appID, d.meta)
+			if (err != nil) != d.wantErr {
+				t.Fatalf("got error %v, want error %t", err, d.wantErr)
+			}
+			if !reflect.DeepEqual(got, d.want) {
+				t.Errorf("got %v, want %v", got, d.want)
+			}
+		})
+	}
+}
@@ -12,11 +12,16 @@ const (
 	PROTECTION_PULL_REQUESTS_BYPASSERS          = "pull_request_bypassers"
 	PROTECTION_PUSH_ALLOWANCES                  = "push_allowances"
 	PROTECTION_REQUIRED_APPROVING_REVIEW_COUNT  = "required_approving_review_count"
+	PROTECTION_REQUIRED_DEPLOYMENT_ENVIRONMENTS = "environments"
+	PROTECTION_REQUIRED_STATUS_CHECKS           = "checks"
+	PROTECTION_REQUIRED_STATUS_CHECK_APP_ID     = "app_id"
+	PROTECTION_REQUIRED_STATUS_CHECK_CONTEXT    = "context"
 	PROTECTION_REQUIRED_STATUS_CHECK_CONTEXTS   = "contexts"
 	PROTECTION_REQUIRES_APPROVING_REVIEWS       = "required_pull_request_reviews"
 	PROTECTION_REQUIRES_CODE_OWNER_REVIEWS      = "require_code_owner_reviews"
 	PROTECTION_REQUIRES_COMMIT_SIGNATURES       = "require_signed_commits"
 	PROTECTION_REQUIRES_CONVERSATION_RESOLUTION = "require_conversation_resolution"
+	PROTECTION_REQUIRES_DEPLOYMENTS             = "required_deployments"
 	PROTECTION_REQUIRES_LINEAR_HISTORY          = "required_linear_history"
 	PROTECTION_REQUIRES_STATUS_CHECKS           = "required_status_checks"
 	PROTECTION_REQUIRES_STRICT_STATUS_CHECKS    = "strict"
@@ -3,6 +3,7 @@ package ghclient
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/google/go-github/v89/github"
@@ -12,8 +13,9 @@ import (
 
 // anonymousSource is a concrete implementation of a [Source] that creates GitHub clients without authentication.
 type anonymousSource struct {
-	restClient    *github.Client
-	graphQLClient *githubv4.Client
+	restClient        *github.Client
+	graphQLClient     *githubv4.Client
+	graphQLHTTPClient *http.Client
 }
 
 // NewAnonymousSource creates a new anonymousSource that provides an unauthenticated GitHub client. This client will have limited access to public resources and will be subject to stricter rate limits compared to authenticated clients.
@@ -33,14 +35,15 @@ func NewAnonymousSource(opts SourceOptions) (*anonymousSource, error) {
 		return nil, err
 	}
 
-	graphQLClient, err := NewAnonymousGraphQLClient(opts.getGraphQLClientOptions(sema))
+	graphQLClient, graphQLHTTPClient, err := newGraphQLClientWithHTTPClient(nil, opts.getGraphQLClientOptions(sema))
 	if err != nil {
 		return nil, err
 	}
 
 	return &anonymousSource{
-		restClient:    client,
-		graphQLClient: graphQLClient,
+		restClient:        client,
+		graphQLClient:     graphQLClient,
+		graphQLHTTPClient: graphQLHTTPClient,
 	}, nil
 }
 
@@ -63,3 +66,8 @@ func (s *anonymousSource) GraphQLClient() (*githubv4.Client, error) {
 func (s *anonymousSource) OwnerGraphQLClient(_ context.Context, _ string) (*githubv4.Client, error) {
 	return s.GraphQLClient()
 }
+
+// OwnerGraphQLHTTPClient returns the HTTP client of the GraphQL client for the specified owner, which is the same anonymous client for any owner.
+func (s *anonymousSource) OwnerGraphQLHTTPClient(_ context.Context, _ string) (*http.Client, error) {
+	return s.graphQLHTTPClient, nil
+}
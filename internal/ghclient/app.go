@@ -1,32 +1,119 @@
 package ghclient
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
+	"strconv"
 
 	"github.com/google/go-github/v89/github"
 	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/jferrl/go-githubauth"
 	"github.com/shurcooL/githubv4"
+	"golang.org/x/oauth2"
 	"golang.org/x/sync/semaphore"
 )
 
 // appClientCacheSize defines the maximum number of app clients to cache in the appSource. This is used to limit memory usage while still providing efficient access to clients for different owners.
 const appClientCacheSize = 8
 
+// ErrInstallationNotFound is returned when the app has not been installed for the requested owner.
+var ErrInstallationNotFound = errors.New("installation not found")
+
+// InstallationTokenScope restricts the permissions and repositories granted to GitHub App installation tokens; an empty scope grants the installation's full permissions and repository access.
+type InstallationTokenScope struct {
+	// Permissions maps permission names (e.g. `contents`) to access levels (`read`, `write` or `admin`).
+	Permissions map[string]string
+	// Repositories are the names of the repositories, owned by the installation's owner, that tokens are restricted to.
+	Repositories []string
+}
+
+// IsEmpty returns true if the scope doesn't restrict the installation tokens.
+func (s *InstallationTokenScope) IsEmpty() bool {
+	return s == nil || (len(s.Permissions) == 0 && len(s.Repositories) == 0)
+}
+
+// tokenOptions returns the installation token request options for the scope, returning an error for unknown permissions.
+func (s *InstallationTokenScope) tokenOptions() (*githubauth.InstallationTokenOptions, error) {
+	opts := &githubauth.InstallationTokenOptions{Repositories: s.Repositories}
+
+	if len(s.Permissions) > 0 {
+		b, err := json.Marshal(s.Permissions)
+		if err != nil {
+			return nil, err
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+
+		opts.Permissions = &githubauth.InstallationPermissions{}
+		if err := dec.Decode(opts.Permissions); err != nil {
+			return nil, fmt.Errorf("invalid installation token permissions: %w", err)
+		}
+	}
+
+	return opts, nil
+}
+
+// MarshalJSON encodes the scope as the body of a request to create an installation token, returning an error for unknown permissions.
+func (s InstallationTokenScope) MarshalJSON() ([]byte, error) {
+	opts, err := s.tokenOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(opts)
+}
+
+// CacheKey returns the [TokenCache] key for installation tokens with the scope; it's safe to call on a nil scope.
+func (s *InstallationTokenScope) CacheKey(baseURL, appID, installationID string) TokenCacheKey {
+	key := TokenCacheKey{BaseURL: baseURL, AppID: appID, InstallationID: installationID}
+	if s.IsEmpty() {
+		return key
+	}
+
+	key.Permissions = s.Permissions
+	if len(s.Repositories) > 0 {
+		key.Repositories = slices.Sorted(slices.Values(s.Repositories))
+	}
+
+	return key
+}
+
 // appSource is a concrete implementation of a [Source] that uses the provided app credentials to create GitHub clients.
 type appSource struct {
 	clientID           string
-	privateKey         []byte
+	signer             crypto.Signer
 	semaCache          *lru.Cache[string, *semaphore.Weighted]
 	restClientCache    *lru.Cache[string, *github.Client]
-	graphQLClientCache *lru.Cache[string, *githubv4.Client]
+	graphQLClientCache *lru.Cache[string, appGraphQLClient]
 	opts               SourceOptions
 }
 
+// appGraphQLClient is a GraphQL client of the appSource along with the HTTP client it sends its requests with.
+type appGraphQLClient struct {
+	client     *githubv4.Client
+	httpClient *http.Client
+}
+
 // NewAppSource creates a new appSource that provides GitHub clients authenticated as either the app itself or as an installation.
 func NewAppSource(clientID string, privateKey []byte, opts SourceOptions) (*appSource, error) {
+	signer, err := NewPEMSigner(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse app private key: %w", err)
+	}
+
+	return NewAppSourceFromSigner(clientID, signer, opts)
+}
+
+// NewAppSourceFromSigner creates a new appSource that provides GitHub clients authenticated as either the app itself or as an installation, using the signer to sign the app JWTs so that the private key doesn't need to be loaded.
+func NewAppSourceFromSigner(clientID string, signer crypto.Signer, opts SourceOptions) (*appSource, error) {
 	semaCache, err := lru.New[string, *semaphore.Weighted](appClientCacheSize)
 	if err != nil {
 		return nil, err
@@ -37,7 +124,7 @@ func NewAppSource(clientID string, privateKey []byte, opts SourceOptions) (*appS
 		return nil, err
 	}
 
-	graphQLClientCache, err := lru.New[string, *githubv4.Client](appClientCacheSize)
+	graphQLClientCache, err := lru.New[string, appGraphQLClient](appClientCacheSize)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +139,7 @@ func NewAppSource(clientID string, privateKey []byte, opts SourceOptions) (*appS
 
 	return &appSource{
 		clientID:           clientID,
-		privateKey:         privateKey,
+		signer:             signer,
 		semaCache:          semaCache,
 		restClientCache:    restClientCache,
 		graphQLClientCache: graphQLClientCache,
@@ -73,7 +160,7 @@ func (s *appSource) RESTClient() (*github.Client, error) {
 		s.semaCache.Add(key, sema)
 	}
 
-	c, err := NewAppRESTClient(s.clientID, s.privateKey, nil, s.opts.getRESTClientOptions(sema, "app-rest-"+s.clientID))
+	c, err := NewAppSignerRESTClient(s.clientID, s.signer, nil, s.opts.getRESTClientOptions(sema, "app-rest-"+s.clientID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create app client: %w", err)
 	}
@@ -100,7 +187,7 @@ func (s *appSource) OwnerRESTClient(ctx context.Context, owner string) (*github.
 		return nil, fmt.Errorf("failed to get installation id for owner %q: %w", owner, err)
 	}
 
-	c, err := NewAppRESTClient(s.clientID, s.privateKey, installationID, s.opts.getRESTClientOptions(sema, fmt.Sprintf("app-rest-%s-%s", s.clientID, owner)))
+	c, err := NewAppSignerRESTClient(s.clientID, s.signer, installationID, s.opts.getRESTClientOptions(sema, fmt.Sprintf("app-rest-%s-%s", s.clientID, owner)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create app client for owner %q: %w", owner, err)
 	}
@@ -113,7 +200,7 @@ func (s *appSource) OwnerRESTClient(ctx context.Context, owner string) (*github.
 func (s *appSource) GraphQLClient() (*githubv4.Client, error) {
 	key := "_"
 	if c, ok := s.graphQLClientCache.Get(key); ok {
-		return c, nil
+		return c.client, nil
 	}
 
 	sema, ok := s.semaCache.Get(key)
@@ -122,17 +209,43 @@ func (s *appSource) GraphQLClient() (*githubv4.Client, error) {
 		s.semaCache.Add(key, sema)
 	}
 
-	c, err := NewAppGraphQLClient(s.clientID, s.privateKey, nil, s.opts.getGraphQLClientOptions(sema))
+	opts := s.opts.getGraphQLClientOptions(sema)
+	tokenSource, err := newAppTokenSource(s.clientID, s.signer, nil, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create app graphql client: %w", err)
 	}
-	s.graphQLClientCache.Add(key, c)
+
+	c, httpClient, err := newGraphQLClientWithHTTPClient(tokenSource, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create app graphql client: %w", err)
+	}
+	s.graphQLClientCache.Add(key, appGraphQLClient{client: c, httpClient: httpClient})
 
 	return c, nil
 }
 
 // OwnerGraphQLClient returns a GitHub GraphQL client authenticated to access resources owned by the specified owner. It creates a client for the installation associated with the owner, if available, or falls back to the default app client if no specific installation is found.
 func (s *appSource) OwnerGraphQLClient(ctx context.Context, owner string) (*githubv4.Client, error) {
+	c, err := s.ownerGraphQLClient(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client, nil
+}
+
+// OwnerGraphQLHTTPClient returns the HTTP client of the GraphQL client for the specified owner, creating the GraphQL client if needed.
+func (s *appSource) OwnerGraphQLHTTPClient(ctx context.Context, owner string) (*http.Client, error) {
+	c, err := s.ownerGraphQLClient(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.httpClient, nil
+}
+
+// ownerGraphQLClient returns the cached GraphQL client for the installation associated with the owner, creating it if needed.
+func (s *appSource) ownerGraphQLClient(ctx context.Context, owner string) (appGraphQLClient, error) {
 	key := owner
 	if c, ok := s.graphQLClientCache.Get(key); ok {
 		return c, nil
@@ -146,16 +259,67 @@ func (s *appSource) OwnerGraphQLClient(ctx context.Context, owner string) (*gith
 
 	installationID, err := s.GetInstallationID(ctx, owner)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get installation id for owner %q: %w", owner, err)
+		return appGraphQLClient{}, fmt.Errorf("failed to get installation id for owner %q: %w", owner, err)
 	}
 
-	c, err := NewAppGraphQLClient(s.clientID, s.privateKey, installationID, s.opts.getGraphQLClientOptions(sema))
+	opts := s.opts.getGraphQLClientOptions(sema)
+	tokenSource, err := newAppTokenSource(s.clientID, s.signer, installationID, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create app graphql client for owner %q: %w", owner, err)
+		return appGraphQLClient{}, fmt.Errorf("failed to create app graphql client for owner %q: %w", owner, err)
 	}
-	s.graphQLClientCache.Add(key, c)
 
-	return c, nil
+	c, httpClient, err := newGraphQLClientWithHTTPClient(tokenSource, opts)
+	if err != nil {
+		return appGraphQLClient{}, fmt.Errorf("failed to create app graphql client for owner %q: %w", owner, err)
+	}
+
+	client := appGraphQLClient{client: c, httpClient: httpClient}
+	s.graphQLClientCache.Add(key, client)
+
+	return client, nil
+}
+
+// newAppTokenSource creates an [oauth2.TokenSource] for app JWTs signed by the signer, exchanging them for installation tokens if installationID is provided. Installation tokens are persisted in the token cache if one is configured.
+func newAppTokenSource(clientID string, signer crypto.Signer, installationID *int64, opts ClientOptions) (oauth2.TokenSource, error) {
+	tokenSource, err := githubauth.NewApplicationTokenSourceFromSigner(clientID, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create app token source: %w", err)
+	}
+
+	if installationID != nil {
+		authOpts := []githubauth.InstallationTokenSourceOpt{}
+		if opts.BaseURL != "" {
+			u, err := opts.getRESTURL()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get rest url: %w", err)
+			}
+			authOpts = append(authOpts, githubauth.WithBaseURL(*u))
+		}
+
+		if !opts.TokenScope.IsEmpty() {
+			tokenOpts, err := opts.TokenScope.tokenOptions()
+			if err != nil {
+				return nil, err
+			}
+			authOpts = append(authOpts, githubauth.WithInstallationTokenOptions(tokenOpts))
+		}
+
+		tokenSource = githubauth.NewInstallationTokenSource(*installationID, tokenSource, authOpts...)
+
+		if opts.TokenCache != nil {
+			u, err := opts.getRESTURL()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get rest url: %w", err)
+			}
+
+			tokenSource, err = opts.TokenCache.TokenSource(opts.TokenScope.CacheKey(*u, clientID, strconv.FormatInt(*installationID, 10)), signer, tokenSource)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create cached app token source: %w", err)
+			}
+		}
+	}
+
+	return tokenSource, nil
 }
 
 // GetInstallationID retrieves the installation ID for the specified owner (which can be either a user or an organization). It first attempts to find an organization installation, and if that fails, it tries to find a user installation. If neither is found, it returns an error.
@@ -171,15 +335,18 @@ func (s *appSource) GetInstallationID(ctx context.Context, owner string) (*int64
 	}
 
 	if installation == nil {
-		ui, _, err := appClient.Apps.GetUserInstallation(ctx, owner)
+		ui, resp, err := appClient.Apps.GetUserInstallation(ctx, owner)
 		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				return nil, fmt.Errorf("no installation found for owner %q: %w", owner, ErrInstallationNotFound)
+			}
 			return nil, fmt.Errorf("failed to get installation for owner %q: %w", owner, err)
 		}
 		installation = ui
 	}
 
 	if installation == nil || installation.ID == nil {
-		return nil, fmt.Errorf("no installation found for owner %q", owner)
+		return nil, fmt.Errorf("no installation found for owner %q: %w", owner, ErrInstallationNotFound)
 	}
 
 	return installation.ID, nil
@@ -1,6 +1,8 @@
 package ghclient
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -165,4 +167,143 @@ func Test_appSource(t *testing.T) {
 	if ownerGraphQLClientFirst == ownerGraphQLClientSecond {
 		t.Fatal("expected different owner graphql clients for different owners")
 	}
+
+	if _, err := source.OwnerRESTClient(t.Context(), "missing"); !errors.Is(err, ErrInstallationNotFound) {
+		t.Fatalf("expected installation not found error for missing owner, got: %v", err)
+	}
+}
+
+func TestInstallationTokenScope(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name      string
+		scope     *InstallationTokenScope
+		wantEmpty bool
+		wantBody  string
+		wantErr   string
+	}{
+		{
+			name:      "nil",
+			scope:     nil,
+			wantEmpty: true,
+		},
+		{
+			name:      "empty",
+			scope:     &InstallationTokenScope{},
+			wantEmpty: true,
+			wantBody:  `{}`,
+		},
+		{
+			name:     "permissions_and_repositories",
+			scope:    &InstallationTokenScope{Permissions: map[string]string{"contents": "read", "pull_requests": "write"}, Repositories: []string{"octo-repo"}},
+			wantBody: `{"repositories":["octo-repo"],"permissions":{"contents":"read","pull_requests":"write"}}`,
+		},
+		{
+			name:    "errors_on_unknown_permission",
+			scope:   &InstallationTokenScope{Permissions: map[string]string{"not_a_permission": "read"}},
+			wantErr: "invalid installation token permissions",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tt.scope.IsEmpty() != tt.wantEmpty {
+				t.Fatalf("expected IsEmpty to be %v", tt.wantEmpty)
+			}
+
+			if tt.scope == nil {
+				return
+			}
+
+			b, err := json.Marshal(tt.scope)
+			if err != nil {
+				if tt.wantErr == "" {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error to contain %q, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if tt.wantErr != "" {
+				t.Fatalf("expected error %q, got nil", tt.wantErr)
+			}
+
+			if string(b) != tt.wantBody {
+				t.Fatalf("expected body %s, got %s", tt.wantBody, b)
+			}
+		})
+	}
+
+	t.Run("cache_key_is_independent_of_repository_order", func(t *testing.T) {
+		t.Parallel()
+
+		a := (&InstallationTokenScope{Repositories: []string{"b", "a"}}).CacheKey("https://api.github.com/", "1", "2")
+		b := (&InstallationTokenScope{Repositories: []string{"a", "b"}}).CacheKey("https://api.github.com/", "1", "2")
+
+		idA, _ := a.id()
+		idB, _ := b.id()
+		if idA != idB {
+			t.Fatal("expected cache keys to match")
+		}
+
+		idNil, _ := (*InstallationTokenScope)(nil).CacheKey("https://api.github.com/", "1", "2").id()
+		if idNil == idA {
+			t.Fatal("expected scoped and unscoped cache keys to differ")
+		}
+	})
+}
+
+func Test_newAppTokenSource_scoped(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if repos, ok := body["repositories"].([]any); !ok || len(repos) != 1 || repos[0] != "octo-repo" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"token": "scoped-token", "expires_at": "2099-01-01T00:00:00Z"}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	signer, err := NewPEMSigner(mustReadAppPrivateKey(t))
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	tokenSource, err := newAppTokenSource("123456789", signer, new(int64(1000)), ClientOptions{
+		BaseURL:    ts.URL,
+		TokenScope: &InstallationTokenScope{Permissions: map[string]string{"contents": "read"}, Repositories: []string{"octo-repo"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create app token source: %v", err)
+	}
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if token.AccessToken != "scoped-token" {
+		t.Fatalf("expected token %q, got %q", "scoped-token", token.AccessToken)
+	}
+
+	if _, err := newAppTokenSource("123456789", signer, new(int64(1000)), ClientOptions{
+		BaseURL:    ts.URL,
+		TokenScope: &InstallationTokenScope{Permissions: map[string]string{"not_a_permission": "read"}},
+	}); err == nil {
+		t.Fatal("expected error for unknown permission, got nil")
+	}
 }
@@ -0,0 +1,223 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// rateLimitCategoryCore is the rate limit category for the REST API requests not covered by another category.
+	rateLimitCategoryCore = "core"
+
+	// rateLimitCategoryGraphQL is the rate limit category for GraphQL API requests.
+	rateLimitCategoryGraphQL = "graphql"
+
+	// rateLimitCategorySearch is the rate limit category for search requests.
+	rateLimitCategorySearch = "search"
+
+	// rateLimitCategoryCodeSearch is the rate limit category for code search requests.
+	rateLimitCategoryCodeSearch = "code_search"
+
+	// rateLimitCategorySecondary is the category reported by a [RateLimitBudgetError] for secondary rate limits.
+	rateLimitCategorySecondary = "secondary"
+)
+
+// RateLimitBudgetError is returned instead of waiting for a GitHub API rate limit to reset when the wait would exceed the maximum acceptable wait of the [RateLimitBudget].
+type RateLimitBudgetError struct {
+	// Category is the rate limit category, e.g. `core` or `graphql`; it's `secondary` for secondary rate limits.
+	Category string
+	// Remaining is the number of requests remaining in the category.
+	Remaining int
+	// Limit is the number of requests allowed in the category per window.
+	Limit int
+	// Reset is the time the rate limit resets.
+	Reset time.Time
+	// MaxWait is the maximum acceptable wait of the budget.
+	MaxWait time.Duration
+}
+
+// Error implements the error interface for the RateLimitBudgetError.
+func (e *RateLimitBudgetError) Error() string {
+	wait := time.Until(e.Reset).Round(time.Second)
+	if e.Category == rateLimitCategorySecondary {
+		return fmt.Sprintf("GitHub API secondary rate limit reached; the limit resets at %s, waiting %s exceeds the rate limit budget's maximum wait of %s", e.Reset.UTC().Format(time.RFC3339), wait, e.MaxWait)
+	}
+
+	return fmt.Sprintf("GitHub API %s rate limit budget exhausted with %d of %d requests remaining; the limit resets at %s, waiting %s exceeds the rate limit budget's maximum wait of %s", e.Category, e.Remaining, e.Limit, e.Reset.UTC().Format(time.RFC3339), wait, e.MaxWait)
+}
+
+// rateLimit is the last known state of a rate limit category.
+type rateLimit struct {
+	remaining int
+	limit     int
+	reset     time.Time
+	warned    bool
+}
+
+// RateLimitBudget guards the GitHub API rate limits so that a run fails fast, naming the limit category and reset time, rather than stalling until a limit resets. Requests stop being sent once fewer than MinRemaining requests remain in their category and wait for the reset only if it's within MaxWait; rate limited responses that would be waited on for longer than MaxWait are returned as a [RateLimitBudgetError]. A warning is logged once per category and window when WarnRemaining or fewer requests remain.
+//
+// GitHub tracks the rate limits per credential, so the rate limit state is tracked per transport created by the budget rather than shared by every client using it.
+type RateLimitBudget struct {
+	MinRemaining  int
+	MaxWait       time.Duration
+	WarnRemaining int
+}
+
+// NewRateLimitBudget creates a new rate limit budget.
+func NewRateLimitBudget(minRemaining int, maxWait time.Duration, warnRemaining int) *RateLimitBudget {
+	return &RateLimitBudget{
+		MinRemaining:  minRemaining,
+		MaxWait:       maxWait,
+		WarnRemaining: warnRemaining,
+	}
+}
+
+// Transport returns a transport enforcing the budget for the requests made through it, tracking the rate limits of its credential; it must be the innermost transport of a client so that rate limited responses are turned into errors before the rate limiting and retry transports wait on them.
+func (b *RateLimitBudget) Transport(tr http.RoundTripper) http.RoundTripper {
+	return &budgetTransport{budget: b, inner: tr, limits: map[string]*rateLimit{}}
+}
+
+// check returns an error if the budget of the category is exhausted and its reset is further away than the maximum wait; otherwise it returns how long to wait for the reset, if at all.
+func (t *budgetTransport) check(category string) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.limits[category]
+	if !ok || l.remaining >= t.budget.MinRemaining && l.remaining > 0 {
+		return 0, nil
+	}
+
+	wait := time.Until(l.reset)
+	if wait <= 0 {
+		delete(t.limits, category)
+		return 0, nil
+	}
+
+	if wait > t.budget.MaxWait {
+		return 0, &RateLimitBudgetError{Category: category, Remaining: l.remaining, Limit: l.limit, Reset: l.reset, MaxWait: t.budget.MaxWait}
+	}
+
+	return wait, nil
+}
+
+// update records the rate limit state of the response and returns its category and a copy of the state, along with whether the category is at or under the warning threshold for the first time in its window.
+func (t *budgetTransport) update(res *http.Response) (string, *rateLimit, bool) {
+	category := res.Header.Get("X-Ratelimit-Resource")
+	remaining, err := strconv.Atoi(res.Header.Get("X-Ratelimit-Remaining"))
+	if category == "" || err != nil {
+		return "", nil, false
+	}
+
+	limit, _ := strconv.Atoi(res.Header.Get("X-Ratelimit-Limit"))
+	resetUnix, _ := strconv.ParseInt(res.Header.Get("X-Ratelimit-Reset"), 10, 64)
+	reset := time.Unix(resetUnix, 0)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.limits[category]
+	if !ok || !l.reset.Equal(reset) {
+		l = &rateLimit{}
+		t.limits[category] = l
+	}
+	l.remaining = remaining
+	l.limit = limit
+	l.reset = reset
+
+	warn := !l.warned && t.budget.WarnRemaining > 0 && remaining <= t.budget.WarnRemaining
+	if warn {
+		l.warned = true
+	}
+
+	state := *l
+	return category, &state, warn
+}
+
+// budgetTransport enforces a rate limit budget for the rate limits of its credential.
+type budgetTransport struct {
+	budget *RateLimitBudget
+	inner  http.RoundTripper
+
+	mu     sync.Mutex
+	limits map[string]*rateLimit
+}
+
+// RoundTrip implements the http.RoundTripper interface for the budgetTransport. It fails the request if the budget of its category is exhausted until a reset further away than the maximum wait, otherwise waiting for the reset, and fails rate limited responses that would be waited on for longer than the maximum wait.
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	wait, err := t.check(requestRateLimitCategory(req))
+	if err != nil {
+		return nil, err
+	}
+
+	if wait > 0 {
+		tflog.Info(req.Context(), "GitHub API rate limit budget exhausted; waiting for the limit to reset.", map[string]any{"category": requestRateLimitCategory(req), "wait": wait.String()})
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := t.inner.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	category, l, warn := t.update(res)
+	if warn {
+		tflog.Warn(req.Context(), "GitHub API rate limit budget running low.", map[string]any{"category": category, "remaining": l.remaining, "limit": l.limit, "reset_time": l.reset})
+	}
+
+	if res.StatusCode != http.StatusForbidden && res.StatusCode != http.StatusTooManyRequests {
+		return res, nil
+	}
+
+	if retryAfter, ok := secondaryRateLimitWait(res); ok {
+		if retryAfter > t.budget.MaxWait {
+			res.Body.Close()
+			return nil, &RateLimitBudgetError{Category: rateLimitCategorySecondary, Reset: time.Now().Add(retryAfter), MaxWait: t.budget.MaxWait}
+		}
+
+		return res, nil
+	}
+
+	if l != nil && l.remaining == 0 && time.Until(l.reset) > t.budget.MaxWait {
+		res.Body.Close()
+		return nil, &RateLimitBudgetError{Category: category, Remaining: l.remaining, Limit: l.limit, Reset: l.reset, MaxWait: t.budget.MaxWait}
+	}
+
+	return res, nil
+}
+
+// requestRateLimitCategory returns the rate limit category of the request based on its path.
+func requestRateLimitCategory(req *http.Request) string {
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	switch {
+	case strings.HasSuffix(path, "/graphql"):
+		return rateLimitCategoryGraphQL
+	case strings.Contains(path, "/search/code"):
+		return rateLimitCategoryCodeSearch
+	case strings.Contains(path, "/search/"):
+		return rateLimitCategorySearch
+	default:
+		return rateLimitCategoryCore
+	}
+}
+
+// sleepContext sleeps for the duration, returning early with the context error if the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
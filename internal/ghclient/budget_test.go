@@ -0,0 +1,225 @@
+package ghclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// rateLimitHandler returns a handler responding with the rate limit headers for the category and the status code.
+func rateLimitHandler(calls *atomic.Int32, category string, remaining int, reset time.Time, statusCode int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		w.Header().Set("X-Ratelimit-Resource", category)
+		w.Header().Set("X-Ratelimit-Limit", "5000")
+		w.Header().Set("X-Ratelimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(statusCode)
+	}
+}
+
+func TestRateLimitBudget(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fails_fast_when_budget_exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		ts := httptest.NewServer(rateLimitHandler(&calls, "core", 5, time.Now().Add(time.Hour), http.StatusOK))
+		defer ts.Close()
+
+		client := &http.Client{Transport: NewRateLimitBudget(10, time.Minute, 0).Transport(http.DefaultTransport)}
+
+		res, err := client.Get(ts.URL + "/repos/o/r")
+		if err != nil {
+			t.Fatalf("failed to make first request: %v", err)
+		}
+		res.Body.Close()
+
+		_, err = client.Get(ts.URL + "/repos/o/r")
+		budgetErr, ok := errors.AsType[*RateLimitBudgetError](err)
+		if !ok {
+			t.Fatalf("expected rate limit budget error, got %v", err)
+		}
+
+		if budgetErr.Category != "core" || budgetErr.Remaining != 5 || budgetErr.Limit != 5000 {
+			t.Errorf("expected core budget error with 5 of 5000 remaining, got %+v", budgetErr)
+		}
+
+		if calls.Load() != 1 {
+			t.Errorf("expected %d requests to be sent, got %d", 1, calls.Load())
+		}
+
+		res, err = client.Post(ts.URL+"/graphql", "application/json", nil)
+		if err != nil {
+			t.Fatalf("expected requests in other categories to be sent, got %v", err)
+		}
+		res.Body.Close()
+	})
+
+	t.Run("waits_for_reset_within_max_wait", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		reset := time.Now().Add(time.Second)
+		ts := httptest.NewServer(rateLimitHandler(&calls, "core", 5, reset, http.StatusOK))
+		defer ts.Close()
+
+		client := &http.Client{Transport: NewRateLimitBudget(10, time.Minute, 0).Transport(http.DefaultTransport)}
+
+		for range 2 {
+			res, err := client.Get(ts.URL + "/repos/o/r")
+			if err != nil {
+				t.Fatalf("failed to make request: %v", err)
+			}
+			res.Body.Close()
+		}
+
+		if time.Now().Before(time.Unix(reset.Unix(), 0)) {
+			t.Error("expected second request to wait for the rate limit to reset")
+		}
+
+		if calls.Load() != 2 {
+			t.Errorf("expected %d requests to be sent, got %d", 2, calls.Load())
+		}
+	})
+
+	t.Run("fails_primary_rate_limit_beyond_max_wait", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		ts := httptest.NewServer(rateLimitHandler(&calls, "graphql", 0, time.Now().Add(time.Hour), http.StatusForbidden))
+		defer ts.Close()
+
+		tr, err := newTransport(nil, ClientOptions{RetryMax: 3, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond, Budget: NewRateLimitBudget(0, time.Minute, 0)})
+		if err != nil {
+			t.Fatalf("failed to create transport: %v", err)
+		}
+
+		_, err = (&http.Client{Transport: tr}).Post(ts.URL+"/graphql", "application/json", nil)
+		budgetErr, ok := errors.AsType[*RateLimitBudgetError](err)
+		if !ok {
+			t.Fatalf("expected rate limit budget error, got %v", err)
+		}
+
+		if budgetErr.Category != "graphql" {
+			t.Errorf("expected category to be %q, got %q", "graphql", budgetErr.Category)
+		}
+
+		if calls.Load() != 1 {
+			t.Errorf("expected %d requests to be sent, got %d", 1, calls.Load())
+		}
+	})
+
+	t.Run("limits_secondary_rate_limit_wait", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", r.URL.Query().Get("retry_after"))
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer ts.Close()
+
+		client := &http.Client{Transport: NewRateLimitBudget(0, time.Minute, 0).Transport(http.DefaultTransport)}
+
+		res, err := client.Get(ts.URL + "/repos/o/r?retry_after=30")
+		if err != nil {
+			t.Fatalf("expected secondary rate limit within max wait to be returned, got %v", err)
+		}
+		res.Body.Close()
+
+		_, err = client.Get(ts.URL + "/repos/o/r?retry_after=120")
+		budgetErr, ok := errors.AsType[*RateLimitBudgetError](err)
+		if !ok {
+			t.Fatalf("expected rate limit budget error, got %v", err)
+		}
+
+		if budgetErr.Category != "secondary" {
+			t.Errorf("expected category to be %q, got %q", "secondary", budgetErr.Category)
+		}
+	})
+
+	t.Run("warns_once_per_window", func(t *testing.T) {
+		t.Parallel()
+
+		budget := NewRateLimitBudget(0, time.Minute, 100).Transport(http.DefaultTransport).(*budgetTransport)
+		reset := time.Now().Add(time.Hour)
+
+		for _, tt := range []struct {
+			remaining int
+			reset     time.Time
+			wantWarn  bool
+		}{
+			{remaining: 200, reset: reset},
+			{remaining: 100, reset: reset, wantWarn: true},
+			{remaining: 50, reset: reset},
+			{remaining: 100, reset: reset.Add(time.Hour), wantWarn: true},
+		} {
+			res := &http.Response{Header: http.Header{}}
+			res.Header.Set("X-Ratelimit-Resource", "core")
+			res.Header.Set("X-Ratelimit-Remaining", strconv.Itoa(tt.remaining))
+			res.Header.Set("X-Ratelimit-Reset", strconv.FormatInt(tt.reset.Unix(), 10))
+
+			if _, _, warn := budget.update(res); warn != tt.wantWarn {
+				t.Errorf("expected warn to be %t with %d remaining, got %t", tt.wantWarn, tt.remaining, warn)
+			}
+		}
+	})
+
+	t.Run("tracks_limits_per_transport", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		ts := httptest.NewServer(rateLimitHandler(&calls, "core", 5, time.Now().Add(time.Hour), http.StatusOK))
+		defer ts.Close()
+
+		budget := NewRateLimitBudget(10, time.Minute, 0)
+		exhausted := &http.Client{Transport: budget.Transport(http.DefaultTransport)}
+		other := &http.Client{Transport: budget.Transport(http.DefaultTransport)}
+
+		res, err := exhausted.Get(ts.URL + "/repos/o/r")
+		if err != nil {
+			t.Fatalf("failed to make first request: %v", err)
+		}
+		res.Body.Close()
+
+		if _, err := exhausted.Get(ts.URL + "/repos/o/r"); err == nil {
+			t.Fatal("expected rate limit budget error for the exhausted credential")
+		}
+
+		res, err = other.Get(ts.URL + "/repos/o/r")
+		if err != nil {
+			t.Fatalf("expected requests with another credential to be sent, got %v", err)
+		}
+		res.Body.Close()
+	})
+}
+
+func Test_requestRateLimitCategory(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{path: "/repos/o/r", want: "core"},
+		{path: "/graphql", want: "graphql"},
+		{path: "/api/graphql", want: "graphql"},
+		{path: "/search/issues", want: "search"},
+		{path: "/api/v3/search/code", want: "code_search"},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if got := requestRateLimitCategory(req); got != tt.want {
+				t.Errorf("expected category to be %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
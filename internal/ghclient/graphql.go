@@ -1,10 +1,10 @@
 package ghclient
 
 import (
+	"crypto"
 	"fmt"
 	"net/http"
 
-	"github.com/jferrl/go-githubauth"
 	"github.com/shurcooL/githubv4"
 	"golang.org/x/oauth2"
 )
@@ -16,22 +16,19 @@ func NewAnonymousGraphQLClient(opts ClientOptions) (*githubv4.Client, error) {
 
 // NewAppGraphQLClient creates a new GitHub GraphQL client authenticated as either the app itself (if installationID is nil) or as the specified installation (if installationID is provided), using the app's private key.
 func NewAppGraphQLClient(clientID string, privateKey []byte, installationID *int64, opts ClientOptions) (*githubv4.Client, error) {
-	tokenSource, err := githubauth.NewApplicationTokenSource(clientID, privateKey)
+	signer, err := NewPEMSigner(privateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create app token source: %w", err)
 	}
 
-	if installationID != nil {
-		authOpts := []githubauth.InstallationTokenSourceOpt{}
-		if opts.BaseURL != "" {
-			u, err := opts.getRESTURL()
-			if err != nil {
-				return nil, fmt.Errorf("failed to get rest url: %w", err)
-			}
-			authOpts = append(authOpts, githubauth.WithBaseURL(*u))
-		}
+	return NewAppSignerGraphQLClient(clientID, signer, installationID, opts)
+}
 
-		tokenSource = githubauth.NewInstallationTokenSource(*installationID, tokenSource, authOpts...)
+// NewAppSignerGraphQLClient creates a new GitHub GraphQL client authenticated as either the app itself (if installationID is nil) or as the specified installation (if installationID is provided), using the signer to sign the app JWTs so that the private key doesn't need to be loaded.
+func NewAppSignerGraphQLClient(clientID string, signer crypto.Signer, installationID *int64, opts ClientOptions) (*githubv4.Client, error) {
+	tokenSource, err := newAppTokenSource(clientID, signer, installationID, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	return newGraphQLClient(tokenSource, opts)
@@ -46,21 +43,27 @@ func NewTokenGraphQLClient(token string, opts ClientOptions) (*githubv4.Client,
 
 // newGraphQLClient creates a new GitHub GraphQL client using the provided OAuth2 token source and options. It sets up the client's transport with caching and rate limit handling, and configures the client's API URL based on the provided options.
 func newGraphQLClient(tokenSource oauth2.TokenSource, opts ClientOptions) (*githubv4.Client, error) {
+	client, _, err := newGraphQLClientWithHTTPClient(tokenSource, opts)
+	return client, err
+}
+
+// newGraphQLClientWithHTTPClient creates a new GitHub GraphQL client like [newGraphQLClient], also returning the HTTP client it sends its requests with since the GraphQL client doesn't expose it.
+func newGraphQLClientWithHTTPClient(tokenSource oauth2.TokenSource, opts ClientOptions) (*githubv4.Client, *http.Client, error) {
 	tr, err := newTransport(tokenSource, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transport: %w", err)
+		return nil, nil, fmt.Errorf("failed to create transport: %w", err)
 	}
 
 	client := &http.Client{Transport: tr, Timeout: clientTimeout}
 
 	if opts.BaseURL == "" {
-		return githubv4.NewClient(client), nil
+		return githubv4.NewClient(client), client, nil
 	}
 
 	u, err := opts.getGraphQLURL()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get graphql url: %w", err)
+		return nil, nil, fmt.Errorf("failed to get graphql url: %w", err)
 	}
 
-	return githubv4.NewEnterpriseClient(*u, client), nil
+	return githubv4.NewEnterpriseClient(*u, client), client, nil
 }
@@ -0,0 +1,219 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/shurcooL/githubv4"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/semaphore"
+)
+
+const (
+	// tokenExchangeGrantType is the OAuth 2.0 token exchange grant type as defined in RFC 8693.
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	// jwtTokenType is the token type identifier for a JWT as defined in RFC 8693.
+	jwtTokenType = "urn:ietf:params:oauth:token-type:jwt"
+
+	// oidcExchangeTimeout defines the timeout duration for token exchange requests to the token broker.
+	oidcExchangeTimeout = 30 * time.Second
+)
+
+// OIDCOptions defines the configuration options for exchanging a signed OIDC JWT (e.g. a CI workload identity token) for a GitHub token with a token broker.
+type OIDCOptions struct {
+	// TokenURL is the token broker endpoint implementing the RFC 8693 token exchange.
+	TokenURL string
+	// IDTokenFile is the path to a file containing the JWT; the file is read for every exchange so that rotated tokens are picked up. This takes precedence over IDToken.
+	IDTokenFile string
+	// IDToken is the JWT to exchange.
+	IDToken string
+	// Audience is the optional audience to request from the token broker.
+	Audience string
+	// Scope is the optional scope to request from the token broker.
+	Scope string
+}
+
+// idToken returns the JWT to exchange, reading it from the configured file if set.
+func (o *OIDCOptions) idToken() (string, error) {
+	if o.IDTokenFile != "" {
+		b, err := os.ReadFile(o.IDTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read id token file: %w", err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+
+	return o.IDToken, nil
+}
+
+// validate returns an error if the options can't be used to exchange a token.
+func (o *OIDCOptions) validate() error {
+	if o.TokenURL == "" {
+		return errors.New("token url is required for oidc authentication")
+	}
+
+	if _, err := url.ParseRequestURI(o.TokenURL); err != nil {
+		return fmt.Errorf("unable to parse token url: %w", err)
+	}
+
+	if o.IDTokenFile == "" && o.IDToken == "" {
+		return errors.New("id token or id token file is required for oidc authentication")
+	}
+
+	return nil
+}
+
+// oidcTokenSource is an [oauth2.TokenSource] that exchanges a signed JWT for a GitHub token with a token broker using the RFC 8693 token exchange.
+type oidcTokenSource struct {
+	opts   OIDCOptions
+	client *http.Client
+}
+
+// tokenExchangeResponse is the successful response from an RFC 8693 token exchange.
+type tokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+}
+
+// tokenExchangeError is the error response from an RFC 8693 token exchange.
+type tokenExchangeError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// NewOIDCTokenSource creates a new [oauth2.TokenSource] that exchanges the configured JWT for a GitHub token with the token broker, reusing the token until it expires.
+func NewOIDCTokenSource(opts OIDCOptions) (oauth2.TokenSource, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	return oauth2.ReuseTokenSource(nil, &oidcTokenSource{
+		opts: opts,
+		client: &http.Client{
+			Transport: logging.NewLoggingHTTPTransport(http.DefaultTransport),
+			Timeout:   oidcExchangeTimeout,
+		},
+	}), nil
+}
+
+// Token exchanges the JWT for a GitHub token with the token broker.
+func (s *oidcTokenSource) Token() (*oauth2.Token, error) {
+	idToken, err := s.opts.idToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if idToken == "" {
+		return nil, errors.New("id token is empty")
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", tokenExchangeGrantType)
+	form.Set("subject_token", idToken)
+	form.Set("subject_token_type", jwtTokenType)
+	if s.opts.Audience != "" {
+		form.Set("audience", s.opts.Audience)
+	}
+	if s.opts.Scope != "" {
+		form.Set("scope", s.opts.Scope)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.opts.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token exchange request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange id token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token exchange response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var e tokenExchangeError
+		if err := json.Unmarshal(body, &e); err == nil && e.Error != "" {
+			return nil, fmt.Errorf("failed to exchange id token: %s: %s (status %d)", e.Error, e.ErrorDescription, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to exchange id token: unexpected status %d", resp.StatusCode)
+	}
+
+	var r tokenExchangeResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("failed to decode token exchange response: %w", err)
+	}
+
+	if r.AccessToken == "" {
+		return nil, errors.New("token exchange response is missing access_token")
+	}
+
+	token := &oauth2.Token{AccessToken: r.AccessToken}
+	if r.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// NewOIDCSource creates a new [Source] that provides GitHub clients authenticated with a GitHub token exchanged for a signed JWT with a token broker. As with a token source, the exchanged token's permissions determine access to resources for any owner.
+func NewOIDCSource(oidc OIDCOptions, opts SourceOptions) (*tokenSource, error) {
+	ts, err := NewOIDCTokenSource(oidc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oidc token source: %w", err)
+	}
+
+	if opts.Cache && opts.CacheBasePath == "" {
+		s, err := os.MkdirTemp("", "*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary cache directory: %w", err)
+		}
+		opts.CacheBasePath = s
+	}
+
+	sema := semaphore.NewWeighted(maxConcurrentRequests)
+
+	client, err := NewOIDCRESTClient(ts, opts.getRESTClientOptions(sema, "oidc-rest"))
+	if err != nil {
+		return nil, err
+	}
+
+	graphQLClient, graphQLHTTPClient, err := newGraphQLClientWithHTTPClient(ts, opts.getGraphQLClientOptions(sema))
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenSource{
+		restClient:        client,
+		graphQLClient:     graphQLClient,
+		graphQLHTTPClient: graphQLHTTPClient,
+	}, nil
+}
+
+// NewOIDCRESTClient creates a new GitHub client authenticated with tokens from the provided OIDC token source.
+func NewOIDCRESTClient(tokenSource oauth2.TokenSource, opts ClientOptions) (*github.Client, error) {
+	return newRESTClient(tokenSource, opts)
+}
+
+// NewOIDCGraphQLClient creates a new GitHub GraphQL client authenticated with tokens from the provided OIDC token source.
+func NewOIDCGraphQLClient(tokenSource oauth2.TokenSource, opts ClientOptions) (*githubv4.Client, error) {
+	return newGraphQLClient(tokenSource, opts)
+}
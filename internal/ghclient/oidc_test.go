@@ -0,0 +1,217 @@
+package ghclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
+	"testing"
+)
+
+func newTestTokenBroker(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if r.Method != http.MethodPost || r.PostForm.Get("grant_type") != tokenExchangeGrantType || r.PostForm.Get("subject_token_type") != jwtTokenType {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "unsupported_grant_type"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.PostForm.Get("subject_token") {
+		case "valid-jwt":
+			_, _ = w.Write([]byte(`{"access_token": "exchanged-token", "issued_token_type": "urn:ietf:params:oauth:token-type:access_token", "token_type": "Bearer", "expires_in": 3600}`))
+		case "scoped-jwt":
+			if r.PostForm.Get("audience") != "github" || r.PostForm.Get("scope") != "octo-org/octo-repo" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error": "invalid_target"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token": "scoped-token", "token_type": "Bearer", "expires_in": 3600}`))
+		case "empty-jwt":
+			_, _ = w.Write([]byte(`{"token_type": "Bearer"}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant", "error_description": "subject token is invalid"}`))
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func TestNewOIDCTokenSource(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	broker := newTestTokenBroker(t, &calls)
+
+	idTokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(idTokenFile, []byte("valid-jwt\n"), 0o600); err != nil {
+		t.Fatalf("failed to write id token file: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name      string
+		opts      OIDCOptions
+		wantToken string
+		wantErr   string
+	}{
+		{
+			name:      "id_token",
+			opts:      OIDCOptions{TokenURL: broker.URL, IDToken: "valid-jwt"},
+			wantToken: "exchanged-token",
+		},
+		{
+			name:      "id_token_file",
+			opts:      OIDCOptions{TokenURL: broker.URL, IDTokenFile: idTokenFile, IDToken: "invalid-jwt"},
+			wantToken: "exchanged-token",
+		},
+		{
+			name:      "audience_and_scope",
+			opts:      OIDCOptions{TokenURL: broker.URL, IDToken: "scoped-jwt", Audience: "github", Scope: "octo-org/octo-repo"},
+			wantToken: "scoped-token",
+		},
+		{
+			name:    "errors_on_missing_token_url",
+			opts:    OIDCOptions{IDToken: "valid-jwt"},
+			wantErr: "token url is required",
+		},
+		{
+			name:    "errors_on_missing_id_token",
+			opts:    OIDCOptions{TokenURL: broker.URL},
+			wantErr: "id token or id token file is required",
+		},
+		{
+			name:    "errors_on_missing_id_token_file",
+			opts:    OIDCOptions{TokenURL: broker.URL, IDTokenFile: filepath.Join(t.TempDir(), "missing")},
+			wantErr: "failed to read id token file",
+		},
+		{
+			name:    "errors_on_rejected_id_token",
+			opts:    OIDCOptions{TokenURL: broker.URL, IDToken: "invalid-jwt"},
+			wantErr: "invalid_grant: subject token is invalid (status 401)",
+		},
+		{
+			name:    "errors_on_missing_access_token",
+			opts:    OIDCOptions{TokenURL: broker.URL, IDToken: "empty-jwt"},
+			wantErr: "missing access_token",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			source, err := NewOIDCTokenSource(tt.opts)
+			if err == nil {
+				var token string
+				tok, tokErr := source.Token()
+				if tokErr == nil {
+					token = tok.AccessToken
+				}
+				err = tokErr
+
+				if err == nil && token != tt.wantToken {
+					t.Fatalf("expected token %q, got %q", tt.wantToken, token)
+				}
+			}
+
+			if err != nil {
+				if tt.wantErr == "" {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if !regexp.MustCompile(regexp.QuoteMeta(tt.wantErr)).MatchString(err.Error()) {
+					t.Fatalf("expected error to match %q, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if tt.wantErr != "" {
+				t.Fatalf("expected error %q, got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewOIDCTokenSource_reusesToken(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	broker := newTestTokenBroker(t, &calls)
+
+	source, err := NewOIDCTokenSource(OIDCOptions{TokenURL: broker.URL, IDToken: "valid-jwt"})
+	if err != nil {
+		t.Fatalf("failed to create oidc token source: %v", err)
+	}
+
+	for range 3 {
+		if _, err := source.Token(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected token to be exchanged once, got %d exchanges", calls.Load())
+	}
+}
+
+func TestNewOIDCSource(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	broker := newTestTokenBroker(t, &calls)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer exchanged-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"login": "octocat"}`))
+	}))
+	t.Cleanup(api.Close)
+
+	source, err := NewOIDCSource(OIDCOptions{TokenURL: broker.URL, IDToken: "valid-jwt"}, SourceOptions{BaseURL: api.URL})
+	if err != nil {
+		t.Fatalf("failed to create oidc source: %v", err)
+	}
+
+	restClient, err := source.OwnerRESTClient(t.Context(), "octocat")
+	if err != nil {
+		t.Fatalf("failed to get owner rest client: %v", err)
+	}
+
+	user, _, err := restClient.Users.Get(t.Context(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if user.GetLogin() != "octocat" {
+		t.Fatalf("expected login %q, got %q", "octocat", user.GetLogin())
+	}
+
+	graphQLClient, err := source.GraphQLClient()
+	if err != nil {
+		t.Fatalf("failed to get graphql client: %v", err)
+	}
+
+	if graphQLClient == nil {
+		t.Fatal("expected graphql client to be non-nil")
+	}
+
+	if _, err := NewOIDCSource(OIDCOptions{IDToken: "valid-jwt"}, SourceOptions{}); err == nil {
+		t.Fatal("expected error for invalid oidc options, got nil")
+	}
+}
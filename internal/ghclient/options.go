@@ -19,6 +19,11 @@ type SourceOptions struct {
 	RetryMax      int
 	RetryWaitMin  time.Duration
 	RetryWaitMax  time.Duration
+	TokenCache    *TokenCache
+	TokenScope    *InstallationTokenScope
+	Recorder      *Recorder
+	Telemetry     *Telemetry
+	Budget        *RateLimitBudget
 }
 
 // getRESTClientOptions returns the REST client options derived from the source options.
@@ -32,6 +37,11 @@ func (o *SourceOptions) getRESTClientOptions(sema *semaphore.Weighted, cacheRef
 		RetryMax:        o.RetryMax,
 		RetryWaitMin:    o.RetryWaitMin,
 		RetryWaitMax:    o.RetryWaitMax,
+		TokenCache:      o.TokenCache,
+		TokenScope:      o.TokenScope,
+		Recorder:        o.Recorder,
+		Telemetry:       o.Telemetry,
+		Budget:          o.Budget,
 		Sema:            sema,
 		MaxIdleConns:    maxIdleConnsREST,
 		IdleConnTimeout: idleConnTimeoutREST,
@@ -47,6 +57,11 @@ func (o *SourceOptions) getGraphQLClientOptions(sema *semaphore.Weighted) Client
 		RetryMax:        o.RetryMax,
 		RetryWaitMin:    o.RetryWaitMin,
 		RetryWaitMax:    o.RetryWaitMax,
+		TokenCache:      o.TokenCache,
+		TokenScope:      o.TokenScope,
+		Recorder:        o.Recorder,
+		Telemetry:       o.Telemetry,
+		Budget:          o.Budget,
 		Sema:            sema,
 		MaxIdleConns:    maxIdleConnsGraphQL,
 		IdleConnTimeout: idleConnTimeoutGraphQL,
@@ -63,6 +78,11 @@ type ClientOptions struct {
 	RetryMax        int
 	RetryWaitMin    time.Duration
 	RetryWaitMax    time.Duration
+	TokenCache      *TokenCache
+	TokenScope      *InstallationTokenScope
+	Recorder        *Recorder
+	Telemetry       *Telemetry
+	Budget          *RateLimitBudget
 	Sema            *semaphore.Weighted
 	MaxIdleConns    int
 	IdleConnTimeout time.Duration
@@ -0,0 +1,332 @@
+package ghclient
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RecorderMode defines whether a [Recorder] records interactions with the GitHub API or replays them.
+type RecorderMode string
+
+const (
+	// RecorderModeRecord sends requests to the GitHub API and appends the sanitized interactions to the cassette.
+	RecorderModeRecord RecorderMode = "record"
+
+	// RecorderModeReplay serves responses from the cassette without sending requests to the GitHub API.
+	RecorderModeReplay RecorderMode = "replay"
+)
+
+// redactedValue replaces sensitive values in recorded responses.
+const redactedValue = "REDACTED"
+
+var (
+	// recordedHeaders are the response headers kept in cassettes; all other headers are dropped so that no cookies, request IDs or rate limit state are recorded.
+	recordedHeaders = []string{"Content-Type", "ETag", "Last-Modified", "Link", "Location"}
+
+	// sensitiveValueMatch matches GitHub tokens in recorded response bodies.
+	sensitiveValueMatch = regexp.MustCompile(`\b(gh[opsur]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,}|v1\.[0-9a-f]{40})\b`)
+
+	// recorders holds the open recorders by cassette path, so that every client in the process shares a cassette.
+	recorders   = map[string]*Recorder{}
+	recordersMu sync.Mutex
+)
+
+// recordedInteraction is a single request and response in a cassette; requests are identified by method, path (including the query) and the SHA-256 hash of the body.
+type recordedInteraction struct {
+	Method   string              `json:"method"`
+	Path     string              `json:"path"`
+	BodyHash string              `json:"body_hash,omitempty"`
+	Status   int                 `json:"status"`
+	Header   map[string][]string `json:"header,omitempty"`
+	Body     string              `json:"body,omitempty"`
+}
+
+// key returns the replay key for the interaction.
+func (i *recordedInteraction) key() string {
+	return i.Method + " " + i.Path + " " + i.BodyHash
+}
+
+// Recorder records the interactions of GitHub clients with the GitHub API to a cassette, or replays them from the cassette, so that acceptance tests can run offline. Cassettes are JSON Lines files covering both REST and GraphQL requests; secrets are never recorded as request headers and bodies aren't stored, response headers are limited to an allow list and tokens are redacted from response bodies.
+//
+// When replaying, interactions with the same key are served in the order they were recorded, with the last one repeated once they're exhausted, so that replay is deterministic.
+type Recorder struct {
+	mode RecorderMode
+	path string
+
+	mu       sync.Mutex
+	file     *os.File
+	replay   map[string][]*recordedInteraction
+	position map[string]int
+}
+
+// NewRecorder returns the recorder for the cassette at the provided path, opening it if it isn't already open; a cassette is only recorded or replayed by one recorder per process.
+func NewRecorder(mode RecorderMode, path string) (*Recorder, error) {
+	if path == "" {
+		return nil, errors.New("recorder cassette path cannot be empty")
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve recorder cassette path: %w", err)
+	}
+
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+
+	if r, ok := recorders[abs]; ok {
+		if r.mode != mode {
+			return nil, fmt.Errorf("recorder cassette %q is already open in %s mode", path, r.mode)
+		}
+		return r, nil
+	}
+
+	r := &Recorder{mode: mode, path: abs}
+
+	switch mode {
+	case RecorderModeRecord:
+		if err := os.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create recorder cassette directory: %w", err)
+		}
+
+		f, err := os.OpenFile(abs, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create recorder cassette: %w", err)
+		}
+		r.file = f
+	case RecorderModeReplay:
+		if err := r.load(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown recorder mode %q", mode)
+	}
+
+	recorders[abs] = r
+
+	return r, nil
+}
+
+// load reads the interactions from the cassette for replay.
+func (r *Recorder) load() error {
+	f, err := os.Open(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to open recorder cassette: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	r.replay = map[string][]*recordedInteraction{}
+	r.position = map[string]int{}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var i recordedInteraction
+		if err := json.Unmarshal(scanner.Bytes(), &i); err != nil {
+			return fmt.Errorf("failed to decode recorder cassette line %d: %w", line, err)
+		}
+
+		r.replay[i.key()] = append(r.replay[i.key()], &i)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read recorder cassette: %w", err)
+	}
+
+	return nil
+}
+
+// Mode returns the recorder mode.
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Close closes the cassette; it's only required when recording.
+func (r *Recorder) Close() error {
+	recordersMu.Lock()
+	delete(recorders, r.path)
+	recordersMu.Unlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Transport returns an [http.RoundTripper] which records or replays requests; when recording requests are sent with the provided RoundTripper. It should be the innermost transport so that every request sent to the GitHub API is captured.
+func (r *Recorder) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &recorderTransport{recorder: r, base: base}
+}
+
+// recorderTransport is an [http.RoundTripper] that records or replays requests with a [Recorder].
+type recorderTransport struct {
+	recorder *Recorder
+	base     http.RoundTripper
+}
+
+// RoundTrip records or replays the request.
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	bodyHash, err := requestBodyHash(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.recorder.mode == RecorderModeReplay {
+		return t.recorder.next(req, bodyHash)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	i := &recordedInteraction{
+		Method:   req.Method,
+		Path:     requestPath(req),
+		BodyHash: bodyHash,
+		Status:   resp.StatusCode,
+		Header:   map[string][]string{},
+		Body:     sensitiveValueMatch.ReplaceAllString(string(body), redactedValue),
+	}
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Values(h); len(v) > 0 {
+			i.Header[h] = v
+		}
+	}
+
+	if err := t.recorder.write(i); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// write appends the interaction to the cassette.
+func (r *Recorder) write(i *recordedInteraction) error {
+	b, err := json.Marshal(i)
+	if err != nil {
+		return fmt.Errorf("failed to encode recorded interaction: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return errors.New("recorder cassette is closed")
+	}
+
+	if _, err := r.file.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("failed to write recorder cassette: %w", err)
+	}
+
+	return nil
+}
+
+// next returns the next recorded response for the request.
+func (r *Recorder) next(req *http.Request, bodyHash string) (*http.Response, error) {
+	key := (&recordedInteraction{Method: req.Method, Path: requestPath(req), BodyHash: bodyHash}).key()
+
+	r.mu.Lock()
+	interactions := r.replay[key]
+	if len(interactions) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("no recorded interaction for %s %s", req.Method, requestPath(req))
+	}
+
+	n := r.position[key]
+	if n < len(interactions)-1 {
+		r.position[key] = n + 1
+	}
+	r.mu.Unlock()
+
+	i := interactions[n]
+
+	header := http.Header{}
+	for k, v := range i.Header {
+		for _, s := range v {
+			header.Add(k, s)
+		}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+		StatusCode:    i.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(i.Body)),
+		ContentLength: int64(len(i.Body)),
+		Request:       req,
+	}, nil
+}
+
+// requestPath returns the request path including the query, with the query parameters sorted by key.
+func requestPath(req *http.Request) string {
+	q := req.URL.Query()
+	if len(q) == 0 {
+		return req.URL.EscapedPath()
+	}
+
+	return req.URL.EscapedPath() + "?" + q.Encode()
+}
+
+// requestBodyHash returns the SHA-256 hash of the request body, restoring the body so it can still be sent. JSON bodies are re-encoded first so that the hash doesn't depend on formatting.
+func requestBodyHash(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(body) == 0 {
+		return "", nil
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		if b, err := json.Marshal(v); err == nil {
+			body = b
+		}
+	}
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
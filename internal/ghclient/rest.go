@@ -1,10 +1,10 @@
 package ghclient
 
 import (
+	"crypto"
 	"fmt"
 
 	"github.com/google/go-github/v89/github"
-	"github.com/jferrl/go-githubauth"
 	"golang.org/x/oauth2"
 )
 
@@ -15,22 +15,19 @@ func NewAnonymousRESTClient(opts ClientOptions) (*github.Client, error) {
 
 // NewAppRESTClient creates a new GitHub client authenticated as either the app itself (if installationID is nil) or as the specified installation (if installationID is provided), using the app's private key.
 func NewAppRESTClient(clientID string, privateKey []byte, installationID *int64, opts ClientOptions) (*github.Client, error) {
-	tokenSource, err := githubauth.NewApplicationTokenSource(clientID, privateKey)
+	signer, err := NewPEMSigner(privateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create app token source: %w", err)
 	}
 
-	if installationID != nil {
-		authOpts := []githubauth.InstallationTokenSourceOpt{}
-		if opts.BaseURL != "" {
-			u, err := opts.getRESTURL()
-			if err != nil {
-				return nil, fmt.Errorf("failed to get rest url: %w", err)
-			}
-			authOpts = append(authOpts, githubauth.WithBaseURL(*u))
-		}
+	return NewAppSignerRESTClient(clientID, signer, installationID, opts)
+}
 
-		tokenSource = githubauth.NewInstallationTokenSource(*installationID, tokenSource, authOpts...)
+// NewAppSignerRESTClient creates a new GitHub client authenticated as either the app itself (if installationID is nil) or as the specified installation (if installationID is provided), using the signer to sign the app JWTs so that the private key doesn't need to be loaded.
+func NewAppSignerRESTClient(clientID string, signer crypto.Signer, installationID *int64, opts ClientOptions) (*github.Client, error) {
+	tokenSource, err := newAppTokenSource(clientID, signer, installationID, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	return newRESTClient(tokenSource, opts)
@@ -0,0 +1,181 @@
+package ghclient
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+const (
+	// execSignerProtocolVersion is the version of the external signer protocol.
+	execSignerProtocolVersion = 1
+
+	// execSignerTimeout defines the timeout duration for a single external signer command invocation.
+	execSignerTimeout = 30 * time.Second
+)
+
+// NewPEMSigner creates a [crypto.Signer] for GitHub App JWTs from the provided PEM encoded RSA private key (PKCS #1 or PKCS #8).
+func NewPEMSigner(privateKey []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return nil, errors.New("no decodeable PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key must be an RSA key")
+	}
+
+	return rsaKey, nil
+}
+
+// NewFileSigner creates a [crypto.Signer] for GitHub App JWTs from the PEM encoded RSA private key in the file at the provided path.
+func NewFileSigner(path string) (crypto.Signer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
+	}
+
+	return NewPEMSigner(b)
+}
+
+// execSignerRequest is the request written to the standard input of an external signer command.
+type execSignerRequest struct {
+	Version   int    `json:"version"`
+	Operation string `json:"operation"`
+	Hash      string `json:"hash,omitempty"`
+	Digest    string `json:"digest,omitempty"`
+}
+
+// execSignerResponse is the response read from the standard output of an external signer command.
+type execSignerResponse struct {
+	PublicKey string `json:"public_key,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// execSigner is a [crypto.Signer] that delegates signing to an external command, in the style of a credential helper, so that the private key never enters the provider process. The command is run once per operation with a JSON request written to its standard input and must write a JSON response to its standard output:
+//
+//   - `{"version": 1, "operation": "public_key"}` must return `{"public_key": "<PEM encoded RSA public key>"}`.
+//   - `{"version": 1, "operation": "sign", "hash": "SHA256", "digest": "<base64 digest>"}` must return `{"signature": "<base64 RSASSA-PKCS1-v1_5 signature>"}`.
+type execSigner struct {
+	command   string
+	args      []string
+	publicKey *rsa.PublicKey
+}
+
+// NewExecSigner creates a [crypto.Signer] for GitHub App JWTs that delegates to the provided external command, requesting the public key from the command up front.
+func NewExecSigner(ctx context.Context, command string, args []string) (crypto.Signer, error) {
+	if command == "" {
+		return nil, errors.New("signer command is required")
+	}
+
+	s := &execSigner{command: command, args: args}
+
+	resp, err := s.run(ctx, execSignerRequest{Version: execSignerProtocolVersion, Operation: "public_key"})
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(resp.PublicKey))
+	if block == nil {
+		return nil, errors.New("signer command returned no decodeable public key")
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		rsaKey, pkcs1Err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if pkcs1Err != nil {
+			return nil, fmt.Errorf("failed to parse signer public key: %w", err)
+		}
+		publicKey = rsaKey
+	}
+
+	rsaKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("signer public key must be an RSA key")
+	}
+	s.publicKey = rsaKey
+
+	return s, nil
+}
+
+// Public returns the public key of the external signer.
+func (s *execSigner) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+// Sign signs the digest using the external signer; only SHA-256 digests are supported as GitHub requires RS256.
+func (s *execSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts.HashFunc() != crypto.SHA256 {
+		return nil, fmt.Errorf("unsupported hash function %s", opts.HashFunc())
+	}
+
+	resp, err := s.run(context.Background(), execSignerRequest{
+		Version:   execSignerProtocolVersion,
+		Operation: "sign",
+		Hash:      "SHA256",
+		Digest:    base64.StdEncoding.EncodeToString(digest),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature from signer command: %w", err)
+	}
+
+	if len(signature) == 0 {
+		return nil, errors.New("signer command returned an empty signature")
+	}
+
+	return signature, nil
+}
+
+// run runs the external signer command with the provided request and decodes its response.
+func (s *execSigner) run(ctx context.Context, req execSignerRequest) (*execSignerResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, execSignerTimeout)
+	defer cancel()
+
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.command, s.args...)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("signer command failed for operation %q: %w: %s", req.Operation, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	var resp execSignerResponse
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode signer command response for operation %q: %w", req.Operation, err)
+	}
+
+	return &resp, nil
+}
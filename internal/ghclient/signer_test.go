@@ -0,0 +1,273 @@
+package ghclient
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestExecSignerHelperProcess isn't a real test; it's run as the external signer command by the exec signer tests.
+func TestExecSignerHelperProcess(t *testing.T) {
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	if len(args) != 3 {
+		return
+	}
+	mode, keyPath := args[1], args[2]
+
+	defer os.Exit(0)
+
+	if mode == "fail" {
+		fmt.Fprint(os.Stderr, "key not found")
+		os.Exit(1)
+	}
+
+	var req execSignerRequest
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		os.Exit(2)
+	}
+
+	signer, err := NewFileSigner(keyPath)
+	if err != nil {
+		os.Exit(3)
+	}
+
+	var resp execSignerResponse
+	switch req.Operation {
+	case "public_key":
+		if mode == "invalid_public_key" {
+			resp.PublicKey = "invalid"
+			break
+		}
+
+		b, err := x509.MarshalPKIXPublicKey(signer.Public())
+		if err != nil {
+			os.Exit(4)
+		}
+		resp.PublicKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b}))
+	case "sign":
+		digest, err := base64.StdEncoding.DecodeString(req.Digest)
+		if err != nil || req.Hash != "SHA256" {
+			os.Exit(5)
+		}
+
+		signature, err := signer.Sign(rand.Reader, digest, crypto.SHA256)
+		if err != nil {
+			os.Exit(6)
+		}
+		resp.Signature = base64.StdEncoding.EncodeToString(signature)
+	}
+
+	_ = json.NewEncoder(os.Stdout).Encode(resp)
+}
+
+func testExecSignerCommand(t *testing.T, mode string) (string, []string) {
+	t.Helper()
+
+	keyPath, err := filepath.Abs(filepath.Join("..", "..", "github", "test-fixtures", "github-app-key.pem"))
+	if err != nil {
+		t.Fatalf("failed to resolve key path: %v", err)
+	}
+
+	return os.Args[0], []string{"-test.run=^TestExecSignerHelperProcess$", "--", mode, keyPath}
+}
+
+func TestNewPEMSigner(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		t.Fatalf("failed to marshal rsa key: %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+
+	ecPKCS8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("failed to marshal ecdsa key: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name       string
+		privateKey []byte
+		wantErr    string
+	}{
+		{
+			name:       "pkcs1",
+			privateKey: mustReadAppPrivateKey(t),
+		},
+		{
+			name:       "pkcs8",
+			privateKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+		},
+		{
+			name:       "errors_on_invalid_pem",
+			privateKey: []byte("invalid-private-key"),
+			wantErr:    "no decodeable PEM data found",
+		},
+		{
+			name:       "errors_on_non_rsa_key",
+			privateKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPKCS8}),
+			wantErr:    "private key must be an RSA key",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := NewPEMSigner(tt.privateKey)
+			if err != nil {
+				if tt.wantErr == "" {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if !regexp.MustCompile(regexp.QuoteMeta(tt.wantErr)).MatchString(err.Error()) {
+					t.Fatalf("expected error to match %q, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if tt.wantErr != "" {
+				t.Fatalf("expected error %q, got nil", tt.wantErr)
+			}
+
+			if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+				t.Fatal("expected signer to have an rsa public key")
+			}
+		})
+	}
+}
+
+func TestNewFileSigner(t *testing.T) {
+	t.Parallel()
+
+	signer, err := NewFileSigner(filepath.Join("..", "..", "github", "test-fixtures", "github-app-key.pem"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if signer == nil {
+		t.Fatal("expected signer to be non-nil")
+	}
+
+	if _, err := NewFileSigner(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Fatal("expected error for missing private key file, got nil")
+	}
+}
+
+func TestNewExecSigner(t *testing.T) {
+	t.Parallel()
+
+	want, err := NewPEMSigner(mustReadAppPrivateKey(t))
+	if err != nil {
+		t.Fatalf("failed to create pem signer: %v", err)
+	}
+
+	t.Run("signs_with_external_command", func(t *testing.T) {
+		t.Parallel()
+
+		command, args := testExecSignerCommand(t, "ok")
+
+		signer, err := NewExecSigner(t.Context(), command, args)
+		if err != nil {
+			t.Fatalf("failed to create exec signer: %v", err)
+		}
+
+		publicKey, ok := signer.Public().(*rsa.PublicKey)
+		if !ok || !publicKey.Equal(want.Public()) {
+			t.Fatal("expected exec signer public key to match the private key")
+		}
+
+		digest := sha256.Sum256([]byte("test-payload"))
+		signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+
+		if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature); err != nil {
+			t.Fatalf("expected signature to verify: %v", err)
+		}
+
+		if _, err := signer.Sign(rand.Reader, digest[:], crypto.SHA512); err == nil {
+			t.Fatal("expected error for unsupported hash function, got nil")
+		}
+
+		tokenSource, err := newAppTokenSource("123456789", signer, nil, ClientOptions{})
+		if err != nil {
+			t.Fatalf("failed to create app token source: %v", err)
+		}
+
+		token, err := tokenSource.Token()
+		if err != nil {
+			t.Fatalf("failed to mint app jwt: %v", err)
+		}
+
+		parts := strings.Split(token.AccessToken, ".")
+		if len(parts) != 3 {
+			t.Fatalf("expected app jwt to have 3 parts, got %d", len(parts))
+		}
+
+		jwtDigest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		jwtSignature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			t.Fatalf("failed to decode app jwt signature: %v", err)
+		}
+
+		if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, jwtDigest[:], jwtSignature); err != nil {
+			t.Fatalf("expected app jwt signature to verify: %v", err)
+		}
+	})
+
+	t.Run("errors_on_failed_command", func(t *testing.T) {
+		t.Parallel()
+
+		command, args := testExecSignerCommand(t, "fail")
+
+		_, err := NewExecSigner(t.Context(), command, args)
+		if err == nil || !strings.Contains(err.Error(), "key not found") {
+			t.Fatalf("expected error with command stderr, got %v", err)
+		}
+	})
+
+	t.Run("errors_on_invalid_public_key", func(t *testing.T) {
+		t.Parallel()
+
+		command, args := testExecSignerCommand(t, "invalid_public_key")
+
+		_, err := NewExecSigner(t.Context(), command, args)
+		if err == nil || !strings.Contains(err.Error(), "no decodeable public key") {
+			t.Fatalf("expected error for invalid public key, got %v", err)
+		}
+	})
+
+	t.Run("errors_on_missing_command", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewExecSigner(t.Context(), "", nil); err == nil {
+			t.Fatal("expected error for missing command, got nil")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package ghclient
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/google/go-github/v89/github"
 	"github.com/shurcooL/githubv4"
@@ -20,4 +21,7 @@ type Source interface {
 
 	// OwnerGraphQLClient returns a GitHub GraphQL client authenticated to access resources owned by the specified owner (which can be either a user or an organization). This method is only applicable for app and token sources.
 	OwnerGraphQLClient(ctx context.Context, owner string) (*githubv4.Client, error)
+
+	// OwnerGraphQLHTTPClient returns the HTTP client that the GraphQL client returned by OwnerGraphQLClient sends its requests with, e.g. to create a GraphQL client with an additional transport.
+	OwnerGraphQLHTTPClient(ctx context.Context, owner string) (*http.Client, error)
 }
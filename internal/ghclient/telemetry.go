@@ -0,0 +1,349 @@
+package ghclient
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// TelemetryScopeProvider is the telemetry scope for requests made while configuring the provider.
+	TelemetryScopeProvider = "provider"
+
+	// TelemetryScopeUnattributed is the telemetry scope for requests made without a scope in their context, e.g. by resources that don't pass their operation's context to the GitHub clients.
+	TelemetryScopeUnattributed = "unattributed"
+)
+
+// telemetryScopeKey is the context key for the telemetry scope.
+type telemetryScopeKey struct{}
+
+// telemetryRequestKey is the context key for the telemetry of a request.
+type telemetryRequestKey struct{}
+
+// WithTelemetryScope returns a copy of the context with the telemetry scope set, so that the requests made with the context are accounted to the scope (e.g. the resource type).
+func WithTelemetryScope(ctx context.Context, scope string) context.Context {
+	return context.WithValue(ctx, telemetryScopeKey{}, scope)
+}
+
+// TelemetryScopeTransport returns a transport setting the telemetry scope on the requests whose context has no scope, so that the requests of code that doesn't pass its operation's context to the client (e.g. legacy resource functions) are still accounted to the scope. It must wrap the transport returned by [Telemetry.Transport].
+func TelemetryScopeTransport(tr http.RoundTripper, scope string) http.RoundTripper {
+	return &telemetryScopeTransport{scope: scope, inner: tr}
+}
+
+// telemetryScopeTransport sets a default telemetry scope on requests.
+type telemetryScopeTransport struct {
+	scope string
+	inner http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface for the telemetryScopeTransport. It sets the scope on the request context unless the context already has one.
+func (t *telemetryScopeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Value(telemetryScopeKey{}).(string); ok {
+		return t.inner.RoundTrip(req)
+	}
+
+	return t.inner.RoundTrip(req.WithContext(WithTelemetryScope(req.Context(), t.scope)))
+}
+
+// telemetryScope returns the telemetry scope of the context, or [TelemetryScopeUnattributed] if the context has no scope.
+func telemetryScope(ctx context.Context) string {
+	if scope, ok := ctx.Value(telemetryScopeKey{}).(string); ok && scope != "" {
+		return scope
+	}
+
+	return TelemetryScopeUnattributed
+}
+
+// TelemetryCounts are the API request counts of a telemetry scope.
+type TelemetryCounts struct {
+	Requests                      int64 `json:"requests"`
+	CacheHits                     int64 `json:"cache_hits"`
+	Retries                       int64 `json:"retries"`
+	SecondaryRateLimitWaits       int64 `json:"secondary_rate_limit_waits"`
+	SecondaryRateLimitWaitSeconds int64 `json:"secondary_rate_limit_wait_seconds"`
+	BytesSent                     int64 `json:"bytes_sent"`
+	BytesReceived                 int64 `json:"bytes_received"`
+}
+
+// add adds the counts to c.
+func (c *TelemetryCounts) add(o TelemetryCounts) {
+	c.Requests += o.Requests
+	c.CacheHits += o.CacheHits
+	c.Retries += o.Retries
+	c.SecondaryRateLimitWaits += o.SecondaryRateLimitWaits
+	c.SecondaryRateLimitWaitSeconds += o.SecondaryRateLimitWaitSeconds
+	c.BytesSent += o.BytesSent
+	c.BytesReceived += o.BytesReceived
+}
+
+// fields returns the counts as structured log fields.
+func (c *TelemetryCounts) fields() map[string]any {
+	return map[string]any{
+		"requests":                          c.Requests,
+		"cache_hits":                        c.CacheHits,
+		"retries":                           c.Retries,
+		"secondary_rate_limit_waits":        c.SecondaryRateLimitWaits,
+		"secondary_rate_limit_wait_seconds": c.SecondaryRateLimitWaitSeconds,
+		"bytes_sent":                        c.BytesSent,
+		"bytes_received":                    c.BytesReceived,
+	}
+}
+
+// TelemetrySummary is the summary of the API requests accounted by a [Telemetry].
+type TelemetrySummary struct {
+	Time   time.Time                  `json:"time"`
+	Total  TelemetryCounts            `json:"total"`
+	Scopes map[string]TelemetryCounts `json:"scopes"`
+}
+
+// telemetryCounters are the API request counters of a telemetry scope; they're updated atomically as requests can be made concurrently.
+type telemetryCounters struct {
+	requests                      atomic.Int64
+	cacheHits                     atomic.Int64
+	retries                       atomic.Int64
+	secondaryRateLimitWaits       atomic.Int64
+	secondaryRateLimitWaitSeconds atomic.Int64
+	bytesSent                     atomic.Int64
+	bytesReceived                 atomic.Int64
+}
+
+// counts returns a snapshot of the counters.
+func (c *telemetryCounters) counts() TelemetryCounts {
+	return TelemetryCounts{
+		Requests:                      c.requests.Load(),
+		CacheHits:                     c.cacheHits.Load(),
+		Retries:                       c.retries.Load(),
+		SecondaryRateLimitWaits:       c.secondaryRateLimitWaits.Load(),
+		SecondaryRateLimitWaitSeconds: c.secondaryRateLimitWaitSeconds.Load(),
+		BytesSent:                     c.bytesSent.Load(),
+		BytesReceived:                 c.bytesReceived.Load(),
+	}
+}
+
+// telemetryRequest tracks the attempts made to serve a single request.
+type telemetryRequest struct {
+	counters *telemetryCounters
+	attempts atomic.Int64
+}
+
+// Telemetry accounts the requests made to the GitHub API per scope (e.g. per resource type), counting requests, conditional request cache hits, retries, secondary rate limit waits and bytes transferred. It's shared by the transports of every client so that a single summary covers the whole provider process.
+type Telemetry struct {
+	mu         sync.Mutex
+	scopes     map[string]*telemetryCounters
+	outputPath string
+}
+
+// NewTelemetry creates a new telemetry with no requests accounted.
+func NewTelemetry() *Telemetry {
+	return &Telemetry{scopes: map[string]*telemetryCounters{}}
+}
+
+// SetOutputPath sets the path of the JSON Lines file that [Telemetry.Flush] appends the summary to; an empty path disables the file output.
+func (t *Telemetry) SetOutputPath(path string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.outputPath = path
+}
+
+// counters returns the counters for the scope, creating them if needed.
+func (t *Telemetry) counters(scope string) *telemetryCounters {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c, ok := t.scopes[scope]
+	if !ok {
+		c = &telemetryCounters{}
+		t.scopes[scope] = c
+	}
+
+	return c
+}
+
+// Summary returns the summary of the requests accounted so far.
+func (t *Telemetry) Summary() TelemetrySummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	summary := TelemetrySummary{Time: time.Now().UTC(), Scopes: make(map[string]TelemetryCounts, len(t.scopes))}
+	for scope, c := range t.scopes {
+		counts := c.counts()
+		summary.Scopes[scope] = counts
+		summary.Total.add(counts)
+	}
+
+	return summary
+}
+
+// Flush logs the summary of the requests accounted so far as structured log entries, one for the total and one per scope ordered by the number of requests, and appends it to the output file if one is set. Nothing is logged or written if no requests have been made.
+func (t *Telemetry) Flush(ctx context.Context) error {
+	summary := t.Summary()
+	if summary.Total.Requests == 0 {
+		return nil
+	}
+
+	tflog.Info(ctx, "GitHub API telemetry summary.", summary.Total.fields())
+
+	scopes := slices.SortedFunc(maps.Keys(summary.Scopes), func(a, b string) int {
+		return cmp.Or(cmp.Compare(summary.Scopes[b].Requests, summary.Scopes[a].Requests), cmp.Compare(a, b))
+	})
+	for _, scope := range scopes {
+		counts := summary.Scopes[scope]
+		fields := counts.fields()
+		fields["scope"] = scope
+		tflog.Info(ctx, "GitHub API telemetry for scope.", fields)
+	}
+
+	t.mu.Lock()
+	path := t.outputPath
+	t.mu.Unlock()
+
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("failed to marshal telemetry summary: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create telemetry directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open telemetry file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write telemetry file: %w", err)
+	}
+
+	return nil
+}
+
+// Transport returns a transport accounting the requests made through it; it must be the outermost transport of a client so that each request is counted once, regardless of retries and caching in the transports it wraps. Use [Telemetry.AttemptTransport] for the innermost transport.
+func (t *Telemetry) Transport(tr http.RoundTripper) http.RoundTripper {
+	return &telemetryTransport{telemetry: t, inner: tr}
+}
+
+// AttemptTransport returns a transport accounting the attempts sent to the GitHub API through it, counting conditional request cache hits, secondary rate limits and bytes transferred; it must be the innermost transport of a client, under any retry and caching transports. Use [Telemetry.Transport] for the outermost transport.
+func (t *Telemetry) AttemptTransport(tr http.RoundTripper) http.RoundTripper {
+	return &telemetryAttemptTransport{telemetry: t, inner: tr}
+}
+
+// telemetryTransport accounts requests and their retries.
+type telemetryTransport struct {
+	telemetry *Telemetry
+	inner     http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface for the telemetryTransport. It counts the request against the scope of its context and, once the request is complete, counts every attempt after the first as a retry.
+func (t *telemetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Value(telemetryRequestKey{}).(*telemetryRequest); ok {
+		return t.inner.RoundTrip(req)
+	}
+
+	tr := &telemetryRequest{counters: t.telemetry.counters(telemetryScope(req.Context()))}
+	tr.counters.requests.Add(1)
+
+	res, err := t.inner.RoundTrip(req.WithContext(context.WithValue(req.Context(), telemetryRequestKey{}, tr)))
+
+	if attempts := tr.attempts.Load(); attempts > 1 {
+		tr.counters.retries.Add(attempts - 1)
+	}
+
+	return res, err
+}
+
+// telemetryAttemptTransport accounts the attempts sent to the GitHub API.
+type telemetryAttemptTransport struct {
+	telemetry *Telemetry
+	inner     http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface for the telemetryAttemptTransport. It counts the attempt against the request it's made for, falling back to the scope of its context, and counts not modified responses as cache hits, secondary rate limits along with their wait time and the bytes sent and received.
+func (t *telemetryAttemptTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var counters *telemetryCounters
+	if tr, ok := req.Context().Value(telemetryRequestKey{}).(*telemetryRequest); ok {
+		tr.attempts.Add(1)
+		counters = tr.counters
+	} else {
+		counters = t.telemetry.counters(telemetryScope(req.Context()))
+	}
+
+	if req.ContentLength > 0 {
+		counters.bytesSent.Add(req.ContentLength)
+	}
+
+	res, err := t.inner.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotModified {
+		counters.cacheHits.Add(1)
+	}
+
+	if wait, ok := secondaryRateLimitWait(res); ok {
+		counters.secondaryRateLimitWaits.Add(1)
+		counters.secondaryRateLimitWaitSeconds.Add(int64(wait / time.Second))
+	}
+
+	if res.Body != nil {
+		res.Body = &telemetryReadCloser{ReadCloser: res.Body, counters: counters}
+	}
+
+	return res, nil
+}
+
+// telemetryReadCloser counts the bytes read from a response body.
+type telemetryReadCloser struct {
+	io.ReadCloser
+	counters *telemetryCounters
+}
+
+// Read implements the io.Reader interface for the telemetryReadCloser, counting the bytes read as received.
+func (c *telemetryReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.counters.bytesReceived.Add(int64(n))
+	return n, err
+}
+
+// secondaryRateLimitWait returns the time to wait before retrying if the response is a secondary rate limit response; GitHub returns secondary rate limits as forbidden or too many requests responses with a `Retry-After` header while the primary rate limit still has requests remaining.
+func secondaryRateLimitWait(res *http.Response) (time.Duration, bool) {
+	if res.StatusCode != http.StatusForbidden && res.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if res.Header.Get("X-Ratelimit-Remaining") == "0" {
+		return 0, false
+	}
+
+	retryAfter := res.Header.Get("Retry-After")
+	if retryAfter == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.ParseInt(retryAfter, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, true
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
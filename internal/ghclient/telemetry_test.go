@@ -0,0 +1,285 @@
+package ghclient
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTelemetry(t *testing.T) {
+	t.Parallel()
+
+	// get makes a GET request with the telemetry scope and reads the response body.
+	get := func(t *testing.T, client *http.Client, url, scope string) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+
+		if scope != "" {
+			req = req.WithContext(WithTelemetryScope(req.Context(), scope))
+		}
+
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("failed to make request: %v", err)
+		}
+		defer res.Body.Close()
+
+		if _, err := io.ReadAll(res.Body); err != nil {
+			t.Fatalf("failed to read response body: %v", err)
+		}
+
+		return res
+	}
+
+	t.Run("accounts_requests_per_scope", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+			_, _ = w.Write([]byte("PASS"))
+		}))
+		defer ts.Close()
+
+		telemetry := NewTelemetry()
+		client := &http.Client{Transport: telemetry.Transport(telemetry.AttemptTransport(http.DefaultTransport))}
+
+		get(t, client, ts.URL, "github_repository")
+		get(t, client, ts.URL, "github_repository")
+		get(t, client, ts.URL, "data.github_team")
+		get(t, client, ts.URL, "")
+
+		req, err := http.NewRequestWithContext(WithTelemetryScope(t.Context(), "github_team"), http.MethodPost, ts.URL, strings.NewReader(`{"name":"team"}`))
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("failed to make request: %v", err)
+		}
+		res.Body.Close()
+
+		summary := telemetry.Summary()
+
+		for scope, want := range map[string]TelemetryCounts{
+			"github_repository":        {Requests: 2, BytesReceived: 8},
+			"data.github_team":         {Requests: 1, BytesReceived: 4},
+			"github_team":              {Requests: 1, BytesSent: 15},
+			TelemetryScopeUnattributed: {Requests: 1, BytesReceived: 4},
+		} {
+			if got := summary.Scopes[scope]; got != want {
+				t.Errorf("expected counts for scope %q to be %+v, got %+v", scope, want, got)
+			}
+		}
+
+		if summary.Total.Requests != 5 {
+			t.Errorf("expected %d total requests, got %d", 5, summary.Total.Requests)
+		}
+	})
+
+	t.Run("accounts_requests_without_scope_to_the_transport_scope", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("PASS"))
+		}))
+		defer ts.Close()
+
+		telemetry := NewTelemetry()
+		client := &http.Client{Transport: TelemetryScopeTransport(telemetry.Transport(telemetry.AttemptTransport(http.DefaultTransport)), "github_repository")}
+
+		get(t, client, ts.URL, "")
+		get(t, client, ts.URL, "data.github_team")
+
+		summary := telemetry.Summary()
+
+		for scope, want := range map[string]TelemetryCounts{
+			"github_repository": {Requests: 1, BytesReceived: 4},
+			"data.github_team":  {Requests: 1, BytesReceived: 4},
+		} {
+			if got := summary.Scopes[scope]; got != want {
+				t.Errorf("expected counts for scope %q to be %+v, got %+v", scope, want, got)
+			}
+		}
+
+		if _, ok := summary.Scopes[TelemetryScopeUnattributed]; ok {
+			t.Error("expected no unattributed requests")
+		}
+	})
+
+	t.Run("counts_retries", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= 2 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			_, _ = w.Write([]byte("PASS"))
+		}))
+		defer ts.Close()
+
+		telemetry := NewTelemetry()
+		tr, err := newTransport(nil, ClientOptions{RetryMax: 3, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond, Telemetry: telemetry})
+		if err != nil {
+			t.Fatalf("failed to create transport: %v", err)
+		}
+
+		get(t, &http.Client{Transport: tr}, ts.URL, "github_repository")
+
+		got := telemetry.Summary().Scopes["github_repository"]
+		if got.Requests != 1 {
+			t.Errorf("expected %d requests, got %d", 1, got.Requests)
+		}
+		if got.Retries != 2 {
+			t.Errorf("expected %d retries, got %d", 2, got.Retries)
+		}
+	})
+
+	t.Run("counts_cache_hits", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"etag"`)
+			if r.Header.Get("If-None-Match") == `"etag"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			_, _ = w.Write([]byte("PASS"))
+		}))
+		defer ts.Close()
+
+		telemetry := NewTelemetry()
+		client := &http.Client{Transport: telemetry.Transport(telemetry.AttemptTransport(http.DefaultTransport))}
+
+		get(t, client, ts.URL, "github_repository")
+
+		req, err := http.NewRequestWithContext(WithTelemetryScope(t.Context(), "github_repository"), http.MethodGet, ts.URL, nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		req.Header.Set("If-None-Match", `"etag"`)
+
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("failed to make request: %v", err)
+		}
+		res.Body.Close()
+
+		got := telemetry.Summary().Scopes["github_repository"]
+		if got.Requests != 2 {
+			t.Errorf("expected %d requests, got %d", 2, got.Requests)
+		}
+		if got.CacheHits != 1 {
+			t.Errorf("expected %d cache hits, got %d", 1, got.CacheHits)
+		}
+		if got.BytesReceived != 4 {
+			t.Errorf("expected %d bytes received, got %d", 4, got.BytesReceived)
+		}
+	})
+
+	t.Run("counts_secondary_rate_limits", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/secondary":
+				w.Header().Set("Retry-After", "30")
+				w.WriteHeader(http.StatusForbidden)
+			case "/primary":
+				w.Header().Set("Retry-After", "60")
+				w.Header().Set("X-Ratelimit-Remaining", "0")
+				w.WriteHeader(http.StatusForbidden)
+			default:
+				w.WriteHeader(http.StatusForbidden)
+			}
+		}))
+		defer ts.Close()
+
+		telemetry := NewTelemetry()
+		client := &http.Client{Transport: telemetry.Transport(telemetry.AttemptTransport(http.DefaultTransport))}
+
+		get(t, client, ts.URL+"/secondary", "github_repository")
+		get(t, client, ts.URL+"/secondary", "github_repository")
+		get(t, client, ts.URL+"/primary", "github_repository")
+		get(t, client, ts.URL+"/forbidden", "github_repository")
+
+		got := telemetry.Summary().Scopes["github_repository"]
+		if got.SecondaryRateLimitWaits != 2 {
+			t.Errorf("expected %d secondary rate limit waits, got %d", 2, got.SecondaryRateLimitWaits)
+		}
+		if got.SecondaryRateLimitWaitSeconds != 60 {
+			t.Errorf("expected %d secondary rate limit wait seconds, got %d", 60, got.SecondaryRateLimitWaitSeconds)
+		}
+	})
+
+	t.Run("flush_appends_summary_to_file", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("PASS"))
+		}))
+		defer ts.Close()
+
+		path := filepath.Join(t.TempDir(), "telemetry", "summary.jsonl")
+
+		telemetry := NewTelemetry()
+		telemetry.SetOutputPath(path)
+
+		if err := telemetry.Flush(t.Context()); err != nil {
+			t.Fatalf("failed to flush telemetry: %v", err)
+		}
+
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("expected no file to be written without requests, got %v", err)
+		}
+
+		client := &http.Client{Transport: telemetry.Transport(telemetry.AttemptTransport(http.DefaultTransport))}
+		get(t, client, ts.URL, "github_repository")
+
+		for range 2 {
+			if err := telemetry.Flush(t.Context()); err != nil {
+				t.Fatalf("failed to flush telemetry: %v", err)
+			}
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("failed to open telemetry file: %v", err)
+		}
+		defer f.Close()
+
+		lines := 0
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			lines++
+
+			var summary TelemetrySummary
+			if err := json.Unmarshal(scanner.Bytes(), &summary); err != nil {
+				t.Fatalf("failed to unmarshal telemetry summary: %v", err)
+			}
+
+			if summary.Total.Requests != 1 || summary.Scopes["github_repository"].Requests != 1 {
+				t.Errorf("expected summary to have 1 request for github_repository, got %+v", summary)
+			}
+		}
+
+		if lines != 2 {
+			t.Errorf("expected %d summaries, got %d", 2, lines)
+		}
+	})
+}
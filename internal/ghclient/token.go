@@ -3,17 +3,20 @@ package ghclient
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/google/go-github/v89/github"
 	"github.com/shurcooL/githubv4"
+	"golang.org/x/oauth2"
 	"golang.org/x/sync/semaphore"
 )
 
 // tokenSource is a concrete implementation of a [Source] that uses the provided token credentials to create GitHub clients.
 type tokenSource struct {
-	restClient    *github.Client
-	graphQLClient *githubv4.Client
+	restClient        *github.Client
+	graphQLClient     *githubv4.Client
+	graphQLHTTPClient *http.Client
 }
 
 // NewTokenSource creates a new tokenSource that provides a GitHub client authenticated with the provided personal access token.
@@ -33,14 +36,15 @@ func NewTokenSource(token string, opts SourceOptions) (*tokenSource, error) {
 		return nil, err
 	}
 
-	graphQLClient, err := NewTokenGraphQLClient(token, opts.getGraphQLClientOptions(sema))
+	graphQLClient, graphQLHTTPClient, err := newGraphQLClientWithHTTPClient(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}), opts.getGraphQLClientOptions(sema))
 	if err != nil {
 		return nil, err
 	}
 
 	return &tokenSource{
-		restClient:    client,
-		graphQLClient: graphQLClient,
+		restClient:        client,
+		graphQLClient:     graphQLClient,
+		graphQLHTTPClient: graphQLHTTPClient,
 	}, nil
 }
 
@@ -63,3 +67,8 @@ func (s *tokenSource) GraphQLClient() (*githubv4.Client, error) {
 func (s *tokenSource) OwnerGraphQLClient(_ context.Context, _ string) (*githubv4.Client, error) {
 	return s.GraphQLClient()
 }
+
+// OwnerGraphQLHTTPClient returns the HTTP client of the GraphQL client for the specified owner, which is the same for any owner.
+func (s *tokenSource) OwnerGraphQLHTTPClient(_ context.Context, _ string) (*http.Client, error) {
+	return s.graphQLHTTPClient, nil
+}
@@ -0,0 +1,215 @@
+package ghclient
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// tokenCacheExpirySkew defines how long before a cached token expires that it stops being reused, so that a token handed out by the cache is always valid for at least this long.
+	tokenCacheExpirySkew = 5 * time.Minute
+
+	// tokenCacheKeyInfo is the context used when deriving the token cache encryption key from the app signer; changing it invalidates all cached tokens.
+	tokenCacheKeyInfo = "terraform-provider-github token cache v1"
+)
+
+// TokenCacheKey identifies an installation token in the [TokenCache]; tokens are only shared between requests for the same app, installation and permissions.
+type TokenCacheKey struct {
+	BaseURL        string            `json:"base_url"`
+	AppID          string            `json:"app_id"`
+	InstallationID string            `json:"installation_id"`
+	Permissions    map[string]string `json:"permissions,omitempty"`
+	Repositories   []string          `json:"repositories,omitempty"`
+}
+
+// id returns the stable identifier for the key, which is used as the cache file name.
+func (k TokenCacheKey) id() (string, error) {
+	b, err := json.Marshal(k)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// TokenCache is a persistent on-disk cache of GitHub App installation tokens which can be shared by parallel provider processes. Each token is stored in its own file, encrypted with a key derived from the app signer so that only holders of the app key can read it, and access is serialized with a file lock so that only one process exchanges a token at a time.
+type TokenCache struct {
+	dir string
+}
+
+// NewTokenCache creates a new TokenCache storing tokens in the provided directory.
+func NewTokenCache(dir string) (*TokenCache, error) {
+	if dir == "" {
+		return nil, errors.New("token cache path cannot be empty")
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create token cache directory: %w", err)
+	}
+
+	return &TokenCache{dir: dir}, nil
+}
+
+// TokenSource returns an [oauth2.TokenSource] which returns the cached token for the key while it's valid, otherwise it gets a new token from the source and caches it. The signer is used to derive the encryption key for the cached token.
+func (c *TokenCache) TokenSource(key TokenCacheKey, signer crypto.Signer, src oauth2.TokenSource) (oauth2.TokenSource, error) {
+	id, err := key.id()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token cache key: %w", err)
+	}
+
+	aead, err := newTokenCacheAEAD(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth2.ReuseTokenSource(nil, &cachedTokenSource{
+		path: filepath.Join(c.dir, id+".token"),
+		id:   []byte(id),
+		aead: aead,
+		src:  src,
+	}), nil
+}
+
+// newTokenCacheAEAD derives the token cache encryption key by signing a fixed digest with the app signer; as RS256 signatures are deterministic, every process using the same app key derives the same encryption key.
+func newTokenCacheAEAD(signer crypto.Signer) (cipher.AEAD, error) {
+	digest := sha256.Sum256([]byte(tokenCacheKeyInfo))
+	secret, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive token cache key: %w", err)
+	}
+
+	key, err := hkdf.Key(sha256.New, secret, nil, tokenCacheKeyInfo, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive token cache key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// cachedToken is the content of a token cache entry.
+type cachedToken struct {
+	AccessToken string    `json:"access_token"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+// cachedTokenSource is an [oauth2.TokenSource] backed by a single token cache entry.
+type cachedTokenSource struct {
+	path string
+	id   []byte
+	aead cipher.AEAD
+	src  oauth2.TokenSource
+}
+
+// Token returns the cached token if it's valid for at least [tokenCacheExpirySkew], otherwise it gets a new token from the source and caches it. The cache entry is locked for the duration so parallel processes wait for the token rather than exchanging their own.
+func (s *cachedTokenSource) Token() (*oauth2.Token, error) {
+	lock, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open token cache lock: %w", err)
+	}
+	defer func() { _ = lock.Close() }()
+
+	if err := lockFile(lock); err != nil {
+		return nil, fmt.Errorf("failed to lock token cache: %w", err)
+	}
+	defer func() { _ = unlockFile(lock) }()
+
+	if token, ok := s.read(); ok {
+		return token, nil
+	}
+
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	// The cache is best effort; failing to persist the token shouldn't fail the request.
+	_ = s.write(token)
+
+	return token, nil
+}
+
+// read returns the cached token if the cache entry exists, can be decrypted and is valid for at least [tokenCacheExpirySkew]; any other state is treated as a cache miss.
+func (s *cachedTokenSource) read() (*oauth2.Token, bool) {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, false
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(b) < nonceSize {
+		return nil, false
+	}
+
+	plaintext, err := s.aead.Open(nil, b[:nonceSize], b[nonceSize:], s.id)
+	if err != nil {
+		return nil, false
+	}
+
+	var ct cachedToken
+	if err := json.Unmarshal(plaintext, &ct); err != nil || ct.AccessToken == "" {
+		return nil, false
+	}
+
+	if ct.Expiry.IsZero() || time.Until(ct.Expiry) < tokenCacheExpirySkew {
+		return nil, false
+	}
+
+	return &oauth2.Token{AccessToken: ct.AccessToken, TokenType: "Bearer", Expiry: ct.Expiry}, true
+}
+
+// write encrypts the token and atomically replaces the cache entry; tokens without an expiry aren't cached.
+func (s *cachedTokenSource) write(token *oauth2.Token) error {
+	if token.Expiry.IsZero() {
+		return nil
+	}
+
+	plaintext, err := json.Marshal(cachedToken{AccessToken: token.AccessToken, Expiry: token.Expiry})
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), ".token-*")
+	if err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if _, err := f.Write(s.aead.Seal(nonce, nonce, plaintext, s.id)); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+
+	return nil
+}
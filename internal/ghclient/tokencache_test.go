@@ -0,0 +1,244 @@
+package ghclient
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// countingTokenSource is an [oauth2.TokenSource] returning tokens with the configured lifetime and counting how often it's called.
+type countingTokenSource struct {
+	calls    atomic.Int32
+	lifetime time.Duration
+	err      error
+}
+
+func (s *countingTokenSource) Token() (*oauth2.Token, error) {
+	n := s.calls.Add(1)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &oauth2.Token{AccessToken: fmt.Sprintf("token-%d", n), Expiry: time.Now().Add(s.lifetime)}, nil
+}
+
+func TestTokenCache(t *testing.T) {
+	t.Parallel()
+
+	signer, err := NewPEMSigner(mustReadAppPrivateKey(t))
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	otherSigner, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+
+	key := TokenCacheKey{BaseURL: "https://api.github.com/", AppID: "123", InstallationID: "456"}
+
+	t.Run("reuses_cached_token_across_sources", func(t *testing.T) {
+		t.Parallel()
+
+		cache, err := NewTokenCache(filepath.Join(t.TempDir(), "tokens"))
+		if err != nil {
+			t.Fatalf("failed to create token cache: %v", err)
+		}
+
+		src := &countingTokenSource{lifetime: time.Hour}
+		for range 3 {
+			ts, err := cache.TokenSource(key, signer, src)
+			if err != nil {
+				t.Fatalf("failed to create token source: %v", err)
+			}
+
+			token, err := ts.Token()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if token.AccessToken != "token-1" {
+				t.Fatalf("expected cached token %q, got %q", "token-1", token.AccessToken)
+			}
+		}
+
+		if src.calls.Load() != 1 {
+			t.Fatalf("expected source to be called once, got %d calls", src.calls.Load())
+		}
+	})
+
+	t.Run("separates_tokens_by_key", func(t *testing.T) {
+		t.Parallel()
+
+		cache, err := NewTokenCache(t.TempDir())
+		if err != nil {
+			t.Fatalf("failed to create token cache: %v", err)
+		}
+
+		src := &countingTokenSource{lifetime: time.Hour}
+		for _, k := range []TokenCacheKey{key, {BaseURL: key.BaseURL, AppID: key.AppID, InstallationID: "789"}, {BaseURL: key.BaseURL, AppID: key.AppID, InstallationID: key.InstallationID, Permissions: map[string]string{"contents": "read"}}} {
+			ts, err := cache.TokenSource(k, signer, src)
+			if err != nil {
+				t.Fatalf("failed to create token source: %v", err)
+			}
+
+			if _, err := ts.Token(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if src.calls.Load() != 3 {
+			t.Fatalf("expected source to be called for each key, got %d calls", src.calls.Load())
+		}
+	})
+
+	t.Run("refreshes_token_close_to_expiry", func(t *testing.T) {
+		t.Parallel()
+
+		cache, err := NewTokenCache(t.TempDir())
+		if err != nil {
+			t.Fatalf("failed to create token cache: %v", err)
+		}
+
+		src := &countingTokenSource{lifetime: tokenCacheExpirySkew - time.Minute}
+		for range 2 {
+			ts, err := cache.TokenSource(key, signer, src)
+			if err != nil {
+				t.Fatalf("failed to create token source: %v", err)
+			}
+
+			if _, err := ts.Token(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if src.calls.Load() != 2 {
+			t.Fatalf("expected source to be called for each expiring token, got %d calls", src.calls.Load())
+		}
+	})
+
+	t.Run("ignores_entries_encrypted_with_another_key", func(t *testing.T) {
+		t.Parallel()
+
+		cache, err := NewTokenCache(t.TempDir())
+		if err != nil {
+			t.Fatalf("failed to create token cache: %v", err)
+		}
+
+		src := &countingTokenSource{lifetime: time.Hour}
+		for _, s := range []*rsa.PrivateKey{otherSigner, signer.(*rsa.PrivateKey)} {
+			ts, err := cache.TokenSource(key, s, src)
+			if err != nil {
+				t.Fatalf("failed to create token source: %v", err)
+			}
+
+			if _, err := ts.Token(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if src.calls.Load() != 2 {
+			t.Fatalf("expected source to be called for each signer, got %d calls", src.calls.Load())
+		}
+	})
+
+	t.Run("ignores_corrupt_entries", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		cache, err := NewTokenCache(dir)
+		if err != nil {
+			t.Fatalf("failed to create token cache: %v", err)
+		}
+
+		id, err := key.id()
+		if err != nil {
+			t.Fatalf("failed to create key id: %v", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, id+".token"), []byte("corrupt"), 0o600); err != nil {
+			t.Fatalf("failed to write cache entry: %v", err)
+		}
+
+		src := &countingTokenSource{lifetime: time.Hour}
+		ts, err := cache.TokenSource(key, signer, src)
+		if err != nil {
+			t.Fatalf("failed to create token source: %v", err)
+		}
+
+		token, err := ts.Token()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if token.AccessToken != "token-1" || src.calls.Load() != 1 {
+			t.Fatalf("expected a new token from the source, got %q after %d calls", token.AccessToken, src.calls.Load())
+		}
+	})
+
+	t.Run("returns_source_errors", func(t *testing.T) {
+		t.Parallel()
+
+		cache, err := NewTokenCache(t.TempDir())
+		if err != nil {
+			t.Fatalf("failed to create token cache: %v", err)
+		}
+
+		ts, err := cache.TokenSource(key, signer, &countingTokenSource{err: errors.New("exchange failed")})
+		if err != nil {
+			t.Fatalf("failed to create token source: %v", err)
+		}
+
+		if _, err := ts.Token(); err == nil || err.Error() != "exchange failed" {
+			t.Fatalf("expected source error, got %v", err)
+		}
+	})
+
+	t.Run("serializes_parallel_exchanges", func(t *testing.T) {
+		t.Parallel()
+
+		cache, err := NewTokenCache(t.TempDir())
+		if err != nil {
+			t.Fatalf("failed to create token cache: %v", err)
+		}
+
+		src := &countingTokenSource{lifetime: time.Hour}
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Go(func() {
+				ts, err := cache.TokenSource(key, signer, src)
+				if err != nil {
+					t.Errorf("failed to create token source: %v", err)
+					return
+				}
+
+				if _, err := ts.Token(); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+		}
+		wg.Wait()
+
+		if src.calls.Load() != 1 {
+			t.Fatalf("expected source to be called once, got %d calls", src.calls.Load())
+		}
+	})
+}
+
+func TestNewTokenCache(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewTokenCache(""); err == nil {
+		t.Fatal("expected error for empty path, got nil")
+	}
+}
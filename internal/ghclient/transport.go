@@ -1,6 +1,8 @@
 package ghclient
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -28,10 +30,22 @@ func cloneTransport(tr http.RoundTripper, opts ClientOptions) http.RoundTripper
 	return tr
 }
 
-// newTransport creates a new HTTP RoundTripper that wraps the provided token source with OAuth2 authentication, adds conditional request caching, logging, and retry logic based on the provided options. The resulting RoundTripper is designed to be used with GitHub API clients to handle authentication, caching, rate limiting, and retries in a consistent manner.
+// newTransport creates a new HTTP RoundTripper that wraps the provided token source with OAuth2 authentication, adds conditional request caching, logging, retry logic and telemetry based on the provided options. The resulting RoundTripper is designed to be used with GitHub API clients to handle authentication, caching, rate limiting, and retries in a consistent manner.
 func newTransport(tokenSource oauth2.TokenSource, opts ClientOptions) (http.RoundTripper, error) {
 	tr := cloneTransport(http.DefaultTransport, opts)
 
+	if opts.Recorder != nil {
+		tr = opts.Recorder.Transport(tr)
+	}
+
+	if opts.Telemetry != nil {
+		tr = opts.Telemetry.AttemptTransport(tr)
+	}
+
+	if opts.Budget != nil {
+		tr = opts.Budget.Transport(tr)
+	}
+
 	if tokenSource != nil {
 		tr = &oauth2.Transport{
 			Base:   tr,
@@ -48,6 +62,7 @@ func newTransport(tokenSource oauth2.TokenSource, opts ClientOptions) (http.Roun
 		retryClient.RetryMax = opts.RetryMax
 		retryClient.RetryWaitMin = opts.RetryWaitMin
 		retryClient.RetryWaitMax = opts.RetryWaitMax
+		retryClient.CheckRetry = checkRetry
 
 		tr = &retryablehttp.RoundTripper{Client: retryClient}
 	}
@@ -67,9 +82,22 @@ func newTransport(tokenSource oauth2.TokenSource, opts ClientOptions) (http.Roun
 		tr = ghct.NewTransport(store, tr)
 	}
 
+	if opts.Telemetry != nil {
+		tr = opts.Telemetry.Transport(tr)
+	}
+
 	return tr, nil
 }
 
+// checkRetry is the retry policy of the retry client; it's the default policy, except that requests failed by the rate limit budget aren't retried.
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if _, ok := errors.AsType[*RateLimitBudgetError](err); ok {
+		return false, err
+	}
+
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
 // primaryRateLimitCallback is a callback function that is called when the GitHub API primary rate limit is detected. It logs a warning message with the category of the rate limit and the reset time.
 func primaryRateLimitCallback(cb *ratelimitp.CallbackContext) {
 	tflog.Warn(cb.Request.Context(), "GitHub API primary rate limit detected.", map[string]any{"category": cb.Category, "reset_time": cb.ResetTime})
@@ -0,0 +1,154 @@
+package ghfake
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// organization is the organization level state of the fake.
+type organization struct {
+	secrets   map[string]*secret
+	variables map[string]*variable
+	rulesets  map[int64]*github.RepositoryRuleset
+	runners   []*github.Runner
+}
+
+func (s *Server) registerAccountRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /user", s.getViewer)
+	mux.HandleFunc("GET /users/{login}", s.getUser)
+	mux.HandleFunc("GET /orgs/{org}", s.getOrganization)
+	mux.HandleFunc("GET /orgs/{org}/members", s.listOrgMembers)
+	mux.HandleFunc("GET /orgs/{org}/memberships/{user}", s.getOrgMembership)
+	mux.HandleFunc("PUT /orgs/{org}/memberships/{user}", s.setOrgMembership)
+	mux.HandleFunc("DELETE /orgs/{org}/memberships/{user}", s.removeOrgMembership)
+}
+
+// account returns the user or organization account for the login.
+func (s *Server) account(login string) (*github.User, bool) {
+	account, ok := s.accounts[strings.ToLower(login)]
+	return account, ok
+}
+
+// organization returns the state for the organization login.
+func (s *Server) organization(login string) (*organization, bool) {
+	org, ok := s.orgs[strings.ToLower(login)]
+	return org, ok
+}
+
+func (s *Server) getViewer(w http.ResponseWriter, r *http.Request) {
+	account, _ := s.account(ViewerLogin)
+	writeJSON(w, r, http.StatusOK, account)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	account, ok := s.account(r.PathValue("login"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, account)
+}
+
+func (s *Server) getOrganization(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.organization(r.PathValue("org")); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	account, _ := s.account(r.PathValue("org"))
+	writeJSON(w, r, http.StatusOK, &github.Organization{
+		Login:   account.Login,
+		ID:      account.ID,
+		NodeID:  account.NodeID,
+		Type:    account.Type,
+		HTMLURL: account.HTMLURL,
+	})
+}
+
+func (s *Server) listOrgMembers(w http.ResponseWriter, r *http.Request) {
+	memberships, ok := s.memberships[strings.ToLower(r.PathValue("org"))]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	members := make([]*github.User, 0, len(memberships))
+	for _, login := range slices.Sorted(maps.Keys(memberships)) {
+		members = append(members, memberships[login].User)
+	}
+
+	writeJSON(w, r, http.StatusOK, members)
+}
+
+func (s *Server) getOrgMembership(w http.ResponseWriter, r *http.Request) {
+	membership, ok := s.memberships[strings.ToLower(r.PathValue("org"))][strings.ToLower(r.PathValue("user"))]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, membership)
+}
+
+func (s *Server) setOrgMembership(w http.ResponseWriter, r *http.Request) {
+	memberships, ok := s.memberships[strings.ToLower(r.PathValue("org"))]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	if _, ok := s.account(r.PathValue("user")); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req struct {
+		Role string `json:"role"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	switch req.Role {
+	case "":
+		req.Role = "member"
+	case "member", "admin":
+	default:
+		writeValidationFailed(w, "Membership", "role", "invalid")
+		return
+	}
+
+	account, _ := s.account(r.PathValue("org"))
+	membership := s.newMembership(account.GetLogin(), r.PathValue("user"), req.Role)
+	memberships[strings.ToLower(r.PathValue("user"))] = membership
+
+	writeJSON(w, r, http.StatusOK, membership)
+}
+
+func (s *Server) removeOrgMembership(w http.ResponseWriter, r *http.Request) {
+	memberships, ok := s.memberships[strings.ToLower(r.PathValue("org"))]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	login := strings.ToLower(r.PathValue("user"))
+	if _, ok := memberships[login]; !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(memberships, login)
+
+	for _, t := range s.teams {
+		if strings.EqualFold(t.org, r.PathValue("org")) {
+			delete(t.members, login)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
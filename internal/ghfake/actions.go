@@ -0,0 +1,541 @@
+package ghfake
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// secret is the state of an Actions secret in the fake.
+type secret struct {
+	value                 string
+	visibility            string
+	selectedRepositoryIDs []int64
+	createdAt             *github.Timestamp
+	updatedAt             *github.Timestamp
+}
+
+// variable is the state of an Actions variable in the fake.
+type variable struct {
+	variable              *github.ActionsVariable
+	selectedRepositoryIDs []int64
+}
+
+// actionsRequest is the request body for creating or updating Actions secrets and variables.
+type actionsRequest struct {
+	Name                  string  `json:"name"`
+	Value                 *string `json:"value"`
+	EncryptedValue        string  `json:"encrypted_value"`
+	KeyID                 string  `json:"key_id"`
+	Visibility            string  `json:"visibility"`
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids"`
+}
+
+func (s *Server) registerActionsRoutes(mux *http.ServeMux) {
+	for _, prefix := range []string{"/repos/{owner}/{repo}/actions", "/orgs/{org}/actions"} {
+		mux.HandleFunc("GET "+prefix+"/secrets/public-key", s.getPublicKey)
+		mux.HandleFunc("GET "+prefix+"/secrets", s.listSecrets)
+		mux.HandleFunc("GET "+prefix+"/secrets/{name}", s.getSecret)
+		mux.HandleFunc("PUT "+prefix+"/secrets/{name}", s.putSecret)
+		mux.HandleFunc("DELETE "+prefix+"/secrets/{name}", s.deleteSecret)
+		mux.HandleFunc("GET "+prefix+"/variables", s.listVariables)
+		mux.HandleFunc("POST "+prefix+"/variables", s.createVariable)
+		mux.HandleFunc("GET "+prefix+"/variables/{name}", s.getVariable)
+		mux.HandleFunc("PATCH "+prefix+"/variables/{name}", s.updateVariable)
+		mux.HandleFunc("DELETE "+prefix+"/variables/{name}", s.deleteVariable)
+	}
+
+	mux.HandleFunc("GET /orgs/{org}/actions/secrets/{name}/repositories", s.listSecretRepositories)
+	mux.HandleFunc("PUT /orgs/{org}/actions/secrets/{name}/repositories", s.setSecretRepositories)
+	mux.HandleFunc("PUT /orgs/{org}/actions/secrets/{name}/repositories/{repository_id}", s.setSecretRepository(true))
+	mux.HandleFunc("DELETE /orgs/{org}/actions/secrets/{name}/repositories/{repository_id}", s.setSecretRepository(false))
+	mux.HandleFunc("GET /orgs/{org}/actions/variables/{name}/repositories", s.listVariableRepositories)
+	mux.HandleFunc("PUT /orgs/{org}/actions/variables/{name}/repositories", s.setVariableRepositories)
+	mux.HandleFunc("PUT /orgs/{org}/actions/variables/{name}/repositories/{repository_id}", s.setVariableRepository(true))
+	mux.HandleFunc("DELETE /orgs/{org}/actions/variables/{name}/repositories/{repository_id}", s.setVariableRepository(false))
+}
+
+// publicKeyID returns the ID of the public key used to encrypt secrets.
+func (s *Server) publicKeyID() string {
+	sum := sha256.Sum256(s.publicKey[:])
+	return hex.EncodeToString(sum[:8])
+}
+
+// actionsScope returns the secrets and variables of the repository or organization from the request path.
+func (s *Server) actionsScope(r *http.Request) (map[string]*secret, map[string]*variable, bool, bool) {
+	if org := r.PathValue("org"); org != "" {
+		state, ok := s.organization(org)
+		if !ok {
+			return nil, nil, false, false
+		}
+
+		return state.secrets, state.variables, true, true
+	}
+
+	repo, ok := s.repository(r)
+	if !ok {
+		return nil, nil, false, false
+	}
+
+	return repo.secrets, repo.variables, false, true
+}
+
+// validVisibility reports whether the visibility of an organization secret or variable is valid.
+func validVisibility(visibility string) bool {
+	return slices.Contains([]string{"all", "private", "selected"}, visibility)
+}
+
+// selectedRepositoriesURL returns the URL listing the selected repositories of an organization secret or variable.
+func (s *Server) selectedRepositoriesURL(r *http.Request, kind, name string) *string {
+	return new(s.URL + restAPIPrefix + "/orgs/" + r.PathValue("org") + "/actions/" + kind + "/" + name + "/repositories")
+}
+
+// selectedRepositories returns the repositories with the IDs.
+func (s *Server) selectedRepositories(ids []int64) *github.SelectedReposList {
+	repos := []*github.Repository{}
+	for _, id := range ids {
+		if repo, ok := s.repositoryByID(id); ok {
+			repos = append(repos, repo.repo)
+		}
+	}
+
+	return &github.SelectedReposList{TotalCount: new(len(repos)), Repositories: repos}
+}
+
+func (s *Server) getPublicKey(w http.ResponseWriter, r *http.Request) {
+	if _, _, _, ok := s.actionsScope(r); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, &github.PublicKey{
+		KeyID: new(s.publicKeyID()),
+		Key:   new(base64.StdEncoding.EncodeToString(s.publicKey[:])),
+	})
+}
+
+// secretResponse returns the API representation of the secret.
+func (s *Server) secretResponse(r *http.Request, name string, sec *secret, isOrg bool) *github.Secret {
+	resp := &github.Secret{Name: name, CreatedAt: *sec.createdAt, UpdatedAt: *sec.updatedAt}
+	if isOrg {
+		resp.Visibility = sec.visibility
+		if sec.visibility == "selected" {
+			resp.SelectedRepositoriesURL = *s.selectedRepositoriesURL(r, "secrets", name)
+		}
+	}
+
+	return resp
+}
+
+func (s *Server) listSecrets(w http.ResponseWriter, r *http.Request) {
+	secrets, _, isOrg, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	resp := &github.Secrets{Secrets: []*github.Secret{}}
+	for _, name := range slices.Sorted(maps.Keys(secrets)) {
+		resp.Secrets = append(resp.Secrets, s.secretResponse(r, name, secrets[name], isOrg))
+	}
+	resp.TotalCount = len(resp.Secrets)
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+func (s *Server) getSecret(w http.ResponseWriter, r *http.Request) {
+	secrets, _, isOrg, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	name := strings.ToUpper(r.PathValue("name"))
+	sec, ok := secrets[name]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, s.secretResponse(r, name, sec, isOrg))
+}
+
+func (s *Server) putSecret(w http.ResponseWriter, r *http.Request) {
+	secrets, _, isOrg, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req actionsRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.KeyID != s.publicKeyID() {
+		writeError(w, http.StatusUnprocessableEntity, "Bad request: key_id does not match the current public key")
+		return
+	}
+
+	encrypted, err := base64.StdEncoding.DecodeString(req.EncryptedValue)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "Bad request: encrypted_value is not valid base64")
+		return
+	}
+
+	value, ok := box.OpenAnonymous(nil, encrypted, s.publicKey, s.privateKey)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Bad request: encrypted_value could not be decrypted")
+		return
+	}
+
+	if isOrg && !validVisibility(req.Visibility) {
+		writeValidationFailed(w, "Secret", "visibility", "invalid")
+		return
+	}
+
+	name := strings.ToUpper(r.PathValue("name"))
+	now := s.now()
+
+	sec, exists := secrets[name]
+	if !exists {
+		sec = &secret{createdAt: now}
+		secrets[name] = sec
+	}
+	sec.value = string(value)
+	sec.updatedAt = now
+	if isOrg {
+		sec.visibility = req.Visibility
+		sec.selectedRepositoryIDs = req.SelectedRepositoryIDs
+	}
+
+	if exists {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) deleteSecret(w http.ResponseWriter, r *http.Request) {
+	secrets, _, _, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	name := strings.ToUpper(r.PathValue("name"))
+	if _, ok := secrets[name]; !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(secrets, name)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listSecretRepositories(w http.ResponseWriter, r *http.Request) {
+	secrets, _, _, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	sec, ok := secrets[strings.ToUpper(r.PathValue("name"))]
+	if !ok || sec.visibility != "selected" {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, s.selectedRepositories(sec.selectedRepositoryIDs))
+}
+
+func (s *Server) setSecretRepositories(w http.ResponseWriter, r *http.Request) {
+	secrets, _, _, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	sec, ok := secrets[strings.ToUpper(r.PathValue("name"))]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	if sec.visibility != "selected" {
+		writeError(w, http.StatusConflict, "Secret visibility is not selected")
+		return
+	}
+
+	var req actionsRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	sec.selectedRepositoryIDs = req.SelectedRepositoryIDs
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// variableResponse returns the API representation of the variable.
+func (s *Server) variableResponse(r *http.Request, v *variable, isOrg bool) *github.ActionsVariable {
+	resp := *v.variable
+	if isOrg && resp.GetVisibility() == "selected" {
+		resp.SelectedRepositoriesURL = s.selectedRepositoriesURL(r, "variables", resp.Name)
+	}
+
+	return &resp
+}
+
+func (s *Server) listVariables(w http.ResponseWriter, r *http.Request) {
+	_, variables, isOrg, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	resp := &github.ActionsVariables{Variables: []*github.ActionsVariable{}}
+	for _, name := range slices.Sorted(maps.Keys(variables)) {
+		resp.Variables = append(resp.Variables, s.variableResponse(r, variables[name], isOrg))
+	}
+	resp.TotalCount = len(resp.Variables)
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+func (s *Server) createVariable(w http.ResponseWriter, r *http.Request) {
+	_, variables, isOrg, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req actionsRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" || req.Value == nil {
+		writeValidationFailed(w, "Variable", "name", "missing_field")
+		return
+	}
+
+	if isOrg && !validVisibility(req.Visibility) {
+		writeValidationFailed(w, "Variable", "visibility", "invalid")
+		return
+	}
+
+	name := strings.ToUpper(req.Name)
+	if _, ok := variables[name]; ok {
+		writeError(w, http.StatusConflict, "Already exists - Variable already exists")
+		return
+	}
+
+	now := s.now()
+	v := &variable{variable: &github.ActionsVariable{Name: name, Value: *req.Value, CreatedAt: now, UpdatedAt: now}}
+	if isOrg {
+		v.variable.Visibility = new(req.Visibility)
+		v.selectedRepositoryIDs = req.SelectedRepositoryIDs
+	}
+	variables[name] = v
+
+	writeJSON(w, r, http.StatusCreated, map[string]any{})
+}
+
+func (s *Server) getVariable(w http.ResponseWriter, r *http.Request) {
+	_, variables, isOrg, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	v, ok := variables[strings.ToUpper(r.PathValue("name"))]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, s.variableResponse(r, v, isOrg))
+}
+
+func (s *Server) updateVariable(w http.ResponseWriter, r *http.Request) {
+	_, variables, isOrg, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	name := strings.ToUpper(r.PathValue("name"))
+	v, ok := variables[name]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req actionsRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if isOrg && req.Visibility != "" && !validVisibility(req.Visibility) {
+		writeValidationFailed(w, "Variable", "visibility", "invalid")
+		return
+	}
+
+	if newName := strings.ToUpper(req.Name); newName != "" && newName != name {
+		if _, ok := variables[newName]; ok {
+			writeError(w, http.StatusConflict, "Already exists - Variable already exists")
+			return
+		}
+		delete(variables, name)
+		variables[newName] = v
+		v.variable.Name = newName
+	}
+
+	if req.Value != nil {
+		v.variable.Value = *req.Value
+	}
+	if isOrg && req.Visibility != "" {
+		v.variable.Visibility = new(req.Visibility)
+	}
+	if isOrg && req.SelectedRepositoryIDs != nil {
+		v.selectedRepositoryIDs = req.SelectedRepositoryIDs
+	}
+	v.variable.UpdatedAt = s.now()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteVariable(w http.ResponseWriter, r *http.Request) {
+	_, variables, _, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	name := strings.ToUpper(r.PathValue("name"))
+	if _, ok := variables[name]; !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(variables, name)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listVariableRepositories(w http.ResponseWriter, r *http.Request) {
+	_, variables, _, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	v, ok := variables[strings.ToUpper(r.PathValue("name"))]
+	if !ok || v.variable.GetVisibility() != "selected" {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, s.selectedRepositories(v.selectedRepositoryIDs))
+}
+
+func (s *Server) setVariableRepositories(w http.ResponseWriter, r *http.Request) {
+	_, variables, _, ok := s.actionsScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	v, ok := variables[strings.ToUpper(r.PathValue("name"))]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	if v.variable.GetVisibility() != "selected" {
+		writeError(w, http.StatusConflict, "Variable visibility is not selected")
+		return
+	}
+
+	var req actionsRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	v.selectedRepositoryIDs = req.SelectedRepositoryIDs
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setSelectedRepository adds or removes the repository from the path to the selected repositories.
+func (s *Server) setSelectedRepository(w http.ResponseWriter, r *http.Request, ids *[]int64, selected bool) {
+	id, err := strconv.ParseInt(r.PathValue("repository_id"), 10, 64)
+	if err != nil {
+		writeNotFound(w)
+		return
+	}
+
+	if _, ok := s.repositoryByID(id); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	*ids = slices.DeleteFunc(*ids, func(v int64) bool { return v == id })
+	if selected {
+		*ids = append(*ids, id)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) setSecretRepository(selected bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		secrets, _, _, ok := s.actionsScope(r)
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+
+		sec, ok := secrets[strings.ToUpper(r.PathValue("name"))]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+
+		if sec.visibility != "selected" {
+			writeError(w, http.StatusConflict, "Secret visibility is not selected")
+			return
+		}
+
+		s.setSelectedRepository(w, r, &sec.selectedRepositoryIDs, selected)
+	}
+}
+
+func (s *Server) setVariableRepository(selected bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, variables, _, ok := s.actionsScope(r)
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+
+		v, ok := variables[strings.ToUpper(r.PathValue("name"))]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+
+		if v.variable.GetVisibility() != "selected" {
+			writeError(w, http.StatusConflict, "Variable visibility is not selected")
+			return
+		}
+
+		s.setSelectedRepository(w, r, &v.selectedRepositoryIDs, selected)
+	}
+}
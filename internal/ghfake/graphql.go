@@ -0,0 +1,117 @@
+package ghfake
+
+import (
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// graphQLOperation is a GraphQL query supported by the fake; queries are matched on their selection rather than parsed, as the provider only sends queries generated by githubv4 from fixed structs.
+type graphQLOperation struct {
+	match   *regexp.Regexp
+	resolve func(s *Server, variables map[string]any) (any, string)
+}
+
+// graphQLOperations are the GraphQL queries supported by the fake.
+var graphQLOperations = []graphQLOperation{
+	{
+		match:   regexp.MustCompile(`organization\(login: ?\$login\)\{team\(slug: ?\$slug\)\{members\(`),
+		resolve: (*Server).resolveTeamMembers,
+	},
+	{
+		match:   regexp.MustCompile(`^(query)?\{viewer\{`),
+		resolve: (*Server).resolveViewer,
+	},
+}
+
+func (s *Server) graphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	query := req.Query
+	if i := strings.Index(query, "{"); i >= 0 && strings.HasPrefix(query, "query(") {
+		query = query[i:]
+	}
+
+	for _, op := range graphQLOperations {
+		if !op.match.MatchString(query) {
+			continue
+		}
+
+		data, errMessage := op.resolve(s, req.Variables)
+		if errMessage != "" {
+			writeJSON(w, r, http.StatusOK, map[string]any{
+				"data":   data,
+				"errors": []map[string]string{{"type": "NOT_FOUND", "message": errMessage}},
+			})
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, map[string]any{"data": data})
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, map[string]any{
+		"errors": []map[string]string{{"message": "ghfake: unsupported query: " + req.Query}},
+	})
+}
+
+// stringVariable returns a string GraphQL variable.
+func stringVariable(variables map[string]any, name string) string {
+	v, _ := variables[name].(string)
+	return v
+}
+
+func (s *Server) resolveViewer(_ map[string]any) (any, string) {
+	viewer, _ := s.account(ViewerLogin)
+
+	return map[string]any{
+		"viewer": map[string]any{"login": viewer.GetLogin(), "id": viewer.GetNodeID()},
+	}, ""
+}
+
+func (s *Server) resolveTeamMembers(variables map[string]any) (any, string) {
+	login := stringVariable(variables, "login")
+	if _, ok := s.organization(login); !ok {
+		return map[string]any{"organization": nil}, "Could not resolve to an Organization with the login of '" + login + "'."
+	}
+
+	t, ok := s.teamBySlug(login, stringVariable(variables, "slug"))
+	if !ok {
+		return map[string]any{"organization": map[string]any{"team": nil}}, ""
+	}
+
+	edges := []map[string]any{}
+	for _, member := range slices.Sorted(maps.Keys(t.members)) {
+		account, ok := s.account(member)
+		if !ok {
+			continue
+		}
+
+		edges = append(edges, map[string]any{
+			"node": map[string]any{"login": account.GetLogin()},
+			"role": strings.ToUpper(t.members[member]),
+		})
+	}
+
+	return map[string]any{
+		"organization": map[string]any{
+			"team": map[string]any{
+				"members": map[string]any{
+					"edges":    edges,
+					"pageInfo": map[string]any{"endCursor": "", "hasNextPage": false},
+				},
+			},
+		},
+	}, ""
+}
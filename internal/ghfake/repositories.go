@@ -0,0 +1,664 @@
+package ghfake
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// defaultBranch is the default branch of repositories created by the fake.
+const defaultBranch = "main"
+
+// repository is the state of a repository in the fake.
+type repository struct {
+	repo                *github.Repository
+	refs                map[string]string
+	pages               *github.Pages
+	vulnerabilityAlerts bool
+	rulesets            map[int64]*github.RepositoryRuleset
+	secrets             map[string]*secret
+	variables           map[string]*variable
+	runners             []*github.Runner
+}
+
+// repoKey returns the key of a repository in the fake state.
+func repoKey(owner, name string) string {
+	return strings.ToLower(owner + "/" + name)
+}
+
+func (s *Server) registerRepositoryRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /user/repos", s.createRepository)
+	mux.HandleFunc("POST /orgs/{org}/repos", s.createRepository)
+	mux.HandleFunc("GET /orgs/{org}/repos", s.listRepositories)
+	mux.HandleFunc("GET /users/{login}/repos", s.listRepositories)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/generate", s.createRepositoryFromTemplate)
+	mux.HandleFunc("GET /repos/{owner}/{repo}", s.getRepository)
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}", s.editRepository)
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}", s.deleteRepository)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/topics", s.getTopics)
+	mux.HandleFunc("PUT /repos/{owner}/{repo}/topics", s.replaceTopics)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/pages", s.getPages)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/pages", s.enablePages)
+	mux.HandleFunc("PUT /repos/{owner}/{repo}/pages", s.updatePages)
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/pages", s.disablePages)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/vulnerability-alerts", s.getVulnerabilityAlerts)
+	mux.HandleFunc("PUT /repos/{owner}/{repo}/vulnerability-alerts", s.setVulnerabilityAlerts(true))
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/vulnerability-alerts", s.setVulnerabilityAlerts(false))
+	mux.HandleFunc("GET /repos/{owner}/{repo}/branches", s.listBranches)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/branches/{branch}", s.getBranch)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/branches/{branch}/rename", s.renameBranch)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/git/ref/{ref...}", s.getRef)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/git/refs", s.createRef)
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}/git/refs/{ref...}", s.updateRef)
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/git/refs/{ref...}", s.deleteRef)
+}
+
+// repository returns the state of the repository from the request path.
+func (s *Server) repository(r *http.Request) (*repository, bool) {
+	repo, ok := s.repos[repoKey(r.PathValue("owner"), r.PathValue("repo"))]
+	return repo, ok
+}
+
+// newRepository adds a new repository for the owner from the create request, initializing the default branch if requested; the lock must be held.
+func (s *Server) newRepository(owner *github.User, req *github.Repository) *repository {
+	id := s.nextID()
+	now := s.now()
+
+	repo := *req
+	repo.ID = new(id)
+	repo.NodeID = new(nodeID("Repository", id))
+	repo.Owner = owner
+	repo.Organization = nil
+	repo.AutoInit = nil
+	repo.GitignoreTemplate = nil
+	repo.LicenseTemplate = nil
+	repo.TeamID = nil
+	repo.DefaultBranch = new(defaultBranch)
+	repo.CreatedAt = now
+	repo.UpdatedAt = now
+	repo.PushedAt = now
+	repo.Permissions = repositoryPermissions("admin")
+	if repo.Topics == nil {
+		repo.Topics = []string{}
+	}
+
+	for _, v := range []**bool{&repo.HasIssues, &repo.HasProjects, &repo.HasWiki, &repo.HasDownloads, &repo.AllowMergeCommit, &repo.AllowSquashMerge, &repo.AllowRebaseMerge, &repo.AllowForking} {
+		if *v == nil {
+			*v = new(true)
+		}
+	}
+	for _, v := range []**bool{&repo.HasDiscussions, &repo.IsTemplate, &repo.Archived, &repo.Disabled, &repo.Fork, &repo.AllowAutoMerge, &repo.AllowUpdateBranch, &repo.DeleteBranchOnMerge, &repo.WebCommitSignoffRequired} {
+		if *v == nil {
+			*v = new(false)
+		}
+	}
+	for v, def := range map[**string]string{&repo.SquashMergeCommitTitle: "COMMIT_OR_PR_TITLE", &repo.SquashMergeCommitMessage: "COMMIT_MESSAGES", &repo.MergeCommitTitle: "MERGE_MESSAGE", &repo.MergeCommitMessage: "PR_TITLE"} {
+		if *v == nil {
+			*v = new(def)
+		}
+	}
+
+	s.setRepositoryURLs(&repo)
+	setVisibility(&repo, nil)
+
+	state := &repository{
+		repo:      &repo,
+		refs:      map[string]string{},
+		rulesets:  map[int64]*github.RepositoryRuleset{},
+		secrets:   map[string]*secret{},
+		variables: map[string]*variable{},
+	}
+	if req.GetAutoInit() {
+		state.refs["refs/heads/"+defaultBranch] = commitSHA(repo.GetNodeID())
+	}
+	s.repos[repoKey(owner.GetLogin(), repo.GetName())] = state
+
+	return state
+}
+
+// repositoryPermissions returns the repository permissions granted by the permission, as each permission includes all lesser ones.
+func repositoryPermissions(permission string) *github.RepositoryPermissions {
+	granted := slices.Index(teamPermissions, permission)
+
+	return &github.RepositoryPermissions{
+		Pull:     new(granted >= 0),
+		Triage:   new(granted >= 1),
+		Push:     new(granted >= 2),
+		Maintain: new(granted >= 3),
+		Admin:    new(granted >= 4),
+	}
+}
+
+// setRepositoryURLs sets the name dependant fields of the repository; the lock must be held.
+func (s *Server) setRepositoryURLs(repo *github.Repository) {
+	fullName := repo.GetOwner().GetLogin() + "/" + repo.GetName()
+	repo.FullName = new(fullName)
+	repo.HTMLURL = new(s.URL + "/" + fullName)
+	repo.URL = new(s.URL + restAPIPrefix + "/repos/" + fullName)
+	repo.CloneURL = new(s.URL + "/" + fullName + ".git")
+	repo.GitURL = new("git://" + strings.TrimPrefix(strings.TrimPrefix(s.URL, "http://"), "https://") + "/" + fullName + ".git")
+	repo.SSHURL = new("git@" + strings.TrimPrefix(strings.TrimPrefix(s.URL, "http://"), "https://") + ":" + fullName + ".git")
+	repo.SVNURL = new(s.URL + "/" + fullName)
+}
+
+// setVisibility keeps the visibility and private fields of the repository consistent, giving precedence to the fields set in the patch.
+func setVisibility(repo *github.Repository, patch map[string]any) {
+	_, visibilitySet := patch["visibility"]
+	if _, privateSet := patch["private"]; privateSet && !visibilitySet {
+		repo.Visibility = nil
+	}
+
+	switch repo.GetVisibility() {
+	case "private", "internal":
+		repo.Private = new(true)
+	case "public":
+		repo.Private = new(false)
+	default:
+		if repo.GetPrivate() {
+			repo.Visibility = new("private")
+		} else {
+			repo.Visibility = new("public")
+		}
+		repo.Private = new(repo.GetPrivate())
+	}
+}
+
+func (s *Server) createRepository(w http.ResponseWriter, r *http.Request) {
+	login := ViewerLogin
+	if org := r.PathValue("org"); org != "" {
+		if _, ok := s.organization(org); !ok {
+			writeNotFound(w)
+			return
+		}
+		login = org
+	}
+	owner, _ := s.account(login)
+
+	var req github.Repository
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.GetName() == "" {
+		writeValidationFailed(w, "Repository", "name", "missing_field")
+		return
+	}
+
+	if _, ok := s.repos[repoKey(login, req.GetName())]; ok {
+		writeValidationFailed(w, "Repository", "name", "already_exists")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, s.newRepository(owner, &req).repo)
+}
+
+func (s *Server) createRepositoryFromTemplate(w http.ResponseWriter, r *http.Request) {
+	template, ok := s.repository(r)
+	if !ok || !template.repo.GetIsTemplate() {
+		writeNotFound(w)
+		return
+	}
+
+	var req github.TemplateRepoRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	login := req.GetOwner()
+	if login == "" {
+		login = ViewerLogin
+	}
+	owner, ok := s.account(login)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	if _, ok := s.repos[repoKey(login, req.GetName())]; ok {
+		writeValidationFailed(w, "Repository", "name", "already_exists")
+		return
+	}
+
+	state := s.newRepository(owner, &github.Repository{
+		Name:        req.Name,
+		Description: req.Description,
+		Private:     req.Private,
+		TemplateRepository: &github.Repository{
+			ID:       template.repo.ID,
+			Name:     template.repo.Name,
+			FullName: template.repo.FullName,
+			Owner:    template.repo.Owner,
+		},
+	})
+	for ref, sha := range template.refs {
+		if ref == "refs/heads/"+template.repo.GetDefaultBranch() || req.GetIncludeAllBranches() {
+			state.refs[ref] = sha
+		}
+	}
+	state.repo.DefaultBranch = new(template.repo.GetDefaultBranch())
+
+	writeJSON(w, r, http.StatusCreated, state.repo)
+}
+
+func (s *Server) listRepositories(w http.ResponseWriter, r *http.Request) {
+	login := r.PathValue("org") + r.PathValue("login")
+	if _, ok := s.account(login); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	repos := []*github.Repository{}
+	for _, key := range slices.Sorted(maps.Keys(s.repos)) {
+		if repo := s.repos[key].repo; strings.EqualFold(repo.GetOwner().GetLogin(), login) {
+			repos = append(repos, repo)
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, repos)
+}
+
+func (s *Server) getRepository(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, repo.repo)
+}
+
+func (s *Server) editRepository(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	patch := map[string]any{}
+	if !decode(w, r, &patch) {
+		return
+	}
+
+	if repo.repo.GetArchived() {
+		if archived, ok := patch["archived"].(bool); !ok || archived {
+			writeError(w, http.StatusForbidden, "Repository was archived so is read-only.")
+			return
+		}
+	}
+
+	updated := *repo.repo
+	if err := merge(&updated, patch); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return
+	}
+
+	if _, ok := patch["default_branch"]; ok && len(repo.refs) > 0 {
+		if _, ok := repo.refs["refs/heads/"+updated.GetDefaultBranch()]; !ok {
+			writeValidationFailed(w, "Repository", "default_branch", "invalid")
+			return
+		}
+	}
+
+	if !strings.EqualFold(updated.GetName(), repo.repo.GetName()) {
+		newKey := repoKey(updated.GetOwner().GetLogin(), updated.GetName())
+		if _, ok := s.repos[newKey]; ok {
+			writeValidationFailed(w, "Repository", "name", "already_exists")
+			return
+		}
+		delete(s.repos, repoKey(r.PathValue("owner"), r.PathValue("repo")))
+		s.repos[newKey] = repo
+	}
+
+	s.setRepositoryURLs(&updated)
+	setVisibility(&updated, patch)
+	updated.UpdatedAt = s.now()
+	repo.repo = &updated
+
+	writeJSON(w, r, http.StatusOK, repo.repo)
+}
+
+func (s *Server) deleteRepository(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	delete(s.repos, repoKey(r.PathValue("owner"), r.PathValue("repo")))
+	for _, t := range s.teams {
+		delete(t.repos, repo.repo.GetID())
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getTopics(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, map[string][]string{"names": repo.repo.Topics})
+}
+
+func (s *Server) replaceTopics(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req struct {
+		Names []string `json:"names"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	topics := []string{}
+	for _, topic := range req.Names {
+		if topic = strings.ToLower(topic); !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+	repo.repo.Topics = topics
+	repo.repo.UpdatedAt = s.now()
+
+	writeJSON(w, r, http.StatusOK, map[string][]string{"names": topics})
+}
+
+func (s *Server) getPages(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok || repo.pages == nil {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, repo.pages)
+}
+
+func (s *Server) enablePages(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	if repo.pages != nil {
+		writeError(w, http.StatusConflict, "GitHub Pages is already enabled.")
+		return
+	}
+
+	var req github.Pages
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.BuildType == nil {
+		req.BuildType = new("legacy")
+	}
+	if req.GetBuildType() == "legacy" && req.Source == nil {
+		req.Source = &github.PagesSource{Branch: new(repo.repo.GetDefaultBranch()), Path: new("/")}
+	}
+	req.Status = new("built")
+	req.URL = new(repo.repo.GetURL() + "/pages")
+	req.HTMLURL = new(s.URL + "/pages/" + repo.repo.GetFullName() + "/")
+	req.Custom404 = new(false)
+	req.Public = new(!repo.repo.GetPrivate())
+	repo.pages = &req
+	repo.repo.HasPages = new(true)
+
+	writeJSON(w, r, http.StatusCreated, repo.pages)
+}
+
+func (s *Server) updatePages(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok || repo.pages == nil {
+		writeNotFound(w)
+		return
+	}
+
+	patch := map[string]any{}
+	if !decode(w, r, &patch) {
+		return
+	}
+
+	if err := merge(repo.pages, patch); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) disablePages(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok || repo.pages == nil {
+		writeNotFound(w)
+		return
+	}
+
+	repo.pages = nil
+	repo.repo.HasPages = new(false)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getVulnerabilityAlerts(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok || !repo.vulnerabilityAlerts {
+		writeNotFound(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) setVulnerabilityAlerts(enabled bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		repo, ok := s.repository(r)
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+
+		repo.vulnerabilityAlerts = enabled
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// branch returns the branch response for the branch.
+func (repo *repository) branch(name string) (*github.Branch, bool) {
+	sha, ok := repo.refs["refs/heads/"+name]
+	if !ok {
+		return nil, false
+	}
+
+	return &github.Branch{
+		Name:      new(name),
+		Commit:    &github.RepositoryCommit{SHA: new(sha)},
+		Protected: new(false),
+	}, true
+}
+
+func (s *Server) listBranches(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	branches := []*github.Branch{}
+	for _, ref := range slices.Sorted(maps.Keys(repo.refs)) {
+		if name, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+			branch, _ := repo.branch(name)
+			branches = append(branches, branch)
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, branches)
+}
+
+func (s *Server) getBranch(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	branch, ok := repo.branch(r.PathValue("branch"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Branch not found")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, branch)
+}
+
+func (s *Server) renameBranch(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	oldName := r.PathValue("branch")
+	sha, ok := repo.refs["refs/heads/"+oldName]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Branch not found")
+		return
+	}
+
+	var req struct {
+		NewName string `json:"new_name"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if _, ok := repo.refs["refs/heads/"+req.NewName]; ok || req.NewName == "" {
+		writeValidationFailed(w, "Branch", "new_name", "invalid")
+		return
+	}
+
+	delete(repo.refs, "refs/heads/"+oldName)
+	repo.refs["refs/heads/"+req.NewName] = sha
+	if repo.repo.GetDefaultBranch() == oldName {
+		repo.repo.DefaultBranch = new(req.NewName)
+	}
+
+	branch, _ := repo.branch(req.NewName)
+	writeJSON(w, r, http.StatusCreated, branch)
+}
+
+// reference returns the git reference response for the ref.
+func (repo *repository) reference(ref string) *github.Reference {
+	return &github.Reference{
+		Ref:    new(ref),
+		URL:    new(repo.repo.GetURL() + "/git/" + ref),
+		NodeID: new(nodeID("Ref", repo.repo.GetNodeID()+":"+ref)),
+		Object: &github.GitObject{
+			Type: new("commit"),
+			SHA:  new(repo.refs[ref]),
+		},
+	}
+}
+
+func (s *Server) getRef(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	ref := "refs/" + r.PathValue("ref")
+	if _, ok := repo.refs[ref]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, repo.reference(ref))
+}
+
+func (s *Server) createRef(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if !strings.HasPrefix(req.Ref, "refs/") || strings.Count(req.Ref, "/") < 2 {
+		writeError(w, http.StatusUnprocessableEntity, "Reference name is not valid")
+		return
+	}
+
+	if _, ok := repo.refs[req.Ref]; ok {
+		writeError(w, http.StatusUnprocessableEntity, "Reference already exists")
+		return
+	}
+
+	if !slices.Contains(slices.Collect(maps.Values(repo.refs)), req.SHA) {
+		writeError(w, http.StatusUnprocessableEntity, "Object does not exist")
+		return
+	}
+
+	repo.refs[req.Ref] = req.SHA
+
+	writeJSON(w, r, http.StatusCreated, repo.reference(req.Ref))
+}
+
+func (s *Server) updateRef(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	ref := "refs/" + r.PathValue("ref")
+	if _, ok := repo.refs[ref]; !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Reference does not exist")
+		return
+	}
+
+	var req struct {
+		SHA string `json:"sha"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	repo.refs[ref] = req.SHA
+
+	writeJSON(w, r, http.StatusOK, repo.reference(ref))
+}
+
+func (s *Server) deleteRef(w http.ResponseWriter, r *http.Request) {
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	ref := "refs/" + r.PathValue("ref")
+	if _, ok := repo.refs[ref]; !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Reference does not exist")
+		return
+	}
+
+	delete(repo.refs, ref)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,186 @@
+package ghfake
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func (s *Server) registerRulesetRoutes(mux *http.ServeMux) {
+	for _, prefix := range []string{"/repos/{owner}/{repo}/rulesets", "/orgs/{org}/rulesets"} {
+		mux.HandleFunc("GET "+prefix, s.listRulesets)
+		mux.HandleFunc("POST "+prefix, s.createRuleset)
+		mux.HandleFunc("GET "+prefix+"/{id}", s.getRuleset)
+		mux.HandleFunc("PUT "+prefix+"/{id}", s.updateRuleset)
+		mux.HandleFunc("DELETE "+prefix+"/{id}", s.deleteRuleset)
+	}
+}
+
+// rulesets returns the rulesets of the repository or organization from the request path, along with the ruleset source and source type.
+func (s *Server) rulesets(r *http.Request) (map[int64]*github.RepositoryRuleset, string, github.RulesetSourceType, bool) {
+	if org := r.PathValue("org"); org != "" {
+		state, ok := s.organization(org)
+		if !ok {
+			return nil, "", "", false
+		}
+
+		account, _ := s.account(org)
+		return state.rulesets, account.GetLogin(), github.RulesetSourceTypeOrganization, true
+	}
+
+	repo, ok := s.repository(r)
+	if !ok {
+		return nil, "", "", false
+	}
+
+	return repo.rulesets, repo.repo.GetFullName(), github.RulesetSourceTypeRepository, true
+}
+
+// ruleset returns the ruleset from the request path.
+func (s *Server) ruleset(r *http.Request) (map[int64]*github.RepositoryRuleset, *github.RepositoryRuleset, bool) {
+	rulesets, _, _, ok := s.rulesets(r)
+	if !ok {
+		return nil, nil, false
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	ruleset, ok := rulesets[id]
+	return rulesets, ruleset, ok
+}
+
+// rulesetNameTaken reports whether another ruleset already uses the name.
+func rulesetNameTaken(rulesets map[int64]*github.RepositoryRuleset, name string, id int64) bool {
+	for _, ruleset := range rulesets {
+		if ruleset.Name == name && ruleset.GetID() != id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) listRulesets(w http.ResponseWriter, r *http.Request) {
+	rulesets, _, _, ok := s.rulesets(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	resp := []*github.RepositoryRuleset{}
+	for _, id := range slices.Sorted(maps.Keys(rulesets)) {
+		ruleset := *rulesets[id]
+		ruleset.Rules = nil
+		ruleset.Conditions = nil
+		ruleset.BypassActors = nil
+		resp = append(resp, &ruleset)
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+func (s *Server) createRuleset(w http.ResponseWriter, r *http.Request) {
+	rulesets, source, sourceType, ok := s.rulesets(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var ruleset github.RepositoryRuleset
+	if !decode(w, r, &ruleset) {
+		return
+	}
+
+	if ruleset.Name == "" {
+		writeValidationFailed(w, "Ruleset", "name", "missing_field")
+		return
+	}
+
+	if rulesetNameTaken(rulesets, ruleset.Name, 0) {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: Name must be unique")
+		return
+	}
+
+	id := s.nextID()
+	now := s.now()
+	ruleset.ID = new(id)
+	ruleset.NodeID = new(nodeID("RepositoryRuleset", id))
+	ruleset.Source = source
+	ruleset.SourceType = new(sourceType)
+	ruleset.CurrentUserCanBypass = new(github.BypassModeNever)
+	ruleset.CreatedAt = now
+	ruleset.UpdatedAt = now
+	if ruleset.Target == nil {
+		ruleset.Target = new(github.RulesetTargetBranch)
+	}
+	if ruleset.Enforcement == "" {
+		ruleset.Enforcement = github.RulesetEnforcementDisabled
+	}
+	if ruleset.BypassActors == nil {
+		ruleset.BypassActors = []*github.BypassActor{}
+	}
+	rulesets[id] = &ruleset
+
+	writeJSON(w, r, http.StatusCreated, &ruleset)
+}
+
+func (s *Server) getRuleset(w http.ResponseWriter, r *http.Request) {
+	_, ruleset, ok := s.ruleset(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, ruleset)
+}
+
+func (s *Server) updateRuleset(w http.ResponseWriter, r *http.Request) {
+	rulesets, ruleset, ok := s.ruleset(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	patch := map[string]any{}
+	if !decode(w, r, &patch) {
+		return
+	}
+
+	for _, field := range []string{"id", "node_id", "source", "source_type", "created_at", "updated_at", "current_user_can_bypass", "_links"} {
+		delete(patch, field)
+	}
+
+	updated := *ruleset
+	if err := merge(&updated, patch); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return
+	}
+
+	if rulesetNameTaken(rulesets, updated.Name, updated.GetID()) {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: Name must be unique")
+		return
+	}
+
+	updated.UpdatedAt = s.now()
+	rulesets[updated.GetID()] = &updated
+
+	writeJSON(w, r, http.StatusOK, &updated)
+}
+
+func (s *Server) deleteRuleset(w http.ResponseWriter, r *http.Request) {
+	rulesets, ruleset, ok := s.ruleset(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	delete(rulesets, ruleset.GetID())
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,158 @@
+package ghfake
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// runnerTokenLifetime is the lifetime of runner registration and removal tokens.
+const runnerTokenLifetime = time.Hour
+
+func (s *Server) registerRunnerRoutes(mux *http.ServeMux) {
+	for _, prefix := range []string{"/repos/{owner}/{repo}/actions/runners", "/orgs/{org}/actions/runners"} {
+		mux.HandleFunc("POST "+prefix+"/registration-token", s.createRunnerToken)
+		mux.HandleFunc("POST "+prefix+"/remove-token", s.createRunnerToken)
+		mux.HandleFunc("POST "+prefix+"/generate-jitconfig", s.generateJITConfig)
+		mux.HandleFunc("GET "+prefix+"/{runner_id}", s.getRunner)
+		mux.HandleFunc("DELETE "+prefix+"/{runner_id}", s.removeRunner)
+	}
+}
+
+// Runners returns the self-hosted runners registered with a repository, or with an organization when repo is empty.
+func (s *Server) Runners(owner, repo string) []*github.Runner {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if repo == "" {
+		org, ok := s.orgs[strings.ToLower(owner)]
+		if !ok {
+			return nil
+		}
+		return org.runners
+	}
+
+	r, ok := s.repos[repoKey(owner, repo)]
+	if !ok {
+		return nil
+	}
+	return r.runners
+}
+
+// runnersScope returns the runners of the repository or organization from the request path.
+func (s *Server) runnersScope(r *http.Request) (*[]*github.Runner, bool) {
+	if org := r.PathValue("org"); org != "" {
+		state, ok := s.organization(org)
+		if !ok {
+			return nil, false
+		}
+		return &state.runners, true
+	}
+
+	repo, ok := s.repository(r)
+	if !ok {
+		return nil, false
+	}
+	return &repo.runners, true
+}
+
+func (s *Server) createRunnerToken(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.runnersScope(r); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, &github.RegistrationToken{
+		Token:     new(fmt.Sprintf("FAKE%d", s.nextID())),
+		ExpiresAt: &github.Timestamp{Time: s.now().Add(runnerTokenLifetime)},
+	})
+}
+
+func (s *Server) generateJITConfig(w http.ResponseWriter, r *http.Request) {
+	runners, ok := s.runnersScope(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req github.CreateJITConfigRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if len(req.Labels) == 0 {
+		writeValidationFailed(w, "Runner", "labels", "missing_field")
+		return
+	}
+	for _, runner := range *runners {
+		if runner.GetName() == req.Name {
+			writeError(w, http.StatusConflict, "Already exists - A runner with the name already exists.")
+			return
+		}
+	}
+
+	runner := &github.Runner{
+		ID:     new(s.nextID()),
+		Name:   new(req.Name),
+		OS:     new("unknown"),
+		Status: new("offline"),
+	}
+	for _, label := range req.Labels {
+		runner.Labels = append(runner.Labels, &github.RunnerLabels{Name: new(label), Type: new("custom")})
+	}
+	*runners = append(*runners, runner)
+
+	config, err := json.Marshal(map[string]any{"runner_id": runner.GetID(), "work_folder": req.GetWorkFolder()})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, &github.JITRunnerConfig{
+		Runner:           runner,
+		EncodedJITConfig: new(base64.StdEncoding.EncodeToString(config)),
+	})
+}
+
+// runner returns the runners of the scope from the request path and the index of the runner with the ID from the path, or -1 if there's no such runner.
+func (s *Server) runner(r *http.Request) (*[]*github.Runner, int) {
+	runners, ok := s.runnersScope(r)
+	if !ok {
+		return nil, -1
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("runner_id"), 10, 64)
+	if err != nil {
+		return nil, -1
+	}
+
+	return runners, slices.IndexFunc(*runners, func(runner *github.Runner) bool { return runner.GetID() == id })
+}
+
+func (s *Server) getRunner(w http.ResponseWriter, r *http.Request) {
+	runners, i := s.runner(r)
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, (*runners)[i])
+}
+
+func (s *Server) removeRunner(w http.ResponseWriter, r *http.Request) {
+	runners, i := s.runner(r)
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+
+	*runners = slices.Delete(*runners, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,332 @@
+// Package ghfake provides an in-process fake of the GitHub REST and GraphQL APIs for unit-testing resources without credentials.
+package ghfake
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+	"golang.org/x/crypto/nacl/box"
+)
+
+const (
+	// ViewerLogin is the login of the user the fake authenticates every request as.
+	ViewerLogin = "fake-user"
+
+	// restAPIPrefix is the REST API path prefix used for GitHub Enterprise Server; the provider treats every host other than github.com and ghe.com as GHES, so the fake serves the REST API both with and without it.
+	restAPIPrefix = "/api/v3"
+
+	// graphQLPath is the GraphQL API path used for GitHub Enterprise Server; the fake also serves it at /graphql.
+	graphQLPath = "/api/graphql"
+)
+
+// Server is a stateful in-process fake of the GitHub API. It implements the REST and GraphQL endpoints used by the core resources (repositories, branches, teams, memberships, rulesets, secrets and variables) closely enough that resources can run their full lifecycle against it, including imports and drift introduced with [Server.Client].
+//
+// Every request is authenticated as [ViewerLogin] regardless of the credentials sent, which is an admin of every organization added with [Server.AddOrganization].
+type Server struct {
+	// URL is the base URL of the fake, to be used as the provider base_url.
+	URL string
+
+	mu          sync.Mutex
+	lastID      int64
+	lastTime    time.Time
+	accounts    map[string]*github.User
+	memberships map[string]map[string]*github.Membership
+	repos       map[string]*repository
+	teams       map[int64]*team
+	orgs        map[string]*organization
+	publicKey   *[32]byte
+	privateKey  *[32]byte
+}
+
+// NewServer starts a new fake GitHub API which is closed when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate fake secrets key: %v", err)
+	}
+
+	s := &Server{
+		accounts:    map[string]*github.User{},
+		memberships: map[string]map[string]*github.Membership{},
+		repos:       map[string]*repository{},
+		teams:       map[int64]*team{},
+		orgs:        map[string]*organization{},
+		publicKey:   publicKey,
+		privateKey:  privateKey,
+	}
+
+	ts := httptest.NewServer(s.handler())
+	t.Cleanup(ts.Close)
+	s.URL = ts.URL
+
+	s.AddUser(ViewerLogin)
+
+	return s
+}
+
+// Client returns a go-github client for the fake, which tests can use to change the fake state outside of Terraform to simulate drift.
+func (s *Server) Client(t testing.TB) *github.Client {
+	t.Helper()
+
+	client, err := github.NewClient(github.WithURLs(new(s.URL+restAPIPrefix+"/"), nil))
+	if err != nil {
+		t.Fatalf("failed to create fake client: %v", err)
+	}
+
+	return client
+}
+
+// AddUser adds a user account to the fake.
+func (s *Server) AddUser(login string) *github.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addAccount(login, "User")
+}
+
+// AddOrganization adds an organization to the fake with [ViewerLogin] as an admin.
+func (s *Server) AddOrganization(login string) *github.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := s.addAccount(login, "Organization")
+	s.orgs[strings.ToLower(login)] = &organization{
+		secrets:   map[string]*secret{},
+		variables: map[string]*variable{},
+		rulesets:  map[int64]*github.RepositoryRuleset{},
+	}
+	s.memberships[strings.ToLower(login)] = map[string]*github.Membership{
+		strings.ToLower(ViewerLogin): s.newMembership(login, ViewerLogin, "admin"),
+	}
+
+	return org
+}
+
+// RotatePublicKey replaces the public key used to encrypt secrets, so that values encrypted with the previous key are rejected.
+func (s *Server) RotatePublicKey(t testing.TB) {
+	t.Helper()
+
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate fake secrets key: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.publicKey = publicKey
+	s.privateKey = privateKey
+}
+
+// SecretValue returns the decrypted value of a repository Actions secret, or of an organization Actions secret when repo is empty.
+func (s *Server) SecretValue(owner, repo, name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var secrets map[string]*secret
+	if repo == "" {
+		org, ok := s.orgs[strings.ToLower(owner)]
+		if !ok {
+			return "", false
+		}
+		secrets = org.secrets
+	} else {
+		r, ok := s.repos[repoKey(owner, repo)]
+		if !ok {
+			return "", false
+		}
+		secrets = r.secrets
+	}
+
+	sec, ok := secrets[strings.ToUpper(name)]
+	if !ok {
+		return "", false
+	}
+
+	return sec.value, true
+}
+
+// addAccount adds a user or organization account; the lock must be held.
+func (s *Server) addAccount(login, accountType string) *github.User {
+	id := s.nextID()
+	account := &github.User{
+		Login:   new(login),
+		ID:      new(id),
+		NodeID:  new(nodeID(accountType, id)),
+		Type:    new(accountType),
+		HTMLURL: new(s.URL + "/" + login),
+	}
+	s.accounts[strings.ToLower(login)] = account
+
+	return account
+}
+
+// newMembership returns an active organization membership; the lock must be held.
+func (s *Server) newMembership(org, login, role string) *github.Membership {
+	return &github.Membership{
+		State:        new("active"),
+		Role:         new(role),
+		User:         s.accounts[strings.ToLower(login)],
+		Organization: &github.Organization{Login: new(org), ID: s.accounts[strings.ToLower(org)].ID},
+	}
+}
+
+// nextID returns a new unique ID; the lock must be held.
+func (s *Server) nextID() int64 {
+	s.lastID++
+	return s.lastID
+}
+
+// now returns the current time truncated to the second, guaranteed to be later than the previous result so that updates always change timestamps; the lock must be held.
+func (s *Server) now() *github.Timestamp {
+	now := time.Now().UTC().Truncate(time.Second)
+	if !now.After(s.lastTime) {
+		now = s.lastTime.Add(time.Second)
+	}
+	s.lastTime = now
+
+	return &github.Timestamp{Time: now}
+}
+
+// handler returns the HTTP handler for the fake, serving the REST API both at the root and under the GHES prefix.
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	s.registerAccountRoutes(mux)
+	s.registerRepositoryRoutes(mux)
+	s.registerTeamRoutes(mux)
+	s.registerRulesetRoutes(mux)
+	s.registerActionsRoutes(mux)
+	s.registerRunnerRoutes(mux)
+	mux.HandleFunc("POST /graphql", s.graphQL)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == graphQLPath:
+			r.URL.Path = "/graphql"
+		case strings.HasPrefix(r.URL.Path, restAPIPrefix+"/"):
+			r.URL.Path = strings.TrimPrefix(r.URL.Path, restAPIPrefix)
+			r.URL.RawPath = strings.TrimPrefix(r.URL.RawPath, restAPIPrefix)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// writeJSON writes the value as a JSON response with an ETag, responding with 304 Not Modified if the request already has the current representation.
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	sum := sha256.Sum256(b)
+	etag := fmt.Sprintf(`W/"%s"`, hex.EncodeToString(sum[:]))
+	w.Header().Set("ETag", etag)
+
+	if status == http.StatusOK && r.Method == http.MethodGet && r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+// writeError writes a GitHub API error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
+
+// writeNotFound writes the GitHub API response for a missing resource.
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+// writeValidationFailed writes the GitHub API response for an invalid request.
+func writeValidationFailed(w http.ResponseWriter, resource, field, code string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"message":           "Validation Failed",
+		"documentation_url": "https://docs.github.com/rest",
+		"errors": []map[string]string{
+			{"resource": resource, "field": field, "code": code},
+		},
+	})
+}
+
+// decode decodes the JSON request body, writing a 400 response if it's invalid; an empty body leaves the value unchanged.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return false
+	}
+
+	return true
+}
+
+// merge applies a partial JSON update to the value, as the GitHub API does for PATCH requests; fields present in the patch are replaced rather than merged.
+func merge[T any](v *T, patch map[string]any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	current := map[string]any{}
+	if err := json.Unmarshal(b, &current); err != nil {
+		return err
+	}
+
+	for k, val := range patch {
+		current[k] = val
+	}
+
+	b, err = json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	var merged T
+	if err := json.Unmarshal(b, &merged); err != nil {
+		return err
+	}
+	*v = merged
+
+	return nil
+}
+
+// nodeID returns a GraphQL node ID for the type and key.
+func nodeID(typeName string, key any) string {
+	return base64.RawStdEncoding.EncodeToString(fmt.Appendf(nil, "%s:%v", typeName, key))
+}
+
+// commitSHA returns a deterministic fake commit SHA for the seed.
+func commitSHA(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:20])
+}
@@ -0,0 +1,528 @@
+package ghfake
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+	"github.com/shurcooL/githubv4"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// statusCode returns the HTTP status code of a go-github error.
+func statusCode(err error) int {
+	var ghErr *github.ErrorResponse
+	if errors.As(err, &ghErr) {
+		return ghErr.Response.StatusCode
+	}
+
+	return 0
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("serves_accounts", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewServer(t)
+		s.AddOrganization("test-org")
+		client := s.Client(t)
+
+		viewer, _, err := client.Users.Get(t.Context(), "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if viewer.GetLogin() != ViewerLogin {
+			t.Fatalf("expected viewer %q, got %q", ViewerLogin, viewer.GetLogin())
+		}
+
+		org, _, err := client.Users.Get(t.Context(), "TEST-ORG")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if org.GetType() != "Organization" {
+			t.Fatalf("expected organization account, got %q", org.GetType())
+		}
+
+		membership, _, err := client.Organizations.GetOrgMembership(t.Context(), ViewerLogin, "test-org")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if membership.GetRole() != "admin" {
+			t.Fatalf("expected viewer to be an admin, got %q", membership.GetRole())
+		}
+
+		if _, _, err := client.Organizations.Get(t.Context(), ViewerLogin); statusCode(err) != http.StatusNotFound {
+			t.Fatalf("expected 404 for a user as an organization, got %v", err)
+		}
+	})
+
+	t.Run("manages_repositories_and_branches", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewServer(t)
+		s.AddOrganization("test-org")
+		client := s.Client(t)
+		ctx := t.Context()
+
+		repo, _, err := client.Repositories.Create(ctx, "test-org", &github.Repository{Name: new("repo"), Private: new(true), AutoInit: new(true)})
+		if err != nil {
+			t.Fatalf("failed to create repository: %v", err)
+		}
+		if repo.GetVisibility() != "private" || repo.GetDefaultBranch() != "main" || !repo.GetHasIssues() {
+			t.Fatalf("expected repository defaults, got %s", repo)
+		}
+
+		if _, _, err := client.Repositories.Create(ctx, "test-org", &github.Repository{Name: new("REPO")}); statusCode(err) != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422 for a duplicate repository, got %v", err)
+		}
+
+		repo, _, err = client.Repositories.Edit(ctx, "test-org", "repo", &github.Repository{Name: new("renamed"), Visibility: new("public"), Description: new("changed")})
+		if err != nil {
+			t.Fatalf("failed to edit repository: %v", err)
+		}
+		if repo.GetFullName() != "test-org/renamed" || repo.GetPrivate() || repo.GetDescription() != "changed" {
+			t.Fatalf("expected repository to be updated, got %s", repo)
+		}
+
+		if _, _, err := client.Repositories.Get(ctx, "test-org", "repo"); statusCode(err) != http.StatusNotFound {
+			t.Fatalf("expected 404 for the old repository name, got %v", err)
+		}
+
+		main, _, err := client.Git.GetRef(ctx, "test-org", "renamed", "refs/heads/main")
+		if err != nil {
+			t.Fatalf("failed to get ref: %v", err)
+		}
+
+		if _, _, err := client.Git.CreateRef(ctx, "test-org", "renamed", github.CreateRef{Ref: "refs/heads/feature", SHA: main.GetObject().GetSHA()}); err != nil {
+			t.Fatalf("failed to create ref: %v", err)
+		}
+
+		if _, _, err := client.Git.CreateRef(ctx, "test-org", "renamed", github.CreateRef{Ref: "refs/heads/other", SHA: "0000000000000000000000000000000000000000"}); statusCode(err) != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422 for an unknown sha, got %v", err)
+		}
+
+		if _, _, err := client.Repositories.RenameBranch(ctx, "test-org", "renamed", "main", "trunk"); err != nil {
+			t.Fatalf("failed to rename branch: %v", err)
+		}
+
+		repo, _, err = client.Repositories.Get(ctx, "test-org", "renamed")
+		if err != nil {
+			t.Fatalf("failed to get repository: %v", err)
+		}
+		if repo.GetDefaultBranch() != "trunk" {
+			t.Fatalf("expected default branch to follow the rename, got %q", repo.GetDefaultBranch())
+		}
+
+		if _, err := client.Git.DeleteRef(ctx, "test-org", "renamed", "refs/heads/feature"); err != nil {
+			t.Fatalf("failed to delete ref: %v", err)
+		}
+
+		if _, resp, err := client.Repositories.GetBranch(ctx, "test-org", "renamed", "feature", 1); err == nil || resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("expected 404 for a deleted branch, got %v", err)
+		}
+
+		if _, _, err := client.Repositories.ReplaceAllTopics(ctx, "test-org", "renamed", []string{"Go", "go", "terraform"}); err != nil {
+			t.Fatalf("failed to replace topics: %v", err)
+		}
+
+		if _, err := client.Repositories.EnableVulnerabilityAlerts(ctx, "test-org", "renamed"); err != nil {
+			t.Fatalf("failed to enable vulnerability alerts: %v", err)
+		}
+
+		enabled, _, err := client.Repositories.GetVulnerabilityAlerts(ctx, "test-org", "renamed")
+		if err != nil || !enabled {
+			t.Fatalf("expected vulnerability alerts to be enabled, got %v", err)
+		}
+
+		repo, _, err = client.Repositories.Get(ctx, "test-org", "renamed")
+		if err != nil {
+			t.Fatalf("failed to get repository: %v", err)
+		}
+		if len(repo.Topics) != 2 {
+			t.Fatalf("expected topics to be deduplicated, got %v", repo.Topics)
+		}
+
+		if _, err := client.Repositories.Delete(ctx, "test-org", "renamed"); err != nil {
+			t.Fatalf("failed to delete repository: %v", err)
+		}
+
+		if _, _, err := client.Repositories.Get(ctx, "test-org", "renamed"); statusCode(err) != http.StatusNotFound {
+			t.Fatalf("expected 404 for a deleted repository, got %v", err)
+		}
+	})
+
+	t.Run("manages_teams", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewServer(t)
+		org := s.AddOrganization("test-org")
+		s.AddUser("member")
+		client := s.Client(t)
+		ctx := t.Context()
+
+		parent, _, err := client.Teams.CreateTeam(ctx, "test-org", github.NewTeam{Name: "Parent Team"})
+		if err != nil {
+			t.Fatalf("failed to create team: %v", err)
+		}
+		if parent.GetSlug() != "parent-team" || parent.GetMembersCount() != 1 {
+			t.Fatalf("expected team with slug and creator as member, got %s", parent)
+		}
+
+		child, _, err := client.Teams.CreateTeam(ctx, "test-org", github.NewTeam{Name: "child", ParentTeamID: parent.ID})
+		if err != nil {
+			t.Fatalf("failed to create team: %v", err)
+		}
+
+		child, _, err = client.Teams.GetTeamByID(ctx, org.GetID(), child.GetID())
+		if err != nil {
+			t.Fatalf("failed to get team: %v", err)
+		}
+		if child.GetParent().GetSlug() != "parent-team" {
+			t.Fatalf("expected parent team, got %v", child.GetParent())
+		}
+
+		child, _, err = client.Teams.EditTeamByID(ctx, org.GetID(), child.GetID(), github.NewTeam{Name: "Child Team"}, true)
+		if err != nil {
+			t.Fatalf("failed to edit team: %v", err)
+		}
+		if child.GetSlug() != "child-team" || child.Parent != nil {
+			t.Fatalf("expected team to be renamed without a parent, got %s", child)
+		}
+
+		if _, _, err := client.Teams.AddTeamMembershipBySlug(ctx, "test-org", "child-team", "member", &github.TeamAddTeamMembershipOptions{Role: "maintainer"}); err != nil {
+			t.Fatalf("failed to add team membership: %v", err)
+		}
+
+		membership, _, err := client.Teams.GetTeamMembershipByID(ctx, org.GetID(), child.GetID(), "member")
+		if err != nil || membership.GetRole() != "maintainer" {
+			t.Fatalf("expected maintainer membership, got %v", err)
+		}
+
+		var query struct {
+			Organization struct {
+				Team *struct {
+					Members struct {
+						Edges []struct {
+							Node struct {
+								Login string
+							}
+							Role string
+						}
+						PageInfo struct {
+							EndCursor   githubv4.String
+							HasNextPage bool
+						}
+					} `graphql:"members(membership: IMMEDIATE, first: 100, after: $after)"`
+				} `graphql:"team(slug: $slug)"`
+			} `graphql:"organization(login: $login)"`
+		}
+		v4client := githubv4.NewEnterpriseClient(s.URL+graphQLPath, nil)
+		if err := v4client.Query(ctx, &query, map[string]any{
+			"login": githubv4.String("test-org"),
+			"slug":  githubv4.String("child-team"),
+			"after": (*githubv4.String)(nil),
+		}); err != nil {
+			t.Fatalf("failed to query team members: %v", err)
+		}
+		if edges := query.Organization.Team.Members.Edges; len(edges) != 2 || edges[1].Node.Login != "member" || edges[1].Role != "MAINTAINER" {
+			t.Fatalf("expected team members from graphql, got %+v", edges)
+		}
+
+		repo, _, err := client.Repositories.Create(ctx, "test-org", &github.Repository{Name: new("repo")})
+		if err != nil {
+			t.Fatalf("failed to create repository: %v", err)
+		}
+
+		if _, err := client.Teams.AddTeamRepoByID(ctx, org.GetID(), child.GetID(), "test-org", "repo", &github.TeamAddTeamRepoOptions{Permission: "triage"}); err != nil {
+			t.Fatalf("failed to add team repository: %v", err)
+		}
+
+		teamRepo, _, err := client.Teams.IsTeamRepoByID(ctx, org.GetID(), child.GetID(), "test-org", "repo")
+		if err != nil {
+			t.Fatalf("failed to get team repository: %v", err)
+		}
+		if teamRepo.GetID() != repo.GetID() || !teamRepo.GetPermissions().GetTriage() || teamRepo.GetPermissions().GetPush() {
+			t.Fatalf("expected triage permission, got %v", teamRepo.GetPermissions())
+		}
+
+		if _, err := client.Teams.DeleteTeamByID(ctx, org.GetID(), parent.GetID()); err != nil {
+			t.Fatalf("failed to delete team: %v", err)
+		}
+
+		if _, _, err := client.Teams.GetTeamBySlug(ctx, "test-org", "child-team"); err != nil {
+			t.Fatalf("expected team without parent to remain, got %v", err)
+		}
+
+		if _, _, err := client.Teams.GetTeamBySlug(ctx, "test-org", "parent-team"); statusCode(err) != http.StatusNotFound {
+			t.Fatalf("expected 404 for a deleted team, got %v", err)
+		}
+	})
+
+	t.Run("manages_rulesets", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewServer(t)
+		s.AddOrganization("test-org")
+		client := s.Client(t)
+		ctx := t.Context()
+
+		if _, _, err := client.Repositories.Create(ctx, "test-org", &github.Repository{Name: new("repo")}); err != nil {
+			t.Fatalf("failed to create repository: %v", err)
+		}
+
+		ruleset, _, err := client.Repositories.CreateRuleset(ctx, "test-org", "repo", github.RepositoryRuleset{
+			Name:        "main",
+			Enforcement: github.RulesetEnforcementActive,
+			Rules:       &github.RepositoryRulesetRules{Deletion: &github.EmptyRuleParameters{}},
+		})
+		if err != nil {
+			t.Fatalf("failed to create ruleset: %v", err)
+		}
+		if ruleset.Source != "test-org/repo" || *ruleset.GetSourceType() != github.RulesetSourceTypeRepository {
+			t.Fatalf("expected repository ruleset source, got %q", ruleset.Source)
+		}
+
+		if _, _, err := client.Repositories.CreateRuleset(ctx, "test-org", "repo", github.RepositoryRuleset{Name: "main"}); statusCode(err) != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422 for a duplicate ruleset name, got %v", err)
+		}
+
+		ruleset, _, err = client.Repositories.UpdateRuleset(ctx, "test-org", "repo", ruleset.GetID(), github.RepositoryRuleset{
+			Name:        "main",
+			Enforcement: github.RulesetEnforcementEvaluate,
+			Rules:       &github.RepositoryRulesetRules{NonFastForward: &github.EmptyRuleParameters{}},
+		})
+		if err != nil {
+			t.Fatalf("failed to update ruleset: %v", err)
+		}
+
+		ruleset, _, err = client.Repositories.GetRuleset(ctx, "test-org", "repo", ruleset.GetID(), false)
+		if err != nil {
+			t.Fatalf("failed to get ruleset: %v", err)
+		}
+		if ruleset.Enforcement != github.RulesetEnforcementEvaluate || ruleset.Rules.NonFastForward == nil || ruleset.Rules.Deletion != nil {
+			t.Fatalf("expected ruleset to be replaced, got %+v", ruleset.Rules)
+		}
+
+		orgRuleset, _, err := client.Organizations.CreateRepositoryRuleset(ctx, "test-org", github.RepositoryRuleset{Name: "org", Enforcement: github.RulesetEnforcementActive})
+		if err != nil {
+			t.Fatalf("failed to create organization ruleset: %v", err)
+		}
+		if *orgRuleset.GetSourceType() != github.RulesetSourceTypeOrganization {
+			t.Fatalf("expected organization ruleset source type, got %q", *orgRuleset.GetSourceType())
+		}
+
+		if _, err := client.Organizations.DeleteRepositoryRuleset(ctx, "test-org", orgRuleset.GetID()); err != nil {
+			t.Fatalf("failed to delete organization ruleset: %v", err)
+		}
+
+		if _, _, err := client.Organizations.GetRepositoryRuleset(ctx, "test-org", orgRuleset.GetID()); statusCode(err) != http.StatusNotFound {
+			t.Fatalf("expected 404 for a deleted ruleset, got %v", err)
+		}
+	})
+
+	t.Run("manages_secrets_and_variables", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewServer(t)
+		s.AddOrganization("test-org")
+		client := s.Client(t)
+		ctx := t.Context()
+
+		repo, _, err := client.Repositories.Create(ctx, "test-org", &github.Repository{Name: new("repo")})
+		if err != nil {
+			t.Fatalf("failed to create repository: %v", err)
+		}
+
+		key, _, err := client.Actions.GetRepoPublicKey(ctx, "test-org", "repo")
+		if err != nil {
+			t.Fatalf("failed to get public key: %v", err)
+		}
+
+		keyBytes, err := base64.StdEncoding.DecodeString(key.GetKey())
+		if err != nil || len(keyBytes) != 32 {
+			t.Fatalf("expected a 32 byte public key, got %v", err)
+		}
+
+		encrypt := func(value string) string {
+			sealed, err := box.SealAnonymous(nil, []byte(value), (*[32]byte)(keyBytes), rand.Reader)
+			if err != nil {
+				t.Fatalf("failed to encrypt secret: %v", err)
+			}
+			return base64.StdEncoding.EncodeToString(sealed)
+		}
+
+		resp, err := client.Actions.CreateOrUpdateRepoSecret(ctx, "test-org", "repo", "token", github.SecretRequest{KeyID: key.GetKeyID(), EncryptedValue: encrypt("first")})
+		if err != nil || resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected secret to be created, got %v", err)
+		}
+
+		created, _, err := client.Actions.GetRepoSecret(ctx, "test-org", "repo", "TOKEN")
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+
+		resp, err = client.Actions.CreateOrUpdateRepoSecret(ctx, "test-org", "repo", "TOKEN", github.SecretRequest{KeyID: key.GetKeyID(), EncryptedValue: encrypt("second")})
+		if err != nil || resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("expected secret to be updated, got %v", err)
+		}
+
+		updated, _, err := client.Actions.GetRepoSecret(ctx, "test-org", "repo", "TOKEN")
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		if !updated.UpdatedAt.After(created.UpdatedAt.Time) {
+			t.Fatalf("expected updated_at to change on update, got %s and %s", created.UpdatedAt, updated.UpdatedAt)
+		}
+
+		if value, ok := s.SecretValue("test-org", "repo", "token"); !ok || value != "second" {
+			t.Fatalf("expected decrypted secret value %q, got %q", "second", value)
+		}
+
+		if _, err := client.Actions.CreateOrUpdateRepoSecret(ctx, "test-org", "repo", "OTHER", github.SecretRequest{KeyID: key.GetKeyID(), EncryptedValue: base64.StdEncoding.EncodeToString([]byte("plaintext"))}); statusCode(err) != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422 for an unencrypted secret, got %v", err)
+		}
+
+		if _, err := client.Actions.CreateOrUpdateOrgSecret(ctx, "test-org", "ORG", github.OrgSecretRequest{KeyID: key.GetKeyID(), EncryptedValue: encrypt("org"), Visibility: "selected", SelectedRepositoryIDs: []int64{repo.GetID()}}); err != nil {
+			t.Fatalf("failed to create organization secret: %v", err)
+		}
+
+		repos, _, err := client.Actions.ListSelectedReposForOrgSecret(ctx, "test-org", "ORG", nil)
+		if err != nil || len(repos.Repositories) != 1 {
+			t.Fatalf("expected a selected repository, got %v", err)
+		}
+
+		if _, err := client.Actions.CreateRepoVariable(ctx, "test-org", "repo", github.ActionsVariableCreateRequest{Name: "var", Value: "first"}); err != nil {
+			t.Fatalf("failed to create variable: %v", err)
+		}
+
+		if _, err := client.Actions.CreateRepoVariable(ctx, "test-org", "repo", github.ActionsVariableCreateRequest{Name: "VAR", Value: "first"}); statusCode(err) != http.StatusConflict {
+			t.Fatalf("expected 409 for a duplicate variable, got %v", err)
+		}
+
+		if _, err := client.Actions.UpdateRepoVariable(ctx, "test-org", "repo", "VAR", github.ActionsVariableUpdateRequest{Value: new("second")}); err != nil {
+			t.Fatalf("failed to update variable: %v", err)
+		}
+
+		variable, _, err := client.Actions.GetRepoVariable(ctx, "test-org", "repo", "var")
+		if err != nil || variable.Value != "second" {
+			t.Fatalf("expected updated variable value, got %v", err)
+		}
+
+		if _, err := client.Actions.DeleteRepoVariable(ctx, "test-org", "repo", "VAR"); err != nil {
+			t.Fatalf("failed to delete variable: %v", err)
+		}
+
+		if _, _, err := client.Actions.GetRepoVariable(ctx, "test-org", "repo", "VAR"); statusCode(err) != http.StatusNotFound {
+			t.Fatalf("expected 404 for a deleted variable, got %v", err)
+		}
+	})
+
+	t.Run("creates_runner_tokens_and_jit_configs", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewServer(t)
+		s.AddOrganization("test-org")
+		client := s.Client(t)
+		ctx := t.Context()
+
+		if _, _, err := client.Repositories.Create(ctx, "test-org", &github.Repository{Name: new("repo")}); err != nil {
+			t.Fatalf("failed to create repository: %v", err)
+		}
+
+		token, _, err := client.Actions.CreateRegistrationToken(ctx, "test-org", "repo")
+		if err != nil {
+			t.Fatalf("failed to create registration token: %v", err)
+		}
+		if token.GetToken() == "" || !token.GetExpiresAt().After(time.Now()) {
+			t.Fatalf("expected an unexpired registration token, got %v", token)
+		}
+
+		if _, _, err := client.Actions.CreateOrganizationRemoveToken(ctx, "test-org"); err != nil {
+			t.Fatalf("failed to create removal token: %v", err)
+		}
+
+		req := github.CreateJITConfigRequest{Name: "runner", RunnerGroupID: 1, Labels: []string{"self-hosted"}}
+		config, _, err := client.Actions.CreateOrgJITConfig(ctx, "test-org", req)
+		if err != nil {
+			t.Fatalf("failed to create JIT config: %v", err)
+		}
+		if config.GetEncodedJITConfig() == "" {
+			t.Fatal("expected an encoded JIT config")
+		}
+
+		runners := s.Runners("test-org", "")
+		if len(runners) != 1 || runners[0].GetID() != config.GetRunner().GetID() {
+			t.Fatalf("expected the runner to be registered with the organization, got %v", runners)
+		}
+
+		if _, _, err := client.Actions.CreateOrgJITConfig(ctx, "test-org", req); statusCode(err) != http.StatusConflict {
+			t.Fatalf("expected 409 for a duplicate runner name, got %v", err)
+		}
+
+		runner, _, err := client.Actions.GetOrganizationRunner(ctx, "test-org", config.GetRunner().GetID())
+		if err != nil {
+			t.Fatalf("failed to get runner: %v", err)
+		}
+		if runner.GetName() != "runner" {
+			t.Fatalf("expected runner named runner, got %v", runner)
+		}
+		if _, err := client.Actions.RemoveOrganizationRunner(ctx, "test-org", runner.GetID()); err != nil {
+			t.Fatalf("failed to remove runner: %v", err)
+		}
+		if _, _, err := client.Actions.GetOrganizationRunner(ctx, "test-org", runner.GetID()); statusCode(err) != http.StatusNotFound {
+			t.Fatalf("expected 404 for a removed runner, got %v", err)
+		}
+		if _, _, err := client.Actions.CreateRepoJITConfig(ctx, "test-org", "missing", req); statusCode(err) != http.StatusNotFound {
+			t.Fatalf("expected 404 for a missing repository, got %v", err)
+		}
+	})
+
+	t.Run("supports_conditional_requests", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewServer(t)
+		client := s.Client(t)
+		ctx := t.Context()
+
+		_, resp, err := client.Repositories.Create(ctx, "", &github.Repository{Name: new("repo")})
+		if err != nil {
+			t.Fatalf("failed to create repository: %v", err)
+		}
+
+		_, resp, err = client.Repositories.Get(ctx, ViewerLogin, "repo")
+		if err != nil {
+			t.Fatalf("failed to get repository: %v", err)
+		}
+
+		req, err := client.NewRequest(ctx, http.MethodGet, "repos/"+ViewerLogin+"/repo", nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		req.Header.Set("If-None-Match", resp.Header.Get("ETag"))
+
+		if _, err := client.Do(req, nil); statusCode(err) != http.StatusNotModified {
+			t.Fatalf("expected 304 for a matching etag, got %v", err)
+		}
+	})
+
+	t.Run("errors_on_unsupported_graphql_queries", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewServer(t)
+
+		var query struct {
+			Enterprise struct {
+				ID string
+			} `graphql:"enterprise(slug: \"test\")"`
+		}
+		if err := githubv4.NewEnterpriseClient(s.URL+"/graphql", nil).Query(t.Context(), &query, nil); err == nil {
+			t.Fatal("expected error for an unsupported query, got nil")
+		}
+	})
+}
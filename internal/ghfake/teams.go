@@ -0,0 +1,510 @@
+package ghfake
+
+import (
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// teamPermissions are the repository permissions granted to teams, in increasing order of access.
+var teamPermissions = []string{"pull", "triage", "push", "maintain", "admin"}
+
+// slugInvalidChars matches the characters replaced when deriving a team slug from its name.
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// team is the state of a team in the fake.
+type team struct {
+	team    *github.Team
+	org     string
+	members map[string]string
+	repos   map[int64]string
+}
+
+// teamSlug returns the slug GitHub derives from a team name.
+func teamSlug(name string) string {
+	return strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+func (s *Server) registerTeamRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /orgs/{org}/teams", s.createTeam)
+	mux.HandleFunc("GET /orgs/{org}/teams", s.listTeams)
+
+	for _, prefix := range []string{"/orgs/{org}/teams/{slug}", "/organizations/{org_id}/team/{team_id}"} {
+		mux.HandleFunc("GET "+prefix, s.getTeam)
+		mux.HandleFunc("PATCH "+prefix, s.editTeam)
+		mux.HandleFunc("DELETE "+prefix, s.deleteTeam)
+		mux.HandleFunc("GET "+prefix+"/members", s.listTeamMembers)
+		mux.HandleFunc("GET "+prefix+"/memberships/{user}", s.getTeamMembership)
+		mux.HandleFunc("PUT "+prefix+"/memberships/{user}", s.addTeamMembership)
+		mux.HandleFunc("DELETE "+prefix+"/memberships/{user}", s.removeTeamMembership)
+		mux.HandleFunc("GET "+prefix+"/repos", s.listTeamRepos)
+		mux.HandleFunc("GET "+prefix+"/repos/{owner}/{repo}", s.getTeamRepo)
+		mux.HandleFunc("PUT "+prefix+"/repos/{owner}/{repo}", s.addTeamRepo)
+		mux.HandleFunc("DELETE "+prefix+"/repos/{owner}/{repo}", s.removeTeamRepo)
+	}
+}
+
+// team returns the team from the request path, which identifies it either by organization login and slug or by organization and team ID.
+func (s *Server) team(r *http.Request) (*team, bool) {
+	if r.PathValue("team_id") != "" {
+		orgID, err := strconv.ParseInt(r.PathValue("org_id"), 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		id, err := strconv.ParseInt(r.PathValue("team_id"), 10, 64)
+		if err != nil {
+			return nil, false
+		}
+
+		t, ok := s.teams[id]
+		if !ok {
+			return nil, false
+		}
+
+		if account, _ := s.account(t.org); account.GetID() != orgID {
+			return nil, false
+		}
+
+		return t, true
+	}
+
+	return s.teamBySlug(r.PathValue("org"), r.PathValue("slug"))
+}
+
+// teamBySlug returns the team with the slug in the organization.
+func (s *Server) teamBySlug(org, slug string) (*team, bool) {
+	for _, t := range s.teams {
+		if strings.EqualFold(t.org, org) && t.team.GetSlug() == strings.ToLower(slug) {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
+
+// teamResponse returns the API representation of the team.
+func (s *Server) teamResponse(t *team) *github.Team {
+	resp := *t.team
+	resp.MembersCount = new(len(t.members))
+	resp.ReposCount = new(len(t.repos))
+	resp.Parent = nil
+
+	if parent, ok := s.teams[t.team.GetParent().GetID()]; ok {
+		resp.Parent = &github.Team{
+			ID:     parent.team.ID,
+			NodeID: parent.team.NodeID,
+			Name:   parent.team.Name,
+			Slug:   parent.team.Slug,
+		}
+	}
+
+	return &resp
+}
+
+// setTeamParent sets the parent of the team from the parent_team_id request field, reporting whether the parent is valid.
+func (s *Server) setTeamParent(t *team, parentID any) bool {
+	switch id := parentID.(type) {
+	case nil:
+		t.team.Parent = nil
+	case float64:
+		parent, ok := s.teams[int64(id)]
+		if !ok || parent.org != t.org || parent.team.GetID() == t.team.GetID() {
+			return false
+		}
+		t.team.Parent = &github.Team{ID: parent.team.ID}
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request) {
+	org, ok := s.account(r.PathValue("org"))
+	if !ok || org.GetType() != "Organization" {
+		writeNotFound(w)
+		return
+	}
+
+	req := map[string]any{}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var newTeam github.NewTeam
+	if err := merge(&newTeam, req); err != nil || newTeam.Name == "" {
+		writeValidationFailed(w, "Team", "name", "missing_field")
+		return
+	}
+
+	slug := teamSlug(newTeam.Name)
+	if _, ok := s.teamBySlug(org.GetLogin(), slug); ok {
+		writeValidationFailed(w, "Team", "name", "already_exists")
+		return
+	}
+
+	id := s.nextID()
+	t := &team{
+		team: &github.Team{
+			ID:                  new(id),
+			NodeID:              new(nodeID("Team", id)),
+			Name:                new(newTeam.Name),
+			Slug:                new(slug),
+			Description:         new(newTeam.GetDescription()),
+			Privacy:             new("secret"),
+			NotificationSetting: new("notifications_enabled"),
+			Permission:          new("pull"),
+			URL:                 new(s.URL + restAPIPrefix + "/organizations/" + strconv.FormatInt(org.GetID(), 10) + "/team/" + strconv.FormatInt(id, 10)),
+			HTMLURL:             new(s.URL + "/orgs/" + org.GetLogin() + "/teams/" + slug),
+			Organization:        &github.Organization{Login: org.Login, ID: org.ID},
+		},
+		org:     org.GetLogin(),
+		members: map[string]string{strings.ToLower(ViewerLogin): "maintainer"},
+		repos:   map[int64]string{},
+	}
+	if newTeam.Privacy != nil {
+		t.team.Privacy = newTeam.Privacy
+	}
+	if newTeam.NotificationSetting != nil {
+		t.team.NotificationSetting = newTeam.NotificationSetting
+	}
+
+	if parentID, ok := req["parent_team_id"]; ok {
+		if !s.setTeamParent(t, parentID) {
+			writeValidationFailed(w, "Team", "parent_team_id", "invalid")
+			return
+		}
+		t.team.Privacy = new("closed")
+	}
+
+	for _, login := range newTeam.Maintainers {
+		t.members[strings.ToLower(login)] = "maintainer"
+	}
+	for _, name := range newTeam.RepoNames {
+		if repo, ok := s.repos[strings.ToLower(name)]; ok {
+			t.repos[repo.repo.GetID()] = "pull"
+		}
+	}
+
+	s.teams[id] = t
+
+	writeJSON(w, r, http.StatusCreated, s.teamResponse(t))
+}
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.organization(r.PathValue("org")); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	teams := []*github.Team{}
+	for _, id := range slices.Sorted(maps.Keys(s.teams)) {
+		if t := s.teams[id]; strings.EqualFold(t.org, r.PathValue("org")) {
+			teams = append(teams, s.teamResponse(t))
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, teams)
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, s.teamResponse(t))
+}
+
+func (s *Server) editTeam(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	patch := map[string]any{}
+	if !decode(w, r, &patch) {
+		return
+	}
+
+	updated := *t
+	updated.team = new(*t.team)
+
+	if parentID, ok := patch["parent_team_id"]; ok {
+		if !s.setTeamParent(&updated, parentID) {
+			writeValidationFailed(w, "Team", "parent_team_id", "invalid")
+			return
+		}
+		delete(patch, "parent_team_id")
+	}
+
+	if err := merge(updated.team, patch); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return
+	}
+
+	if slug := teamSlug(updated.team.GetName()); slug != t.team.GetSlug() {
+		if _, ok := s.teamBySlug(t.org, slug); ok {
+			writeValidationFailed(w, "Team", "name", "already_exists")
+			return
+		}
+		updated.team.Slug = new(slug)
+		updated.team.HTMLURL = new(s.URL + "/orgs/" + t.org + "/teams/" + slug)
+	}
+
+	t.team = updated.team
+
+	writeJSON(w, r, http.StatusOK, s.teamResponse(t))
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	s.removeTeam(t.team.GetID())
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// removeTeam removes the team and its child teams.
+func (s *Server) removeTeam(id int64) {
+	delete(s.teams, id)
+
+	for childID, child := range s.teams {
+		if child.team.GetParent().GetID() == id {
+			s.removeTeam(childID)
+		}
+	}
+}
+
+func (s *Server) listTeamMembers(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	role := r.URL.Query().Get("role")
+
+	members := []*github.User{}
+	for _, login := range slices.Sorted(maps.Keys(t.members)) {
+		if role == "" || role == "all" || role == t.members[login] {
+			if account, ok := s.account(login); ok {
+				members = append(members, account)
+			}
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, members)
+}
+
+// teamMembership returns the team membership response for the role.
+func teamMembership(role string) *github.Membership {
+	return &github.Membership{Role: new(role), State: new("active")}
+}
+
+func (s *Server) getTeamMembership(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	role, ok := t.members[strings.ToLower(r.PathValue("user"))]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, teamMembership(role))
+}
+
+func (s *Server) addTeamMembership(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	if _, ok := s.account(r.PathValue("user")); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req struct {
+		Role string `json:"role"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	switch req.Role {
+	case "":
+		req.Role = "member"
+	case "member", "maintainer":
+	default:
+		writeValidationFailed(w, "TeamMember", "role", "invalid")
+		return
+	}
+
+	t.members[strings.ToLower(r.PathValue("user"))] = req.Role
+
+	writeJSON(w, r, http.StatusOK, teamMembership(req.Role))
+}
+
+func (s *Server) removeTeamMembership(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	login := strings.ToLower(r.PathValue("user"))
+	if _, ok := t.members[login]; !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(t.members, login)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// teamRepository returns the repository as listed for a team, with the permissions granted to the team.
+func teamRepository(repo *github.Repository, permission string) *github.Repository {
+	resp := *repo
+
+	resp.Permissions = repositoryPermissions(permission)
+
+	switch permission {
+	case "pull":
+		resp.RoleName = new("read")
+	case "push":
+		resp.RoleName = new("write")
+	default:
+		resp.RoleName = new(permission)
+	}
+
+	return &resp
+}
+
+// repositoryByID returns the repository with the ID.
+func (s *Server) repositoryByID(id int64) (*repository, bool) {
+	for _, repo := range s.repos {
+		if repo.repo.GetID() == id {
+			return repo, true
+		}
+	}
+
+	return nil, false
+}
+
+func (s *Server) listTeamRepos(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	repos := []*github.Repository{}
+	for _, id := range slices.Sorted(maps.Keys(t.repos)) {
+		if repo, ok := s.repositoryByID(id); ok {
+			repos = append(repos, teamRepository(repo.repo, t.repos[id]))
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, repos)
+}
+
+func (s *Server) getTeamRepo(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	permission, ok := t.repos[repo.repo.GetID()]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	if !strings.Contains(r.Header.Get("Accept"), "repository+json") {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, teamRepository(repo.repo, permission))
+}
+
+func (s *Server) addTeamRepo(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	repo, ok := s.repository(r)
+	if !ok || !strings.EqualFold(repo.repo.GetOwner().GetLogin(), t.org) {
+		writeNotFound(w)
+		return
+	}
+
+	var req struct {
+		Permission string `json:"permission"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	switch req.Permission {
+	case "":
+		req.Permission = "push"
+	case "read":
+		req.Permission = "pull"
+	case "write":
+		req.Permission = "push"
+	}
+
+	if !slices.Contains(teamPermissions, req.Permission) {
+		writeValidationFailed(w, "TeamRepository", "permission", "invalid")
+		return
+	}
+
+	t.repos[repo.repo.GetID()] = req.Permission
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) removeTeamRepo(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	repo, ok := s.repository(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	delete(t.repos, repo.repo.GetID())
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -14,9 +14,10 @@ var (
 
 func main() {
 	opts := &plugin.ServeOpts{
-		ProviderAddr: "registry.terraform.io/integrations/github",
-		ProviderFunc: github.NewProvider(version, commit),
+		ProviderAddr:     "registry.terraform.io/integrations/github",
+		GRPCProviderFunc: github.NewProviderServer(version, commit),
 	}
 
 	plugin.Serve(opts)
+	github.FlushTelemetry()
 }